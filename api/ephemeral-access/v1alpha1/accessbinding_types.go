@@ -17,9 +17,13 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
+	"slices"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/expr-lang/expr"
 
@@ -68,6 +72,181 @@ type AccessBindingSpec struct {
 	// FriendlyName defines a name for this role
 	// +kubebuilder:validation:MaxLength=512
 	FriendlyName *string `json:"friendlyName,omitempty"`
+	// Duration defines the default value and the allowed range for the
+	// access duration requested by users through this binding. If not
+	// provided, the backend default duration is used for all requests.
+	// +optional
+	Duration *DurationPolicy `json:"duration,omitempty"`
+	// Extension defines if and how much users are allowed to extend the
+	// duration of granted accesses. Extensions are not allowed if not provided.
+	// +optional
+	Extension *ExtensionPolicy `json:"extension,omitempty"`
+	// Justification defines if users must explain why they need the access
+	// when requesting the role.
+	// +optional
+	Justification *JustificationPolicy `json:"justification,omitempty"`
+	// Approval defines the approvers that must agree before the access is
+	// granted. If not provided, the access is granted without approvals
+	// (subject to the configured plugin).
+	// +optional
+	Approval *ApprovalPolicy `json:"approval,omitempty"`
+	// BreakGlass allows users to request emergency access through this
+	// binding. Break-glass access is granted immediately, bypassing
+	// approvals and the plugin decision. Not allowed if not provided.
+	// +optional
+	BreakGlass *BreakGlassPolicy `json:"breakGlass,omitempty"`
+	// Notifications defines the webhooks notified about the lifecycle of
+	// the AccessRequests created from this binding. They are notified in
+	// addition to the webhook configured globally in the controller.
+	// +optional
+	Notifications *NotificationPolicy `json:"notifications,omitempty"`
+	// Plugins is the list of the controller plugin names that must grant
+	// the AccessRequests created from this binding. Overrides the plugins
+	// selected in the RoleTemplate. All configured plugins are invoked if
+	// not provided.
+	// +optional
+	Plugins []string `json:"plugins,omitempty"`
+}
+
+// NotificationEvent defines the AccessRequest lifecycle events that can be
+// notified
+// +kubebuilder:validation:Enum=requested;scheduled;granted;denied;expiring;expired;revoked;cancelled;invalid;timeout
+type NotificationEvent string
+
+const (
+	NotificationRequested NotificationEvent = "requested"
+	NotificationScheduled NotificationEvent = "scheduled"
+	NotificationGranted   NotificationEvent = "granted"
+	NotificationDenied    NotificationEvent = "denied"
+	// NotificationExpiring is sent before the access expires
+	NotificationExpiring  NotificationEvent = "expiring"
+	NotificationExpired   NotificationEvent = "expired"
+	NotificationRevoked   NotificationEvent = "revoked"
+	NotificationCancelled NotificationEvent = "cancelled"
+	NotificationInvalid   NotificationEvent = "invalid"
+	NotificationTimeout   NotificationEvent = "timeout"
+)
+
+// NotificationPolicy defines how the lifecycle of the AccessRequests created
+// from an AccessBinding is notified
+type NotificationPolicy struct {
+	// Webhooks is the list of HTTP endpoints receiving the notifications
+	// +kubebuilder:validation:MinItems=1
+	Webhooks []WebhookNotification `json:"webhooks"`
+	// ExpiryWarning defines how long before the access expires the
+	// "expiring" event is notified. Overrides the global configuration.
+	// +optional
+	ExpiryWarning *metav1.Duration `json:"expiryWarning,omitempty"`
+}
+
+// WebhookNotification defines an HTTP endpoint receiving notifications
+type WebhookNotification struct {
+	// URL is the endpoint receiving the notifications with a POST request
+	// +kubebuilder:validation:MinLength=1
+	URL string `json:"url"`
+	// Events is the list of events notified to this webhook. All events
+	// are notified if not provided.
+	// +optional
+	Events []NotificationEvent `json:"events,omitempty"`
+	// Template is the Go template used to render the JSON payload. A
+	// default payload is sent if not provided.
+	// +optional
+	Template string `json:"template,omitempty"`
+	// SecretKey is the key of the controller notification Secret holding
+	// the secret used to sign the payload with HMAC-SHA256. The Secret is
+	// managed by the controller administrators in the controller namespace.
+	// Payloads are not signed if not provided.
+	// +optional
+	SecretKey string `json:"secretKey,omitempty"`
+}
+
+// BreakGlassPolicy defines the constraints applied to emergency access
+// requested through an AccessBinding
+type BreakGlassPolicy struct {
+	// MaxDuration is the longest duration allowed for break-glass access
+	// +kubebuilder:validation:Required
+	MaxDuration metav1.Duration `json:"maxDuration"`
+}
+
+// DurationPolicy defines the duration constraints applied to AccessRequests
+// created from an AccessBinding
+// +kubebuilder:validation:XValidation:rule="!has(self.min) || !has(self.max) || duration(self.min) <= duration(self.max)",message="min must not be greater than max"
+// +kubebuilder:validation:XValidation:rule="!has(self.default) || !has(self.min) || duration(self.default) >= duration(self.min)",message="default must not be shorter than min"
+// +kubebuilder:validation:XValidation:rule="!has(self.default) || !has(self.max) || duration(self.default) <= duration(self.max)",message="default must not be longer than max"
+type DurationPolicy struct {
+	// Default is the duration assigned to the AccessRequest when users
+	// don't specify one
+	// +optional
+	Default *metav1.Duration `json:"default,omitempty"`
+	// Min is the shortest duration users are allowed to request
+	// +optional
+	Min *metav1.Duration `json:"min,omitempty"`
+	// Max is the longest duration users are allowed to request
+	// +optional
+	Max *metav1.Duration `json:"max,omitempty"`
+}
+
+// ExtensionPolicy defines the constraints applied when extending the duration
+// of granted AccessRequests created from an AccessBinding
+type ExtensionPolicy struct {
+	// MaxDuration is the maximum amount of time a single extension can add
+	// to the access expiration
+	// +kubebuilder:validation:Required
+	MaxDuration metav1.Duration `json:"maxDuration"`
+	// MaxTotalDuration is the maximum lifetime of the access, including the
+	// initial duration and all extensions
+	// +kubebuilder:validation:Required
+	MaxTotalDuration metav1.Duration `json:"maxTotalDuration"`
+}
+
+// JustificationPolicy defines the justification and ticket reference
+// requirements for AccessRequests created from an AccessBinding
+type JustificationPolicy struct {
+	// Required defines if a justification must be provided
+	// +optional
+	Required bool `json:"required,omitempty"`
+	// TicketRefRequired defines if a ticket reference must be provided
+	// +optional
+	TicketRefRequired bool `json:"ticketRefRequired,omitempty"`
+	// TicketRefPattern is a regular expression that the whole ticket
+	// reference must match when provided (e.g. OPS-\d+)
+	// +optional
+	TicketRefPattern string `json:"ticketRefPattern,omitempty"`
+}
+
+// ApprovalPolicy defines who must approve AccessRequests and how many
+// approvals are necessary before the access is granted
+type ApprovalPolicy struct {
+	// Approvers is the list of groups allowed to approve or reject the
+	// AccessRequests
+	// +kubebuilder:validation:MinItems=1
+	Approvers []string `json:"approvers"`
+	// RequiredApprovals is the number of distinct approvals necessary to
+	// grant the access
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RequiredApprovals int `json:"requiredApprovals,omitempty"`
+}
+
+// IsApprover returns true if at least one of the given groups is listed
+// as approver in this policy.
+func (p *ApprovalPolicy) IsApprover(groups []string) bool {
+	for _, group := range groups {
+		if slices.Contains(p.Approvers, group) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetRequiredApprovals returns the number of approvals required by this
+// policy. At least one approval is always required.
+func (p *ApprovalPolicy) GetRequiredApprovals() int {
+	if p.RequiredApprovals < 1 {
+		return 1
+	}
+	return p.RequiredApprovals
 }
 
 // RoleTemplateReference is a reference to a RoleTemplate
@@ -83,26 +262,39 @@ func (ab *AccessBinding) RenderSubjects(app, project *unstructured.Unstructured)
 		return nil, nil
 	}
 
-	values := map[string]interface{}{
-		"app":         app.Object,
-		"application": app.Object,
-		"project":     project.Object,
+	allowed, err := ab.EvaluateCondition(app, project)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		// No need to render template, condition is false
+		return nil, nil
 	}
+	return ab.RenderSubjectTemplates(app, project)
+}
 
-	if ab.Spec.If != nil {
-		out, err := expr.Eval(*ab.Spec.If, values)
-		if err != nil {
-			return nil, fmt.Errorf("failed to evaluate binding condition '%s': %w", *ab.Spec.If, err)
-		}
-		switch condResult := out.(type) {
-		case bool:
-			if !condResult {
-				// No need to render template, condition is false
-				return nil, nil
-			}
-		default:
-			return nil, fmt.Errorf("binding condition '%s' evaluated to non-boolean value", *ab.Spec.If)
-		}
+// EvaluateCondition evaluates the If condition against the given application
+// and project. Returns true if the binding has no condition.
+func (ab *AccessBinding) EvaluateCondition(app, project *unstructured.Unstructured) (bool, error) {
+	if ab.Spec.If == nil {
+		return true, nil
+	}
+	out, err := expr.Eval(*ab.Spec.If, templateValues(app, project))
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate binding condition '%s': %w", *ab.Spec.If, err)
+	}
+	condResult, ok := out.(bool)
+	if !ok {
+		return false, fmt.Errorf("binding condition '%s' evaluated to non-boolean value", *ab.Spec.If)
+	}
+	return condResult, nil
+}
+
+// RenderSubjectTemplates renders the access bindings subjects without
+// evaluating the If condition.
+func (ab *AccessBinding) RenderSubjectTemplates(app, project *unstructured.Unstructured) ([]string, error) {
+	if len(ab.Spec.Subjects) == 0 {
+		return nil, nil
 	}
 
 	subStr := strings.Join(ab.Spec.Subjects, "\n")
@@ -110,7 +302,7 @@ func (ab *AccessBinding) RenderSubjects(app, project *unstructured.Unstructured)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing AccessBinding subjects: %w", err)
 	}
-	p, err := ab.execTemplate(subTmpl, values)
+	p, err := ab.execTemplate(subTmpl, templateValues(app, project))
 	if err != nil {
 		return nil, fmt.Errorf("error rendering AccessBinding subjects: %w", err)
 	}
@@ -119,6 +311,128 @@ func (ab *AccessBinding) RenderSubjects(app, project *unstructured.Unstructured)
 	return subjects, nil
 }
 
+// templateValues returns the values available to the AccessBinding condition
+// and subject templates.
+func templateValues(app, project *unstructured.Unstructured) map[string]interface{} {
+	return map[string]interface{}{
+		"app":         app.Object,
+		"application": app.Object,
+		"project":     project.Object,
+	}
+}
+
+// ErrInvalidDefaultDuration is returned by ResolveDuration when the binding
+// default duration is out of the range allowed by the binding itself.
+var ErrInvalidDefaultDuration = errors.New("invalid binding default duration")
+
+// ResolveDuration returns the duration to be assigned to an AccessRequest
+// created with this binding. If requested is zero, the binding default
+// duration is used. If the binding has no default, the given fallback is used
+// after being adjusted to fit the binding boundaries. An error is returned if
+// the requested duration or the binding default is out of the range allowed by
+// this binding. The binding misconfiguration is reported with
+// ErrInvalidDefaultDuration.
+func (ab *AccessBinding) ResolveDuration(requested, fallback time.Duration) (time.Duration, error) {
+	policy := ab.Spec.Duration
+	if requested != 0 {
+		return requested, ab.ValidateDuration(requested)
+	}
+	if policy == nil {
+		return fallback, nil
+	}
+	if policy.Default != nil {
+		err := ab.ValidateDuration(policy.Default.Duration)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %w", ErrInvalidDefaultDuration, err)
+		}
+		return policy.Default.Duration, nil
+	}
+	if policy.Min != nil && fallback < policy.Min.Duration {
+		return policy.Min.Duration, nil
+	}
+	if policy.Max != nil && fallback > policy.Max.Duration {
+		return policy.Max.Duration, nil
+	}
+	return fallback, nil
+}
+
+// ValidateDuration returns an error if the given duration isn't within the
+// boundaries defined by this binding.
+func (ab *AccessBinding) ValidateDuration(d time.Duration) error {
+	if d <= 0 {
+		return fmt.Errorf("duration must be positive: %s", d)
+	}
+	policy := ab.Spec.Duration
+	if policy == nil {
+		return nil
+	}
+	if policy.Min != nil && d < policy.Min.Duration {
+		return fmt.Errorf("duration %s is shorter than the minimum allowed (%s)", d, policy.Min.Duration)
+	}
+	if policy.Max != nil && d > policy.Max.Duration {
+		return fmt.Errorf("duration %s is longer than the maximum allowed (%s)", d, policy.Max.Duration)
+	}
+	return nil
+}
+
+// ValidateJustification returns an error if the given justification and
+// ticket reference don't satisfy the requirements defined in this binding.
+func (ab *AccessBinding) ValidateJustification(justification, ticketRef string) error {
+	policy := ab.Spec.Justification
+	if policy == nil {
+		return nil
+	}
+	if policy.Required && strings.TrimSpace(justification) == "" {
+		return fmt.Errorf("justification is required")
+	}
+	if policy.TicketRefRequired && strings.TrimSpace(ticketRef) == "" {
+		return fmt.Errorf("ticket reference is required")
+	}
+	if policy.TicketRefPattern != "" && ticketRef != "" {
+		re, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", policy.TicketRefPattern))
+		if err != nil {
+			return fmt.Errorf("error compiling ticket reference pattern %q: %w", policy.TicketRefPattern, err)
+		}
+		if !re.MatchString(ticketRef) {
+			return fmt.Errorf("ticket reference %q does not match the pattern %q", ticketRef, policy.TicketRefPattern)
+		}
+	}
+	return nil
+}
+
+// ValidateExtension returns an error if extending the given AccessRequest by
+// the given duration isn't allowed by this binding.
+func (ab *AccessBinding) ValidateExtension(ar *AccessRequest, d time.Duration) error {
+	policy := ab.Spec.Extension
+	if policy == nil {
+		return fmt.Errorf("extensions are not allowed for role %s", ab.Spec.RoleTemplateRef.Name)
+	}
+	if d <= 0 {
+		return fmt.Errorf("extension duration must be positive: %s", d)
+	}
+	if d > policy.MaxDuration.Duration {
+		return fmt.Errorf("extension duration %s is longer than the maximum allowed (%s)", d, policy.MaxDuration.Duration)
+	}
+	total := ar.Spec.Duration.Duration + ar.ExtendedDuration() + d
+	if total > policy.MaxTotalDuration.Duration {
+		return fmt.Errorf("total access duration %s would exceed the maximum allowed (%s)", total, policy.MaxTotalDuration.Duration)
+	}
+	return nil
+}
+
+// ValidateBreakGlass returns an error if this binding doesn't allow break-glass
+// access for the given duration.
+func (ab *AccessBinding) ValidateBreakGlass(d time.Duration) error {
+	policy := ab.Spec.BreakGlass
+	if policy == nil {
+		return fmt.Errorf("break-glass access is not allowed for role %s", ab.Spec.RoleTemplateRef.Name)
+	}
+	if d > policy.MaxDuration.Duration {
+		return fmt.Errorf("duration %s is longer than the maximum allowed for break-glass access (%s)", d, policy.MaxDuration.Duration)
+	}
+	return nil
+}
+
 func (ab *AccessBinding) execTemplate(
 	tmpl *template.Template,
 	values any,
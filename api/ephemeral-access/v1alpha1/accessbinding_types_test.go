@@ -3,6 +3,7 @@ package v1alpha1_test
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	argocd "github.com/argoproj-labs/argocd-ephemeral-access/api/argoproj/v1alpha1"
 	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
@@ -115,3 +116,373 @@ func TestAccessBinding_RenderSubjects(t *testing.T) {
 		})
 	}
 }
+
+func TestAccessBinding_EvaluateCondition(t *testing.T) {
+	app, err := utils.ToUnstructured(&argocd.Application{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+	})
+	require.NoError(t, err)
+	project, err := utils.ToUnstructured(&argocd.AppProject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "some-project",
+		},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		If            *string
+		expected      bool
+		errorContains string
+	}{
+		{
+			name:     "return true if condition is not defined",
+			expected: true,
+		},
+		{
+			name:     "return true if condition is true",
+			If:       ptr.To(`project.metadata.name == "some-project"`),
+			expected: true,
+		},
+		{
+			name:     "return false if condition is false",
+			If:       ptr.To(`app.metadata.name == "other"`),
+			expected: false,
+		},
+		{
+			name:          "return error if condition is invalid",
+			If:            ptr.To("invalid.golang"),
+			errorContains: "failed to evaluate binding condition",
+		},
+		{
+			name:          "return error if condition is not a boolean",
+			If:            ptr.To("1 + 1"),
+			errorContains: "evaluated to non-boolean value",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ab := &api.AccessBinding{
+				Spec: api.AccessBindingSpec{
+					If: tt.If,
+				},
+			}
+			got, err := ab.EvaluateCondition(app, project)
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+				assert.False(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestAccessBinding_ResolveDuration(t *testing.T) {
+	fallback := 4 * time.Hour
+	policy := &api.DurationPolicy{
+		Min: &metav1.Duration{Duration: 30 * time.Minute},
+		Max: &metav1.Duration{Duration: 8 * time.Hour},
+	}
+	tests := []struct {
+		name          string
+		policy        *api.DurationPolicy
+		requested     time.Duration
+		fallback      time.Duration
+		expected      time.Duration
+		errorContains string
+	}{
+		{
+			name:     "return fallback if binding has no duration policy",
+			fallback: fallback,
+			expected: fallback,
+		},
+		{
+			name:      "return requested if binding has no duration policy",
+			requested: 10 * time.Minute,
+			fallback:  fallback,
+			expected:  10 * time.Minute,
+		},
+		{
+			name: "return binding default if no duration is requested",
+			policy: &api.DurationPolicy{
+				Default: &metav1.Duration{Duration: time.Hour},
+			},
+			fallback: fallback,
+			expected: time.Hour,
+		},
+		{
+			name: "return error if binding default is longer than max",
+			policy: &api.DurationPolicy{
+				Default: &metav1.Duration{Duration: 12 * time.Hour},
+				Max:     &metav1.Duration{Duration: 8 * time.Hour},
+			},
+			fallback:      fallback,
+			errorContains: "invalid binding default duration",
+		},
+		{
+			name: "return error if binding default is shorter than min",
+			policy: &api.DurationPolicy{
+				Default: &metav1.Duration{Duration: 10 * time.Minute},
+				Min:     &metav1.Duration{Duration: 30 * time.Minute},
+			},
+			fallback:      fallback,
+			errorContains: "shorter than the minimum allowed",
+		},
+		{
+			name:      "return requested if within range",
+			policy:    policy,
+			requested: time.Hour,
+			fallback:  fallback,
+			expected:  time.Hour,
+		},
+		{
+			name:     "return min if fallback is shorter than allowed",
+			policy:   policy,
+			fallback: time.Minute,
+			expected: 30 * time.Minute,
+		},
+		{
+			name:     "return max if fallback is longer than allowed",
+			policy:   policy,
+			fallback: 24 * time.Hour,
+			expected: 8 * time.Hour,
+		},
+		{
+			name:          "return error if requested is shorter than min",
+			policy:        policy,
+			requested:     time.Minute,
+			fallback:      fallback,
+			errorContains: "shorter than the minimum allowed",
+		},
+		{
+			name:          "return error if requested is longer than max",
+			policy:        policy,
+			requested:     9 * time.Hour,
+			fallback:      fallback,
+			errorContains: "longer than the maximum allowed",
+		},
+		{
+			name:          "return error if requested is negative",
+			requested:     -time.Hour,
+			fallback:      fallback,
+			errorContains: "must be positive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ab := &api.AccessBinding{
+				Spec: api.AccessBindingSpec{
+					Duration: tt.policy,
+				},
+			}
+			got, err := ab.ResolveDuration(tt.requested, tt.fallback)
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestAccessBinding_ValidateExtension(t *testing.T) {
+	policy := &api.ExtensionPolicy{
+		MaxDuration:      metav1.Duration{Duration: time.Hour},
+		MaxTotalDuration: metav1.Duration{Duration: 3 * time.Hour},
+	}
+	granted := func(d time.Duration) api.AccessExtensionStatus {
+		return api.AccessExtensionStatus{Duration: metav1.Duration{Duration: d}, RequestState: api.GrantedStatus}
+	}
+	denied := func(d time.Duration) api.AccessExtensionStatus {
+		return api.AccessExtensionStatus{Duration: metav1.Duration{Duration: d}, RequestState: api.DeniedStatus}
+	}
+	tests := []struct {
+		name          string
+		policy        *api.ExtensionPolicy
+		processed     []api.AccessExtensionStatus
+		extension     time.Duration
+		errorContains string
+	}{
+		{
+			name:      "allow extension within limits",
+			policy:    policy,
+			extension: time.Hour,
+		},
+		{
+			name:      "allow extension reaching the maximum total",
+			policy:    policy,
+			processed: []api.AccessExtensionStatus{granted(time.Hour)},
+			extension: time.Hour,
+		},
+		{
+			name:      "ignore denied extensions in the total",
+			policy:    policy,
+			processed: []api.AccessExtensionStatus{granted(time.Hour), denied(time.Hour)},
+			extension: time.Hour,
+		},
+		{
+			name:          "return error if binding has no extension policy",
+			extension:     time.Hour,
+			errorContains: "extensions are not allowed",
+		},
+		{
+			name:          "return error if extension is not positive",
+			policy:        policy,
+			extension:     0,
+			errorContains: "must be positive",
+		},
+		{
+			name:          "return error if extension is longer than max",
+			policy:        policy,
+			extension:     2 * time.Hour,
+			errorContains: "longer than the maximum allowed",
+		},
+		{
+			name:          "return error if total duration exceeds the maximum",
+			policy:        policy,
+			processed:     []api.AccessExtensionStatus{granted(time.Hour), granted(time.Hour)},
+			extension:     time.Minute,
+			errorContains: "would exceed the maximum allowed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ab := &api.AccessBinding{
+				Spec: api.AccessBindingSpec{
+					RoleTemplateRef: api.RoleTemplateReference{Name: "some-role"},
+					Extension:       tt.policy,
+				},
+			}
+			ar := &api.AccessRequest{
+				Spec: api.AccessRequestSpec{
+					Duration: metav1.Duration{Duration: time.Hour},
+				},
+				Status: api.AccessRequestStatus{
+					Extensions: tt.processed,
+				},
+			}
+			err := ab.ValidateExtension(ar, tt.extension)
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestAccessBinding_ValidateJustification(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        *api.JustificationPolicy
+		justification string
+		ticketRef     string
+		errorContains string
+	}{
+		{
+			name: "allow empty values if binding has no justification policy",
+		},
+		{
+			name:          "allow justification and ticket reference when required",
+			policy:        &api.JustificationPolicy{Required: true, TicketRefRequired: true},
+			justification: "some reason",
+			ticketRef:     "OPS-123",
+		},
+		{
+			name:      "allow ticket reference matching the pattern",
+			policy:    &api.JustificationPolicy{TicketRefPattern: `OPS-\d+`},
+			ticketRef: "OPS-123",
+		},
+		{
+			name:   "allow empty ticket reference when not required",
+			policy: &api.JustificationPolicy{TicketRefPattern: `OPS-\d+`},
+		},
+		{
+			name:          "return error if justification is required",
+			policy:        &api.JustificationPolicy{Required: true},
+			justification: "  ",
+			errorContains: "justification is required",
+		},
+		{
+			name:          "return error if ticket reference is required",
+			policy:        &api.JustificationPolicy{TicketRefRequired: true},
+			justification: "some reason",
+			errorContains: "ticket reference is required",
+		},
+		{
+			name:          "return error if ticket reference only partially matches the pattern",
+			policy:        &api.JustificationPolicy{TicketRefPattern: `OPS-\d+`},
+			ticketRef:     "OPS-123-abc",
+			errorContains: "does not match the pattern",
+		},
+		{
+			name:          "return error if pattern is invalid",
+			policy:        &api.JustificationPolicy{TicketRefPattern: `OPS-(\d+`},
+			ticketRef:     "OPS-123",
+			errorContains: "error compiling ticket reference pattern",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ab := &api.AccessBinding{
+				Spec: api.AccessBindingSpec{
+					Justification: tt.policy,
+				},
+			}
+			err := ab.ValidateJustification(tt.justification, tt.ticketRef)
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestAccessBinding_ValidateBreakGlass(t *testing.T) {
+	policy := &api.BreakGlassPolicy{MaxDuration: metav1.Duration{Duration: time.Hour}}
+	tests := []struct {
+		name          string
+		policy        *api.BreakGlassPolicy
+		duration      time.Duration
+		errorContains string
+	}{
+		{
+			name:     "allow break-glass within the maximum duration",
+			policy:   policy,
+			duration: time.Hour,
+		},
+		{
+			name:          "return error if binding has no break-glass policy",
+			duration:      time.Hour,
+			errorContains: "break-glass access is not allowed for role some-role",
+		},
+		{
+			name:          "return error if duration is longer than max",
+			policy:        policy,
+			duration:      2 * time.Hour,
+			errorContains: "longer than the maximum allowed for break-glass access",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ab := &api.AccessBinding{
+				Spec: api.AccessBindingSpec{
+					RoleTemplateRef: api.RoleTemplateReference{Name: "some-role"},
+					BreakGlass:      tt.policy,
+				},
+			}
+			err := ab.ValidateBreakGlass(tt.duration)
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -25,7 +25,7 @@ import (
 
 // Status defines the different stages a given access request can be
 // at a given time.
-// +kubebuilder:validation:Enum=initiated;requested;granted;expired;denied;invalid;timeout
+// +kubebuilder:validation:Enum=initiated;requested;scheduled;granted;expired;denied;invalid;timeout;revoked;cancelled
 type Status string
 
 const (
@@ -35,6 +35,10 @@ const (
 	// RequestedStatus is the stage that defines the access request as pending
 	RequestedStatus Status = "requested"
 
+	// ScheduledStatus is the stage that defines the access request as allowed
+	// but waiting for the scheduled start time to be granted
+	ScheduledStatus Status = "scheduled"
+
 	// GrantedStatus is the stage that defines the access request as granted
 	GrantedStatus Status = "granted"
 
@@ -49,6 +53,43 @@ const (
 
 	// TimeoutStatus is the stage that defines the access request has timed out
 	TimeoutStatus Status = "timeout"
+
+	// RevokedStatus is the stage that defines the access request as revoked
+	// by the subject before the expiration time
+	RevokedStatus Status = "revoked"
+
+	// CancelledStatus is the stage that defines the access request as
+	// cancelled by the subject before being granted
+	CancelledStatus Status = "cancelled"
+)
+
+const (
+	// BreakGlassAnnotation is added to break-glass AccessRequests so they
+	// can be easily identified during reviews
+	BreakGlassAnnotation = "ephemeral-access.argoproj-labs.io/break-glass"
+)
+
+// Annotations written in the Argo CD Application targeted by the
+// AccessRequests. They describe the last notified AccessRequest event and
+// are meant to be used in Argo CD Notifications triggers and templates.
+const (
+	// AccessEventAnnotation is the last notified event (e.g. granted)
+	AccessEventAnnotation = "ephemeral-access.argoproj-labs.io/access-event"
+	// AccessEventIDAnnotation uniquely identifies the last notified event.
+	// It can be used in the trigger's oncePer field.
+	AccessEventIDAnnotation = "ephemeral-access.argoproj-labs.io/access-event-id"
+	// AccessRequestAnnotation is the AccessRequest in the format
+	// <namespace>/<name>
+	AccessRequestAnnotation = "ephemeral-access.argoproj-labs.io/access-request"
+	// AccessUserAnnotation is the username of the AccessRequest subject
+	AccessUserAnnotation = "ephemeral-access.argoproj-labs.io/access-user"
+	// AccessRoleAnnotation is the name of the requested RoleTemplate
+	AccessRoleAnnotation = "ephemeral-access.argoproj-labs.io/access-role"
+	// AccessExpiresAtAnnotation is the time the access expires in RFC3339.
+	// Removed if the access isn't granted.
+	AccessExpiresAtAnnotation = "ephemeral-access.argoproj-labs.io/access-expires-at"
+	// AccessMessageAnnotation contains the event details
+	AccessMessageAnnotation = "ephemeral-access.argoproj-labs.io/access-message"
 )
 
 // AccessRequestSpec defines the desired state of AccessRequest
@@ -70,6 +111,107 @@ type AccessRequestSpec struct {
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
 	Subject Subject `json:"subject"`
+	// AccessBindingRef is the reference to the AccessBinding that authorized
+	// this access request. When provided, the controller will enforce the
+	// constraints defined in the binding.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	AccessBindingRef *AccessBindingReference `json:"accessBindingRef,omitempty"`
+	// Justification is the reason provided by the subject for requesting
+	// the elevated access
+	// +optional
+	// +kubebuilder:validation:MaxLength=1024
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	Justification string `json:"justification,omitempty"`
+	// TicketRef is the identifier of the ticket (incident, change request,
+	// etc) associated with this access request
+	// +optional
+	// +kubebuilder:validation:MaxLength=256
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	TicketRef string `json:"ticketRef,omitempty"`
+	// StartsAt defines when the elevated access should begin. If provided,
+	// the access request is held in the scheduled state until the start
+	// time and the access expires after the requested duration counted
+	// from the start time.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	StartsAt *metav1.Time `json:"startsAt,omitempty"`
+	// BreakGlass defines this access request as an emergency access. It is
+	// granted immediately without approvals and the plugin is only invoked
+	// afterwards for review.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	BreakGlass bool `json:"breakGlass,omitempty"`
+	// Revoke signals that the subject no longer needs the elevated access.
+	// Once set, the controller will remove the access and conclude the
+	// request with the revoked status.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self || !oldSelf",message="Value cannot be unset"
+	Revoke bool `json:"revoke,omitempty"`
+	// Cancel signals that the subject no longer wants the access requested
+	// but not granted yet. Once set, the controller will notify the plugin
+	// and conclude the request with the cancelled status.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self || !oldSelf",message="Value cannot be unset"
+	Cancel bool `json:"cancel,omitempty"`
+	// Extensions is the list of requests to extend the duration of the
+	// granted access. Extensions are processed by the controller in order
+	// and new entries can only be appended.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="size(self) >= size(oldSelf)",message="Extensions can only be appended"
+	Extensions []AccessExtension `json:"extensions,omitempty"`
+	// Approval is the approval policy copied from the AccessBinding when
+	// the access request is created. When provided, the access is only
+	// granted after receiving the required number of approvals.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	Approval *ApprovalPolicy `json:"approval,omitempty"`
+	// Approvals is the list of decisions made by approvers. New decisions
+	// can only be appended.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="size(self) >= size(oldSelf)",message="Approvals can only be appended"
+	Approvals []ApprovalDecision `json:"approvals,omitempty"`
+}
+
+// ApprovalDecisionType defines the decision made by an approver
+// +kubebuilder:validation:Enum=approved;rejected
+type ApprovalDecisionType string
+
+const (
+	// ApprovedDecision means that the approver agreed with the access request
+	ApprovedDecision ApprovalDecisionType = "approved"
+	// RejectedDecision means that the approver refused the access request
+	RejectedDecision ApprovalDecisionType = "rejected"
+)
+
+// ApprovalDecision defines the decision made by an approver
+type ApprovalDecision struct {
+	// Username is the user that made the decision
+	// +kubebuilder:validation:Required
+	Username string `json:"username"`
+	// Decision is the approver decision. Can be approved or rejected.
+	// +kubebuilder:validation:Required
+	Decision ApprovalDecisionType `json:"decision"`
+	// Comment is an optional message provided by the approver
+	// +optional
+	// +kubebuilder:validation:MaxLength=1024
+	Comment string `json:"comment,omitempty"`
+	// Time is when the decision was made
+	Time metav1.Time `json:"time"`
+}
+
+// AccessExtension defines a request to extend the duration of a granted access
+type AccessExtension struct {
+	// Duration is the amount of time to be added to the access expiration
+	Duration metav1.Duration `json:"duration"`
+}
+
+// AccessBindingReference defines the reference to an AccessBinding
+type AccessBindingReference struct {
+	// Name refers to the AccessBinding name
+	Name string `json:"name"`
+	// Namespace refers to the namespace where the AccessBinding lives
+	Namespace string `json:"namespace"`
 }
 
 // TargetApplication defines the Argo CD AppProject to assign the elevated permission
@@ -119,6 +261,37 @@ type AccessRequestStatus struct {
 	RoleTemplateHash string                 `json:"roleTemplateHash,omitempty"`
 	RoleName         string                 `json:"roleName,omitempty"`
 	History          []AccessRequestHistory `json:"history,omitempty"`
+	// Extensions contains the result of the processed access extensions
+	// in the same order as defined in the spec
+	Extensions []AccessExtensionStatus `json:"extensions,omitempty"`
+	// Approvals contains the approver decisions accepted by the controller
+	Approvals []ApprovalDecision `json:"approvals,omitempty"`
+	// Conditions contains the standard conditions describing the current
+	// state of the AccessRequest (Ready, Granted, PluginApproved,
+	// ProjectSynced and Expired)
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// ExpiryWarning defines how long before the access expires the
+	// "expiring" notification is sent. Not set if notifications are not
+	// configured.
+	// +optional
+	ExpiryWarning *metav1.Duration `json:"expiryWarning,omitempty"`
+	// NotifiedExpiresAt is the expiration time announced in the last
+	// "expiring" notification
+	// +optional
+	NotifiedExpiresAt *metav1.Time `json:"notifiedExpiresAt,omitempty"`
+}
+
+// AccessExtensionStatus contains the result of a processed access extension
+type AccessExtensionStatus struct {
+	// Duration is the amount of time requested by the extension
+	Duration metav1.Duration `json:"duration"`
+	// RequestState is the extension result. Can be granted or denied.
+	RequestState Status `json:"status"`
+	// TransitionTime is the time the extension was processed
+	TransitionTime metav1.Time `json:"transitionTime"`
 }
 
 // AccessRequestHistory contain the history of all status transitions associated
@@ -149,6 +322,8 @@ func (h AccessRequestHistory) String() string {
 // +kubebuilder:printcolumn:name="Application",type=string,JSONPath=`.spec.application.name`,priority=1
 // +kubebuilder:printcolumn:name="Role",type=string,JSONPath=`.spec.role.friendlyName`
 // +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.requestState`
+// +kubebuilder:printcolumn:name="Starts",type="date",JSONPath=`.spec.startsAt`,priority=1
+// +kubebuilder:printcolumn:name="Break Glass",type=boolean,JSONPath=`.spec.breakGlass`
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
 type AccessRequest struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -166,9 +341,14 @@ func (ar *AccessRequest) UpdateStatusHistory(newStatus Status, details string) {
 	status := ar.Status.DeepCopy()
 	status.RequestState = newStatus
 
-	// set the expiresAt only when transitioning to GrantedStatus
+	// set the expiresAt only when transitioning to GrantedStatus. Scheduled
+	// accesses expire based on the start time.
 	if newStatus == GrantedStatus && status.ExpiresAt == nil {
-		expiresAt := metav1.NewTime(time.Now().Add(ar.Spec.Duration.Duration))
+		start := time.Now()
+		if ar.Spec.StartsAt != nil {
+			start = ar.Spec.StartsAt.Time
+		}
+		expiresAt := metav1.NewTime(start.Add(ar.Spec.Duration.Duration))
 		status.ExpiresAt = &expiresAt
 	}
 
@@ -189,6 +369,39 @@ func (ar *AccessRequest) UpdateStatusHistory(newStatus Status, details string) {
 		status.History = append(status.History, history)
 	}
 	ar.Status = *status
+	ar.updateConditions(newStatus, details)
+}
+
+// updateConditions will update the standard conditions of this AccessRequest
+// to reflect the transition to the given newStatus. The PluginApproved
+// condition is maintained by the controller when invoking the plugin.
+func (ar *AccessRequest) updateConditions(newStatus Status, details string) {
+	reason := newStatus.ConditionReason()
+	if details == "" {
+		details = fmt.Sprintf("Access %s", newStatus)
+	}
+	switch newStatus {
+	case GrantedStatus:
+		ar.SetCondition(ConditionReady, metav1.ConditionTrue, reason, details)
+		ar.SetCondition(ConditionGranted, metav1.ConditionTrue, reason, details)
+		ar.SetCondition(ConditionProjectSynced, metav1.ConditionTrue, reason, "Subject added to the AppProject role")
+		ar.SetCondition(ConditionExpired, metav1.ConditionFalse, reason,
+			fmt.Sprintf("Access expires at %s", ar.Status.ExpiresAt.Format(time.RFC3339)))
+	case ExpiredStatus, RevokedStatus, CancelledStatus:
+		ar.SetCondition(ConditionReady, metav1.ConditionTrue, reason, details)
+		ar.SetCondition(ConditionGranted, metav1.ConditionFalse, reason, details)
+		if ar.Status.TargetProject != "" {
+			ar.SetCondition(ConditionProjectSynced, metav1.ConditionTrue, reason, "Subject removed from the AppProject role")
+		}
+		if newStatus == ExpiredStatus {
+			ar.SetCondition(ConditionExpired, metav1.ConditionTrue, reason, details)
+		}
+	default:
+		// initiated, requested and scheduled requests are still progressing
+		// while denied, invalid and timeout requests failed to be granted
+		ar.SetCondition(ConditionReady, metav1.ConditionFalse, reason, details)
+		ar.SetCondition(ConditionGranted, metav1.ConditionFalse, reason, details)
+	}
 }
 
 // isLastHistorySameAsCurrent will check if the last history entry has the same status and details as the new one.
@@ -239,12 +452,101 @@ func (ar *AccessRequest) IsExpiring() bool {
 	return false
 }
 
+// IsScheduled will return true if this AccessRequest has a start time in the
+// future. Otherwise it returns false.
+func (ar *AccessRequest) IsScheduled() bool {
+	return ar.Spec.StartsAt != nil && time.Now().Before(ar.Spec.StartsAt.Time)
+}
+
+// ExpiryWarningDue returns the time the "expiring" notification must be sent
+// for the current expiration time. Returns nil if the notification is not
+// configured or was already sent.
+func (ar *AccessRequest) ExpiryWarningDue() *time.Time {
+	if ar.Status.ExpiryWarning == nil || ar.Status.ExpiresAt == nil {
+		return nil
+	}
+	if ar.Status.NotifiedExpiresAt != nil && ar.Status.NotifiedExpiresAt.Equal(ar.Status.ExpiresAt) {
+		return nil
+	}
+	due := ar.Status.ExpiresAt.Add(-ar.Status.ExpiryWarning.Duration)
+	return &due
+}
+
+// GetPendingExtension will return the first extension in the spec that wasn't
+// processed yet. Returns nil if all extensions are processed.
+func (ar *AccessRequest) GetPendingExtension() *AccessExtension {
+	processed := len(ar.Status.Extensions)
+	if processed < len(ar.Spec.Extensions) {
+		return &ar.Spec.Extensions[processed]
+	}
+	return nil
+}
+
+// ExtendedDuration returns the total amount of time added to the access
+// expiration by granted extensions.
+func (ar *AccessRequest) ExtendedDuration() time.Duration {
+	var total time.Duration
+	for _, ext := range ar.Status.Extensions {
+		if ext.RequestState == GrantedStatus {
+			total += ext.Duration.Duration
+		}
+	}
+	return total
+}
+
+// ConcludePendingExtension will record the result of the pending extension in
+// the status. If the extension is granted, the expiration time is moved forward
+// by the extension duration. Noop if there is no pending extension.
+func (ar *AccessRequest) ConcludePendingExtension(result Status) {
+	ext := ar.GetPendingExtension()
+	if ext == nil {
+		return
+	}
+	if result == GrantedStatus && ar.Status.ExpiresAt != nil {
+		expiresAt := metav1.NewTime(ar.Status.ExpiresAt.Add(ext.Duration.Duration))
+		ar.Status.ExpiresAt = &expiresAt
+	}
+	ar.Status.Extensions = append(ar.Status.Extensions, AccessExtensionStatus{
+		Duration:       ext.Duration,
+		RequestState:   result,
+		TransitionTime: metav1.Now(),
+	})
+}
+
+// RequiresApproval returns true if the access request must be approved
+// before the access is granted.
+func (ar *AccessRequest) RequiresApproval() bool {
+	return ar.Spec.Approval != nil
+}
+
+// GetApprovalDecision returns the decision made by the given username in
+// the spec. Returns nil if the user didn't make a decision yet.
+func (ar *AccessRequest) GetApprovalDecision(username string) *ApprovalDecision {
+	for i := range ar.Spec.Approvals {
+		if ar.Spec.Approvals[i].Username == username {
+			return &ar.Spec.Approvals[i]
+		}
+	}
+	return nil
+}
+
+// ApprovalCount returns the number of approvals accepted by the controller.
+func (ar *AccessRequest) ApprovalCount() int {
+	count := 0
+	for _, decision := range ar.Status.Approvals {
+		if decision.Decision == ApprovedDecision {
+			count++
+		}
+	}
+	return count
+}
+
 // IsConcluded will check the status of this AccessRequest to determine
 // if it is concluded. Concluded AccessRequest means it is in Denied,
-// Expired, Invalid or Timeout status.
+// Expired, Invalid, Timeout, Revoked or Cancelled status.
 func (ar *AccessRequest) IsConcluded() bool {
 	switch ar.Status.RequestState {
-	case DeniedStatus, ExpiredStatus, InvalidStatus, TimeoutStatus:
+	case DeniedStatus, ExpiredStatus, InvalidStatus, TimeoutStatus, RevokedStatus, CancelledStatus:
 		return true
 	default:
 		return false
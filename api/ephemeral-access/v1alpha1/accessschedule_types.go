@@ -0,0 +1,141 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AccessSchedule is the Schema for the accessschedules API
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Schedule",type=string,JSONPath=`.spec.schedule`
+// +kubebuilder:printcolumn:name="Duration",type=string,JSONPath=`.spec.duration`
+// +kubebuilder:printcolumn:name="Suspend",type=boolean,JSONPath=`.spec.suspend`
+// +kubebuilder:printcolumn:name="Last Schedule",type="date",JSONPath=`.status.lastScheduleTime`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+type AccessSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AccessScheduleSpec   `json:"spec,omitempty"`
+	Status AccessScheduleStatus `json:"status,omitempty"`
+}
+
+// AccessScheduleList contains a list of AccessSchedule
+// +kubebuilder:object:root=true
+type AccessScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AccessSchedule `json:"items"`
+}
+
+// AccessScheduleSpec defines the desired state of AccessSchedule
+type AccessScheduleSpec struct {
+	// Schedule is the cron expression defining when each access window
+	// starts (e.g. "0 9 * * 2" for every Tuesday at 9am)
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+	// TimeZone is the IANA time zone name used to evaluate the schedule.
+	// If not provided, the schedule is evaluated in UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+	// Duration defines for how long the access is granted in each window
+	Duration metav1.Duration `json:"duration"`
+	// Application defines the Argo CD Application to assign the elevated
+	// permission
+	// +kubebuilder:validation:Required
+	Application TargetApplication `json:"application"`
+	// AccessBindingRef is the reference to the AccessBinding defining the
+	// role granted in each window. The constraints defined in the binding
+	// are enforced in the generated AccessRequests.
+	// +kubebuilder:validation:Required
+	AccessBindingRef AccessBindingReference `json:"accessBindingRef"`
+	// Subjects is the list of users that will receive the elevated access
+	// in each window
+	// +kubebuilder:validation:MinItems=1
+	Subjects []ScheduleSubject `json:"subjects"`
+	// Justification is the reason associated with the generated
+	// AccessRequests
+	// +optional
+	// +kubebuilder:validation:MaxLength=1024
+	Justification string `json:"justification,omitempty"`
+	// Suspend will stop the creation of AccessRequests for new windows.
+	// AccessRequests already created are not affected.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// ScheduleSubject defines a user receiving the scheduled access
+type ScheduleSubject struct {
+	// Username refers to the user receiving the elevated permission
+	// +kubebuilder:validation:MinLength=1
+	Username string `json:"username"`
+	// UserId refers to the user id as authenticated in Argo CD
+	// +optional
+	UserId string `json:"userId,omitempty"`
+}
+
+// AccessScheduleStatus defines the observed state of AccessSchedule
+type AccessScheduleStatus struct {
+	// LastScheduleTime is the start time of the last window where
+	// AccessRequests were created
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// NextScheduleTime is the start time of the next window
+	// +optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+}
+
+// ParseSchedule returns the cron schedule defined in the spec evaluated in the
+// configured time zone.
+func (s *AccessSchedule) ParseSchedule() (cron.Schedule, error) {
+	timeZone := "UTC"
+	if s.Spec.TimeZone != "" {
+		if _, err := time.LoadLocation(s.Spec.TimeZone); err != nil {
+			return nil, fmt.Errorf("invalid time zone %q: %w", s.Spec.TimeZone, err)
+		}
+		timeZone = s.Spec.TimeZone
+	}
+	schedule, err := cron.ParseStandard(fmt.Sprintf("CRON_TZ=%s %s", timeZone, s.Spec.Schedule))
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", s.Spec.Schedule, err)
+	}
+	return schedule, nil
+}
+
+// ActiveWindow returns the start time of the window that is active at the
+// given time. Returns nil if no window is active.
+func (s *AccessSchedule) ActiveWindow(schedule cron.Schedule, now time.Time) *time.Time {
+	start := schedule.Next(now.Add(-s.Spec.Duration.Duration))
+	if start.IsZero() || start.After(now) {
+		return nil
+	}
+	// when windows overlap, the most recent one is considered active
+	for next := schedule.Next(start); !next.IsZero() && !next.After(now); next = schedule.Next(next) {
+		start = next
+	}
+	return &start
+}
+
+func init() {
+	SchemeBuilder.Register(&AccessSchedule{}, &AccessScheduleList{})
+}
@@ -0,0 +1,131 @@
+package v1alpha1_test
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func newAccessSchedule(schedule, timeZone string, duration time.Duration) *api.AccessSchedule {
+	return &api.AccessSchedule{
+		Spec: api.AccessScheduleSpec{
+			Schedule: schedule,
+			TimeZone: timeZone,
+			Duration: metav1.Duration{Duration: duration},
+		},
+	}
+}
+
+func TestAccessSchedule_ParseSchedule(t *testing.T) {
+	t.Run("will evaluate the schedule in UTC by default", func(t *testing.T) {
+		// Given
+		as := newAccessSchedule("0 9 * * 2", "", 4*time.Hour)
+
+		// When
+		schedule, err := as.ParseSchedule()
+
+		// Then
+		require.NoError(t, err)
+		monday := time.Date(2024, time.February, 12, 12, 0, 0, 0, time.UTC)
+		assert.Equal(t, time.Date(2024, time.February, 13, 9, 0, 0, 0, time.UTC), schedule.Next(monday))
+	})
+	t.Run("will evaluate the schedule in the configured time zone", func(t *testing.T) {
+		// Given
+		as := newAccessSchedule("0 9 * * 2", "America/Toronto", 4*time.Hour)
+
+		// When
+		schedule, err := as.ParseSchedule()
+
+		// Then
+		require.NoError(t, err)
+		monday := time.Date(2024, time.February, 12, 12, 0, 0, 0, time.UTC)
+		assert.True(t, time.Date(2024, time.February, 13, 14, 0, 0, 0, time.UTC).Equal(schedule.Next(monday)))
+	})
+	t.Run("will return error if the time zone is invalid", func(t *testing.T) {
+		// Given
+		as := newAccessSchedule("0 9 * * 2", "Mars/Olympus_Mons", 4*time.Hour)
+
+		// When
+		schedule, err := as.ParseSchedule()
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, schedule)
+		assert.Contains(t, err.Error(), "invalid time zone")
+	})
+	t.Run("will return error if the schedule is invalid", func(t *testing.T) {
+		// Given
+		as := newAccessSchedule("every tuesday", "", 4*time.Hour)
+
+		// When
+		schedule, err := as.ParseSchedule()
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, schedule)
+		assert.Contains(t, err.Error(), "invalid schedule")
+	})
+}
+
+func TestAccessSchedule_ActiveWindow(t *testing.T) {
+	tuesday := func(hour, min int) time.Time {
+		return time.Date(2024, time.February, 13, hour, min, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name     string
+		schedule string
+		duration time.Duration
+		now      time.Time
+		want     *time.Time
+	}{
+		{
+			name:     "returns the window start when inside the window",
+			schedule: "0 9 * * 2",
+			duration: 4 * time.Hour,
+			now:      tuesday(10, 30),
+			want:     ptr.To(tuesday(9, 0)),
+		},
+		{
+			name:     "returns the window start at the exact start time",
+			schedule: "0 9 * * 2",
+			duration: 4 * time.Hour,
+			now:      tuesday(9, 0),
+			want:     ptr.To(tuesday(9, 0)),
+		},
+		{
+			name:     "returns nil before the window starts",
+			schedule: "0 9 * * 2",
+			duration: 4 * time.Hour,
+			now:      tuesday(8, 59),
+		},
+		{
+			name:     "returns nil once the window ends",
+			schedule: "0 9 * * 2",
+			duration: 4 * time.Hour,
+			now:      tuesday(13, 0),
+		},
+		{
+			name:     "returns the most recent window when windows overlap",
+			schedule: "0 * * * *",
+			duration: 3 * time.Hour,
+			now:      tuesday(10, 30),
+			want:     ptr.To(tuesday(10, 0)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			as := newAccessSchedule(tt.schedule, "", tt.duration)
+			schedule, err := as.ParseSchedule()
+			require.NoError(t, err)
+
+			got := as.ActiveWindow(schedule, tt.now)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Standard condition types maintained by the controller in the AccessRequest
+// and RoleTemplate status.
+const (
+	// ConditionReady is true when the resource reached its desired state. For
+	// AccessRequests it means the access was granted or properly concluded. For
+	// RoleTemplates it means the template is valid.
+	ConditionReady = "Ready"
+	// ConditionGranted is true while the AccessRequest subject has the
+	// elevated access
+	ConditionGranted = "Granted"
+	// ConditionPluginApproved reflects the last decision returned by the
+	// configured plugin. Not set if no plugin is configured.
+	ConditionPluginApproved = "PluginApproved"
+	// ConditionProjectSynced is true when the AppProject role reflects the
+	// desired state
+	ConditionProjectSynced = "ProjectSynced"
+	// ConditionExpired is true once the AccessRequest duration is over
+	ConditionExpired = "Expired"
+)
+
+// ConditionReason returns the reason used in the conditions set when an
+// AccessRequest transitions to this status (e.g. "granted" -> "Granted").
+func (s Status) ConditionReason() string {
+	if s == "" {
+		return "Unknown"
+	}
+	return strings.ToUpper(string(s[0])) + string(s[1:])
+}
+
+// SetCondition will add or update the condition with the given type in this
+// AccessRequest status. The transition time is only updated if the condition
+// status changes.
+func (ar *AccessRequest) SetCondition(conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&ar.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: ar.GetGeneration(),
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// GetCondition returns the condition with the given type from this
+// AccessRequest status. Returns nil if not found.
+func (ar *AccessRequest) GetCondition(conditionType string) *metav1.Condition {
+	return meta.FindStatusCondition(ar.Status.Conditions, conditionType)
+}
+
+// SetCondition will add or update the condition with the given type in this
+// RoleTemplate status. The transition time is only updated if the condition
+// status changes.
+func (rt *RoleTemplate) SetCondition(conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&rt.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: rt.GetGeneration(),
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// GetCondition returns the condition with the given type from this
+// RoleTemplate status. Returns nil if not found.
+func (rt *RoleTemplate) GetCondition(conditionType string) *metav1.Condition {
+	return meta.FindStatusCondition(rt.Status.Conditions, conditionType)
+}
@@ -33,6 +33,7 @@ const (
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Synced",type=boolean,JSONPath=`.status.synced`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
 type RoleTemplate struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -55,6 +56,11 @@ type RoleTemplateSpec struct {
 	Name        string   `json:"name"`
 	Description string   `json:"description,omitempty"`
 	Policies    []string `json:"policies"`
+	// Plugins is the list of the controller plugin names that must grant
+	// the access to this role. All configured plugins are invoked if not
+	// provided.
+	// +optional
+	Plugins []string `json:"plugins,omitempty"`
 }
 
 // RoleTemplateStatus defines the observed state of RoleTemplate
@@ -62,6 +68,12 @@ type RoleTemplateStatus struct {
 	Synced   bool   `json:"synced"`
 	Message  string `json:"message,omitempty"`
 	SyncHash string `json:"syncHash"`
+	// Conditions contains the standard conditions describing the current
+	// state of the RoleTemplate (Ready and ProjectSynced)
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // Render will return a new RoleTemplate instance with the templates replaced by
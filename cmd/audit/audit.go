@@ -0,0 +1,53 @@
+package audit
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj-labs/argocd-ephemeral-access/internal/controller/audit"
+)
+
+func NewCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:               "audit",
+		Short:             "Inspect the Ephemeral Access Controller audit log",
+		DisableAutoGenTag: true,
+		Run: func(c *cobra.Command, args []string) {
+			c.HelpFunc()(c, args)
+		},
+	}
+	command.AddCommand(newVerifyCommand())
+	return command
+}
+
+func newVerifyCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify FILE",
+		Short: "Verify the hash chain of an audit log",
+		Long: "Verify that no record was modified, removed or reordered in the given audit log. " +
+			"Use '-' to read the audit log from the standard input.",
+		Args:              cobra.ExactArgs(1),
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var r io.Reader = cmd.InOrStdin()
+			if args[0] != "-" {
+				f, err := os.Open(args[0])
+				if err != nil {
+					return fmt.Errorf("error opening audit log: %w", err)
+				}
+				defer f.Close()
+				r = f
+			}
+			result, err := audit.Verify(r)
+			if err != nil {
+				return fmt.Errorf("audit log verification failed after %d valid records: %w", result.Records, err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Audit log verified: %d records in %d chain(s). Last hash: %s\n",
+				result.Records, result.Chains, result.LastHash)
+			return nil
+		},
+	}
+}
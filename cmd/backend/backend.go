@@ -22,6 +22,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/argoproj-labs/argocd-ephemeral-access/internal/archive"
 	"github.com/argoproj-labs/argocd-ephemeral-access/internal/backend"
 	"github.com/argoproj-labs/argocd-ephemeral-access/internal/backend/metrics"
 	"github.com/argoproj-labs/argocd-ephemeral-access/internal/backend/tracing"
@@ -33,8 +34,10 @@ import (
 	"github.com/sethvargo/go-envconfig"
 	"github.com/spf13/cobra"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // Options for the CLI.
@@ -57,8 +60,29 @@ type BackendConfig struct {
 	// DefaultAccessDuration defines the default duration to be used when creating
 	// AccessRequests
 	DefaultAccessDuration time.Duration `env:"EPHEMERAL_BACKEND_DEFAULT_ACCESS_DURATION, default=4h"`
+	// AdminGroups defines the list of groups allowed to use the admin endpoints
+	// to search and export all AccessRequests. Admin endpoints are disabled if
+	// no group is configured.
+	AdminGroups []string `env:"EPHEMERAL_BACKEND_ADMIN_GROUPS"`
 	// Tracing configures OpenTelemetry tracing.
 	Tracing TracingConfig
+	// Archive configures the storage where the controller archives the
+	// AccessRequests before deleting them.
+	Archive ArchiveConfig `env:", prefix=EPHEMERAL_ARCHIVE_"`
+}
+
+// ArchiveConfig defines the configurations used to search the AccessRequests
+// archived by the controller. Must match the controller archive configuration.
+type ArchiveConfig struct {
+	// Type defines the archive storage. Possible values: configmap, file.
+	// The archive endpoint is disabled if not set.
+	Type string `env:"TYPE"`
+	// Namespace defines the namespace of the ConfigMap archive. Defaults to
+	// the backend namespace.
+	Namespace string `env:"NAMESPACE"`
+	// Path defines the directory of the file archive. The directory must
+	// exist as it is shared with the controller.
+	Path string `env:"PATH"`
 }
 
 // TracingConfig defines OpenTelemetry tracing configurations. Variable names
@@ -95,6 +119,26 @@ type LogConfig struct {
 	Format string `env:"FORMAT, default=text"`
 }
 
+// newArchiveReader returns the archive.Reader used to search the archived
+// AccessRequests. ConfigMaps are read without cache as they are only needed
+// by the admin endpoints. The file archive directory must be mounted from the
+// volume written by the controller.
+func newArchiveReader(restConfig *rest.Config, config BackendConfig) (archive.Reader, error) {
+	namespace := config.Archive.Namespace
+	if namespace == "" {
+		namespace = config.Namespace
+	}
+	var k8sClient client.Client
+	if config.Archive.Type == archive.TypeConfigMap {
+		var err error
+		k8sClient, err = client.New(restConfig, client.Options{Scheme: scheme.Scheme})
+		if err != nil {
+			return nil, fmt.Errorf("error creating k8s client: %w", err)
+		}
+	}
+	return archive.NewReader(config.Archive.Type, namespace, config.Archive.Path, k8sClient)
+}
+
 func newRestConfig(kubeconfig string, logger log.Logger) (*rest.Config, error) {
 	var config *rest.Config
 	var err error
@@ -154,8 +198,16 @@ func run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error creating a new k8s persister: %w", err)
 	}
 
-	service := backend.NewDefaultService(persister, logger, opts.Backend.Namespace, opts.Backend.DefaultAccessDuration)
-	handler := backend.NewAPIHandler(service, logger)
+	serviceOpts := []backend.ServiceOption{}
+	if opts.Backend.Archive.Type != "" {
+		reader, err := newArchiveReader(restConfig, opts.Backend)
+		if err != nil {
+			return fmt.Errorf("error creating access request archive: %w", err)
+		}
+		serviceOpts = append(serviceOpts, backend.WithArchive(reader))
+	}
+	service := backend.NewDefaultService(persister, logger, opts.Backend.Namespace, opts.Backend.DefaultAccessDuration, serviceOpts...)
+	handler := backend.NewAPIHandler(service, logger, backend.WithAdminGroups(opts.Backend.AdminGroups))
 
 	tracingShutdown, err := tracing.Init(context.Background(), tracing.Config{
 		ServiceName: opts.Backend.Tracing.ServiceName,
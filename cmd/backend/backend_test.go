@@ -23,6 +23,7 @@ func TestReadEnvConfigs(t *testing.T) {
 		assert.Equal(t, 8091, opts.Backend.MetricsPort)
 		assert.Equal(t, "argocd", opts.Backend.Namespace)
 		assert.Equal(t, 4*time.Hour, opts.Backend.DefaultAccessDuration)
+		assert.Empty(t, opts.Backend.AdminGroups)
 
 		assert.Equal(t, "argocd-ephemeral-access-backend", opts.Backend.Tracing.ServiceName)
 		assert.Empty(t, opts.Backend.Tracing.Endpoint)
@@ -30,6 +31,10 @@ func TestReadEnvConfigs(t *testing.T) {
 		assert.False(t, opts.Backend.Tracing.Insecure)
 		assert.Empty(t, opts.Backend.Tracing.Propagators)
 
+		assert.Empty(t, opts.Backend.Archive.Type)
+		assert.Empty(t, opts.Backend.Archive.Namespace)
+		assert.Empty(t, opts.Backend.Archive.Path)
+
 		assert.Equal(t, "info", opts.Log.Level)
 		assert.Equal(t, "text", opts.Log.Format)
 	})
@@ -41,11 +46,15 @@ func TestReadEnvConfigs(t *testing.T) {
 		t.Setenv("KUBECONFIG", "/tmp/kube.cfg")
 		t.Setenv("EPHEMERAL_BACKEND_NAMESPACE", "ephemeral")
 		t.Setenv("EPHEMERAL_BACKEND_DEFAULT_ACCESS_DURATION", "30m")
+		t.Setenv("EPHEMERAL_BACKEND_ADMIN_GROUPS", "auditors,admins")
 		t.Setenv("OTEL_SERVICE_NAME", "custom-backend")
 		t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://collector:4318")
 		t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", "http/protobuf")
 		t.Setenv("OTEL_EXPORTER_OTLP_INSECURE", "true")
 		t.Setenv("OTEL_PROPAGATORS", "tracecontext,baggage,b3")
+		t.Setenv("EPHEMERAL_ARCHIVE_TYPE", "file")
+		t.Setenv("EPHEMERAL_ARCHIVE_NAMESPACE", "archive")
+		t.Setenv("EPHEMERAL_ARCHIVE_PATH", "/var/lib/archive")
 		t.Setenv("EPHEMERAL_LOG_LEVEL", "debug")
 		t.Setenv("EPHEMERAL_LOG_FORMAT", "json")
 
@@ -59,6 +68,7 @@ func TestReadEnvConfigs(t *testing.T) {
 		assert.Equal(t, "/tmp/kube.cfg", opts.Backend.Kubeconfig)
 		assert.Equal(t, "ephemeral", opts.Backend.Namespace)
 		assert.Equal(t, 30*time.Minute, opts.Backend.DefaultAccessDuration)
+		assert.Equal(t, []string{"auditors", "admins"}, opts.Backend.AdminGroups)
 
 		assert.Equal(t, "custom-backend", opts.Backend.Tracing.ServiceName)
 		assert.Equal(t, "http://collector:4318", opts.Backend.Tracing.Endpoint)
@@ -66,6 +76,10 @@ func TestReadEnvConfigs(t *testing.T) {
 		assert.True(t, opts.Backend.Tracing.Insecure)
 		assert.Equal(t, "tracecontext,baggage,b3", opts.Backend.Tracing.Propagators)
 
+		assert.Equal(t, "file", opts.Backend.Archive.Type)
+		assert.Equal(t, "archive", opts.Backend.Archive.Namespace)
+		assert.Equal(t, "/var/lib/archive", opts.Backend.Archive.Path)
+
 		assert.Equal(t, "debug", opts.Log.Level)
 		assert.Equal(t, "json", opts.Log.Format)
 	})
@@ -19,7 +19,10 @@ package controller
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"os"
+	"strings"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -37,9 +40,12 @@ import (
 
 	argocd "github.com/argoproj-labs/argocd-ephemeral-access/api/argoproj/v1alpha1"
 	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
+	"github.com/argoproj-labs/argocd-ephemeral-access/internal/archive"
 	"github.com/argoproj-labs/argocd-ephemeral-access/internal/controller"
+	"github.com/argoproj-labs/argocd-ephemeral-access/internal/controller/audit"
 	"github.com/argoproj-labs/argocd-ephemeral-access/internal/controller/config"
 	"github.com/argoproj-labs/argocd-ephemeral-access/internal/controller/metrics"
+	"github.com/argoproj-labs/argocd-ephemeral-access/internal/controller/notification"
 	"github.com/argoproj-labs/argocd-ephemeral-access/pkg/log"
 	"github.com/argoproj-labs/argocd-ephemeral-access/pkg/plugin"
 	"github.com/spf13/cobra"
@@ -138,17 +144,74 @@ func run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("unable to start manager: %w", err)
 	}
 
-	var accessRequester plugin.AccessRequester
-	// register the plugin when the path is provided
-	if config.PluginPath() != "" {
-		accessRequester, err = initPlugin(config.PluginPath(), zaplogger)
+	// the signal handler context is also used to interrupt the plugin
+	// initialization when the controller is shutting down
+	ctx := ctrl.SetupSignalHandler()
+
+	if config.PluginPath() != "" && config.PluginURL() != "" {
+		return fmt.Errorf("plugin path and plugin url are mutually exclusive")
+	}
+	location := config.PluginPath()
+	if config.PluginURL() != "" {
+		location = config.PluginURL()
+	}
+	var accessRequester plugin.AccessRequesterV2
+	// register the plugin when the path or the url is provided
+	if location != "" {
+		accessRequester, err = initPlugin(ctx, controller.DefaultPluginName, location, config, zaplogger)
 		if err != nil {
 			return fmt.Errorf("plugin initialization error: %w", err)
 		}
+		err = supervisePlugin(mgr, controller.DefaultPluginName, accessRequester)
+		if err != nil {
+			return fmt.Errorf("plugin supervision error: %w", err)
+		}
 		setupLog.Info("AccessRequester plugin initialized successfully...")
 	}
+	chain, err := parsePluginChain(config.PluginChain())
+	if err != nil {
+		return fmt.Errorf("plugin chain configuration error: %w", err)
+	}
+	pluginOpts := []controller.ServiceOption{}
+	for _, p := range chain {
+		chained, err := initPlugin(ctx, p.name, p.location, config, zaplogger)
+		if err != nil {
+			return fmt.Errorf("plugin %s initialization error: %w", p.name, err)
+		}
+		err = supervisePlugin(mgr, p.name, chained)
+		if err != nil {
+			return fmt.Errorf("plugin %s supervision error: %w", p.name, err)
+		}
+		setupLog.Info("AccessRequester plugin initialized successfully...", "plugin", p.name)
+		pluginOpts = append(pluginOpts, controller.WithPlugin(p.name, chained))
+	}
 
-	service := controller.NewService(mgr.GetClient(), config, accessRequester)
+	// the API reader is used to avoid caching all Secrets in the cluster
+	notifier, err := notification.NewNotifier(mgr.GetAPIReader(), config)
+	if err != nil {
+		return fmt.Errorf("notifier initialization error: %w", err)
+	}
+	if config.NotificationArgoCDAnnotations() {
+		notifier.AddSink(notification.NewArgoCDSink(mgr.GetClient()))
+	}
+
+	recorder := mgr.GetEventRecorderFor("ephemeral-access-controller")
+	serviceOpts := []controller.ServiceOption{
+		controller.WithEventRecorder(recorder),
+		controller.WithNotifier(notifier),
+		controller.WithPluginTimeout(config.PluginTimeout()),
+	}
+	serviceOpts = append(serviceOpts, pluginOpts...)
+	if config.AuditLogPath() != "" {
+		auditLogger, err := audit.NewLoggerFromPath(config.AuditLogPath())
+		if err != nil {
+			return fmt.Errorf("audit log initialization error: %w", err)
+		}
+		defer auditLogger.Close()
+		serviceOpts = append(serviceOpts, controller.WithAuditLogger(auditLogger))
+		setupLog.Info("Audit log enabled", "path", config.AuditLogPath())
+	}
+	service := controller.NewService(mgr.GetClient(), config, accessRequester, serviceOpts...)
 
 	reconciler := &controller.AccessRequestReconciler{
 		Client:  mgr.GetClient(),
@@ -156,9 +219,32 @@ func run(cmd *cobra.Command, args []string) error {
 		Service: service,
 		Config:  config,
 	}
+	if config.ArchiveType() != "" {
+		// the API reader is used to avoid caching all ConfigMaps in the cluster
+		archiver, err := archive.New(config.ArchiveType(), config.ArchiveNamespace(), config.ArchivePath(), mgr.GetAPIReader(), mgr.GetClient())
+		if err != nil {
+			return fmt.Errorf("archive initialization error: %w", err)
+		}
+		reconciler.Archiver = archiver
+		setupLog.Info("AccessRequest archive enabled", "type", config.ArchiveType())
+	}
 	if err = reconciler.SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("unable to create controller AccessRequest controller: %w", err)
 	}
+	scheduleReconciler := &controller.AccessScheduleReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}
+	if err = scheduleReconciler.SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create controller AccessSchedule controller: %w", err)
+	}
+	roleTemplateReconciler := &controller.RoleTemplateReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}
+	if err = roleTemplateReconciler.SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create controller RoleTemplate controller: %w", err)
+	}
 	// +kubebuilder:scaffold:builder
 
 	metrics.Register(context.Background(), mgr.GetCache())
@@ -171,31 +257,159 @@ func run(cmd *cobra.Command, args []string) error {
 	}
 
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	if err := mgr.Start(ctx); err != nil {
 		return fmt.Errorf("problem running manager: %w", err)
 	}
 	return nil
 }
 
-// initPlugin will initialize the AccessRequester plugin from the binary
-// provided in the given path.
-func initPlugin(path string, logger *zap.Logger) (plugin.AccessRequester, error) {
-	setupLog.Info("Initializing AccessRequester plugin...", "path", path)
+// initPlugin will initialize the AccessRequester plugin with the given name
+// from the binary or the remote service URL provided in location.
+func initPlugin(ctx context.Context, name, location string, config config.PluginConfigurer, logger *zap.Logger) (plugin.AccessRequesterV2, error) {
+	var accessRequester plugin.AccessRequesterV2
+	var err error
+	if isPluginURL(location) {
+		accessRequester, err = newHTTPPlugin(name, location, config)
+	} else {
+		accessRequester, err = newBinaryPlugin(name, location, config, logger)
+	}
+	if err != nil {
+		return nil, err
+	}
+	setupLog.Info("Calling AccessRequester plugin Init function...", "plugin", name)
+	initCtx, cancel := plugin.ContextWithTimeout(ctx, config.PluginTimeout())
+	defer cancel()
+	err = accessRequester.Init(initCtx)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing the AccessRequester plugin: %w", err)
+	}
+	return accessRequester, nil
+}
+
+// newBinaryPlugin will build the supervisor of the AccessRequester plugin
+// binary provided in the given path. The process is launched when the plugin
+// Init function is invoked.
+func newBinaryPlugin(name, path string, config config.PluginConfigurer, logger *zap.Logger) (plugin.AccessRequesterV2, error) {
+	setupLog.Info("Initializing AccessRequester plugin...", "plugin", name, "path", path)
 
 	pluginLog, err := log.NewPluginLogger(logger)
 	if err != nil {
 		return nil, fmt.Errorf("error building plugin logger: %w", err)
 	}
-	cliConfig := plugin.NewClientConfig(path, pluginLog)
-	client := goPlugin.NewClient(cliConfig)
-	accessRequester, err := plugin.GetAccessRequester(client)
+	// a new client is required every time the plugin process is launched
+	newClient := func() *goPlugin.Client {
+		return goPlugin.NewClient(plugin.NewClientConfig(path, pluginLog))
+	}
+	supervisor := plugin.NewSupervisor(newClient, pluginLog.Named(name),
+		plugin.WithHealthCheckInterval(config.PluginHealthCheckInterval()),
+		plugin.WithRestartMaxBackoff(config.PluginRestartMaxBackoff()),
+		plugin.WithSupervisorTimeout(config.PluginTimeout()),
+		plugin.WithHealthHook(func(healthy bool) {
+			metrics.SetPluginUp(name, healthy)
+		}),
+	)
+	return supervisor, nil
+}
+
+// supervisePlugin will register the health checks of the given plugin in the
+// manager. Only plugin binaries are supervised: the lifecycle of remote
+// services isn't managed by the controller.
+func supervisePlugin(mgr ctrl.Manager, name string, accessRequester plugin.AccessRequesterV2) error {
+	supervisor, ok := accessRequester.(*plugin.Supervisor)
+	if !ok {
+		return nil
+	}
+	// the supervisor relaunches the plugin process when it isn't healthy
+	err := mgr.Add(supervisor)
 	if err != nil {
-		return nil, fmt.Errorf("error getting AccessRequester plugin: %w", err)
+		return fmt.Errorf("error adding plugin supervisor to the manager: %w", err)
 	}
-	setupLog.Info("Calling AccessRequester plugin Init function...")
-	err = accessRequester.Init()
+	err = mgr.AddReadyzCheck("plugin-"+name, supervisor.Check)
 	if err != nil {
-		return nil, fmt.Errorf("error initializing the AccessRequester plugin: %w", err)
+		return fmt.Errorf("error adding plugin ready check: %w", err)
+	}
+	return nil
+}
+
+// newHTTPPlugin will build the AccessRequester calling the remote service
+// provided in the given url. The authentication and retry settings are shared
+// by all remote services.
+func newHTTPPlugin(name, url string, config config.PluginConfigurer) (plugin.AccessRequesterV2, error) {
+	setupLog.Info("Initializing HTTP AccessRequester plugin...", "plugin", name, "url", url)
+
+	opts := []plugin.HTTPOption{
+		plugin.WithHTTPMaxRetries(config.PluginMaxRetries()),
+	}
+	if config.PluginToken() != "" {
+		opts = append(opts, plugin.WithBearerToken(config.PluginToken()))
+	}
+	if config.PluginTLSCertPath() != "" || config.PluginTLSCAPath() != "" {
+		tlsConfig, err := loadPluginTLSConfig(config)
+		if err != nil {
+			return nil, fmt.Errorf("error loading plugin TLS config: %w", err)
+		}
+		opts = append(opts, plugin.WithTLSConfig(tlsConfig))
+	}
+	accessRequester, err := plugin.NewHTTPAccessRequester(url, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating HTTP AccessRequester: %w", err)
 	}
 	return accessRequester, nil
 }
+
+// isPluginURL returns true if the given plugin location is the URL of a remote
+// AccessRequester service instead of a binary path.
+func isPluginURL(location string) bool {
+	return strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://")
+}
+
+// chainedPlugin is an additional plugin configured in the plugin chain.
+type chainedPlugin struct {
+	name     string
+	location string
+}
+
+// parsePluginChain will parse the plugin chain entries in the name=location
+// format. Names must be unique and can't be the name of the plugin configured
+// with the plugin path or url.
+func parsePluginChain(entries []string) ([]chainedPlugin, error) {
+	chain := []chainedPlugin{}
+	names := map[string]bool{controller.DefaultPluginName: true}
+	for _, entry := range entries {
+		name, location, found := strings.Cut(strings.TrimSpace(entry), "=")
+		if !found || name == "" || location == "" {
+			return nil, fmt.Errorf("invalid plugin chain entry %q: must be in the format name=location", entry)
+		}
+		if names[name] {
+			return nil, fmt.Errorf("invalid plugin chain entry %q: duplicated plugin name %s", entry, name)
+		}
+		names[name] = true
+		chain = append(chain, chainedPlugin{name: name, location: location})
+	}
+	return chain, nil
+}
+
+// loadPluginTLSConfig will load the client certificate and the CA bundle used
+// to connect to the remote AccessRequester service.
+func loadPluginTLSConfig(config config.PluginConfigurer) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if config.PluginTLSCertPath() != "" {
+		cert, err := tls.LoadX509KeyPair(config.PluginTLSCertPath(), config.PluginTLSKeyPath())
+		if err != nil {
+			return nil, fmt.Errorf("error loading client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	if config.PluginTLSCAPath() != "" {
+		ca, err := os.ReadFile(config.PluginTLSCAPath())
+		if err != nil {
+			return nil, fmt.Errorf("error reading CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no valid certificate found in CA file %s", config.PluginTLSCAPath())
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return tlsConfig, nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/argoproj-labs/argocd-ephemeral-access/cmd/audit"
 	"github.com/argoproj-labs/argocd-ephemeral-access/cmd/backend"
 	"github.com/argoproj-labs/argocd-ephemeral-access/cmd/controller"
 	"github.com/argoproj-labs/argocd-ephemeral-access/pkg/log"
@@ -24,6 +25,7 @@ func main() {
 
 	command.AddCommand(backend.NewCommand())
 	command.AddCommand(controller.NewCommand())
+	command.AddCommand(audit.NewCommand())
 
 	if err := command.Execute(); err != nil {
 		msg := "ephemeral-access execution error"
@@ -0,0 +1,118 @@
+// Package archive provides the storages used to keep the AccessRequests
+// after they are deleted from the cluster.
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
+)
+
+// Archiver stores AccessRequests before they are deleted.
+type Archiver interface {
+	// Archive stores the full state of the given AccessRequest. Archiving
+	// the same AccessRequest again replaces the previous version.
+	Archive(ctx context.Context, ar *api.AccessRequest) error
+}
+
+// Reader retrieves the archived AccessRequests.
+type Reader interface {
+	// List returns all archived AccessRequests in the given namespace.
+	// Returns the AccessRequests from all namespaces if namespace is empty.
+	List(ctx context.Context, namespace string) ([]*api.AccessRequest, error)
+}
+
+// Archive defines a storage where AccessRequests can be archived and
+// retrieved.
+type Archive interface {
+	Archiver
+	Reader
+}
+
+// marshal returns the JSON document stored in the archive for the given
+// AccessRequest. Managed fields are removed as they are irrelevant once the
+// object is deleted.
+func marshal(ar *api.AccessRequest) ([]byte, error) {
+	archived := ar.DeepCopy()
+	archived.SetManagedFields(nil)
+	archived.SetResourceVersion("")
+	data, err := json.Marshal(archived)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling AccessRequest: %w", err)
+	}
+	return data, nil
+}
+
+func unmarshal(data []byte) (*api.AccessRequest, error) {
+	ar := &api.AccessRequest{}
+	err := json.Unmarshal(data, ar)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshaling AccessRequest: %w", err)
+	}
+	return ar, nil
+}
+
+// entryName returns the name identifying the given AccessRequest in the
+// archive. The UID is included to distinguish AccessRequests recreated with
+// the same name.
+func entryName(ar *api.AccessRequest) string {
+	return fmt.Sprintf("%s_%s_%s.json", ar.GetNamespace(), ar.GetName(), ar.GetUID())
+}
+
+const (
+	// TypeConfigMap stores the archive in ConfigMaps
+	TypeConfigMap = "configmap"
+	// TypeFile stores the archive in the local filesystem
+	TypeFile = "file"
+)
+
+// New returns the Archive of the given archiveType. The namespace is used by
+// the ConfigMap archive and the path by the file archive. The reader and
+// writer are only used by the ConfigMap archive.
+func New(archiveType, namespace, path string, reader client.Reader, writer client.Writer) (Archive, error) {
+	switch archiveType {
+	case TypeConfigMap:
+		if namespace == "" {
+			return nil, errors.New("namespace is required for the configmap archive")
+		}
+		return NewConfigMapArchive(reader, writer, namespace), nil
+	case TypeFile:
+		if path == "" {
+			return nil, errors.New("path is required for the file archive")
+		}
+		fileArchive, err := NewFileArchive(path)
+		if err != nil {
+			return nil, err
+		}
+		return fileArchive, nil
+	}
+	return nil, fmt.Errorf("unsupported archive type %q: supported types: %s, %s", archiveType, TypeConfigMap, TypeFile)
+}
+
+// NewReader returns the Reader of the given archiveType. The file archive
+// directory must already exist as it is written by the controller and shared
+// with the readers.
+func NewReader(archiveType, namespace, path string, reader client.Reader) (Reader, error) {
+	switch archiveType {
+	case TypeConfigMap:
+		if namespace == "" {
+			return nil, errors.New("namespace is required for the configmap archive")
+		}
+		return NewConfigMapArchive(reader, nil, namespace), nil
+	case TypeFile:
+		if path == "" {
+			return nil, errors.New("path is required for the file archive")
+		}
+		fileArchive, err := OpenFileArchive(path)
+		if err != nil {
+			return nil, err
+		}
+		return fileArchive, nil
+	}
+	return nil, fmt.Errorf("unsupported archive type %q: supported types: %s, %s", archiveType, TypeConfigMap, TypeFile)
+}
@@ -0,0 +1,183 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
+)
+
+const (
+	// ArchiveLabel identifies the ConfigMaps containing archived
+	// AccessRequests
+	ArchiveLabel = "ephemeral-access.argoproj-labs.io/archive"
+	// ArchiveMonthLabel is the month (YYYY-MM) the AccessRequests archived in
+	// the ConfigMap were created
+	ArchiveMonthLabel = "ephemeral-access.argoproj-labs.io/archive-month"
+
+	shardPrefix = "ephemeral-access-archive"
+	// ConfigMaps are limited to 1MiB. Some room is left for the metadata.
+	defaultMaxShardSize = 900 * 1024
+)
+
+// ConfigMapArchive stores the AccessRequests in ConfigMaps. AccessRequests are
+// sharded by creation month and a new shard is created once the current one
+// is full.
+type ConfigMapArchive struct {
+	reader       client.Reader
+	writer       client.Writer
+	namespace    string
+	maxShardSize int
+}
+
+// ConfigMapOption defines an optional configuration of the ConfigMapArchive.
+type ConfigMapOption func(*ConfigMapArchive)
+
+// WithMaxShardSize configures the maximum size in bytes of the archived data
+// in each ConfigMap.
+func WithMaxShardSize(size int) ConfigMapOption {
+	return func(a *ConfigMapArchive) {
+		a.maxShardSize = size
+	}
+}
+
+// NewConfigMapArchive returns a ConfigMapArchive storing the ConfigMaps in
+// the given namespace. The reader should not be backed by a cache to avoid
+// watching all ConfigMaps in the cluster.
+func NewConfigMapArchive(reader client.Reader, writer client.Writer, namespace string, opts ...ConfigMapOption) *ConfigMapArchive {
+	a := &ConfigMapArchive{
+		reader:       reader,
+		writer:       writer,
+		namespace:    namespace,
+		maxShardSize: defaultMaxShardSize,
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// Archive stores the given AccessRequest in the shard of its creation month.
+func (a *ConfigMapArchive) Archive(ctx context.Context, ar *api.AccessRequest) error {
+	data, err := marshal(ar)
+	if err != nil {
+		return err
+	}
+	key := entryName(ar)
+	if len(key)+len(data) > a.maxShardSize {
+		return fmt.Errorf("AccessRequest %s/%s is too large to be archived", ar.GetNamespace(), ar.GetName())
+	}
+	month := ar.GetCreationTimestamp().UTC().Format("2006-01")
+	retriable := func(err error) bool {
+		return apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err)
+	}
+	return retry.OnError(retry.DefaultRetry, retriable, func() error {
+		shards, err := a.listShards(ctx, client.MatchingLabels{ArchiveLabel: "true", ArchiveMonthLabel: month})
+		if err != nil {
+			return err
+		}
+		for _, shard := range shards {
+			old, found := shard.Data[key]
+			if !found {
+				continue
+			}
+			if shardSize(shard)-len(old)+len(data) <= a.maxShardSize {
+				shard.Data[key] = string(data)
+				return a.update(ctx, shard)
+			}
+			// the updated entry doesn't fit in its shard anymore so it is
+			// removed and stored as a new entry
+			delete(shard.Data, key)
+			err = a.update(ctx, shard)
+			if err != nil {
+				return err
+			}
+			break
+		}
+		if len(shards) > 0 {
+			last := shards[len(shards)-1]
+			if shardSize(last)+len(key)+len(data) <= a.maxShardSize {
+				last.Data[key] = string(data)
+				return a.update(ctx, last)
+			}
+		}
+		shard := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-%s-%03d", shardPrefix, month, len(shards)),
+				Namespace: a.namespace,
+				Labels: map[string]string{
+					ArchiveLabel:      "true",
+					ArchiveMonthLabel: month,
+				},
+			},
+			Data: map[string]string{key: string(data)},
+		}
+		err = a.writer.Create(ctx, shard)
+		if err != nil {
+			return fmt.Errorf("error creating archive shard %s: %w", shard.GetName(), err)
+		}
+		return nil
+	})
+}
+
+// List returns the AccessRequests archived in all shards.
+func (a *ConfigMapArchive) List(ctx context.Context, namespace string) ([]*api.AccessRequest, error) {
+	shards, err := a.listShards(ctx, client.MatchingLabels{ArchiveLabel: "true"})
+	if err != nil {
+		return nil, err
+	}
+	result := []*api.AccessRequest{}
+	for _, shard := range shards {
+		for key, data := range shard.Data {
+			ar, err := unmarshal([]byte(data))
+			if err != nil {
+				return nil, fmt.Errorf("error reading %s from archive shard %s: %w", key, shard.GetName(), err)
+			}
+			if namespace == "" || ar.GetNamespace() == namespace {
+				result = append(result, ar)
+			}
+		}
+	}
+	return result, nil
+}
+
+// listShards returns the shards matching the given labels sorted by name.
+func (a *ConfigMapArchive) listShards(ctx context.Context, labels client.MatchingLabels) ([]*corev1.ConfigMap, error) {
+	list := &corev1.ConfigMapList{}
+	err := a.reader.List(ctx, list, client.InNamespace(a.namespace), labels)
+	if err != nil {
+		return nil, fmt.Errorf("error listing archive shards: %w", err)
+	}
+	shards := []*corev1.ConfigMap{}
+	for i := range list.Items {
+		shards = append(shards, &list.Items[i])
+	}
+	slices.SortFunc(shards, func(a, b *corev1.ConfigMap) int {
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+	return shards, nil
+}
+
+func (a *ConfigMapArchive) update(ctx context.Context, shard *corev1.ConfigMap) error {
+	err := a.writer.Update(ctx, shard)
+	if err != nil {
+		return fmt.Errorf("error updating archive shard %s: %w", shard.GetName(), err)
+	}
+	return nil
+}
+
+func shardSize(cm *corev1.ConfigMap) int {
+	size := 0
+	for key, value := range cm.Data {
+		size += len(key) + len(value)
+	}
+	return size
+}
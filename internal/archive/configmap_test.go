@@ -0,0 +1,148 @@
+package archive_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/argoproj-labs/argocd-ephemeral-access/internal/archive"
+)
+
+func newFakeClient(t *testing.T) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).Build()
+}
+
+func listShards(t *testing.T, c client.Client) []corev1.ConfigMap {
+	t.Helper()
+	list := &corev1.ConfigMapList{}
+	require.NoError(t, c.List(context.Background(), list, client.InNamespace("archive-ns")))
+	return list.Items
+}
+
+func TestConfigMapArchive(t *testing.T) {
+	t.Run("will archive AccessRequests in the shard of the creation month", func(t *testing.T) {
+		// Given
+		c := newFakeClient(t)
+		a := archive.NewConfigMapArchive(c, c, "archive-ns")
+
+		// When
+		require.NoError(t, a.Archive(context.Background(), newAccessRequest("first", "some-ns")))
+		require.NoError(t, a.Archive(context.Background(), newAccessRequest("second", "some-ns")))
+
+		// Then
+		shards := listShards(t, c)
+		require.Len(t, shards, 1)
+		assert.Equal(t, "ephemeral-access-archive-2024-03-000", shards[0].GetName())
+		assert.Equal(t, "true", shards[0].GetLabels()[archive.ArchiveLabel])
+		assert.Equal(t, "2024-03", shards[0].GetLabels()[archive.ArchiveMonthLabel])
+		assert.Len(t, shards[0].Data, 2)
+		assert.Contains(t, shards[0].Data, "some-ns_first_first-uid.json")
+	})
+	t.Run("will create a new shard once the current one is full", func(t *testing.T) {
+		// Given
+		c := newFakeClient(t)
+		a := archive.NewConfigMapArchive(c, c, "archive-ns", archive.WithMaxShardSize(600))
+
+		// When
+		require.NoError(t, a.Archive(context.Background(), newAccessRequest("first", "some-ns")))
+		require.NoError(t, a.Archive(context.Background(), newAccessRequest("second", "some-ns")))
+
+		// Then
+		shards := listShards(t, c)
+		require.Len(t, shards, 2)
+		assert.Equal(t, "ephemeral-access-archive-2024-03-000", shards[0].GetName())
+		assert.Equal(t, "ephemeral-access-archive-2024-03-001", shards[1].GetName())
+		archived, err := a.List(context.Background(), "some-ns")
+		require.NoError(t, err)
+		assert.Len(t, archived, 2)
+	})
+	t.Run("will replace AccessRequests archived again", func(t *testing.T) {
+		// Given
+		c := newFakeClient(t)
+		a := archive.NewConfigMapArchive(c, c, "archive-ns")
+		ar := newAccessRequest("some-ar", "some-ns")
+		require.NoError(t, a.Archive(context.Background(), ar))
+		ar.Spec.Justification = "updated"
+
+		// When
+		err := a.Archive(context.Background(), ar)
+
+		// Then
+		require.NoError(t, err)
+		archived, err := a.List(context.Background(), "")
+		require.NoError(t, err)
+		require.Len(t, archived, 1)
+		assert.Equal(t, "updated", archived[0].Spec.Justification)
+	})
+	t.Run("will move AccessRequests archived again to a new shard if they no longer fit", func(t *testing.T) {
+		// Given
+		c := newFakeClient(t)
+		maxShardSize := 1200
+		a := archive.NewConfigMapArchive(c, c, "archive-ns", archive.WithMaxShardSize(maxShardSize))
+		ar := newAccessRequest("first", "some-ns")
+		require.NoError(t, a.Archive(context.Background(), ar))
+		require.NoError(t, a.Archive(context.Background(), newAccessRequest("second", "some-ns")))
+		shards := listShards(t, c)
+		require.Len(t, shards, 1)
+		size := 0
+		for key, value := range shards[0].Data {
+			size += len(key) + len(value)
+		}
+		ar.Spec.Justification = strings.Repeat("x", maxShardSize-size+1)
+
+		// When
+		err := a.Archive(context.Background(), ar)
+
+		// Then
+		require.NoError(t, err)
+		shards = listShards(t, c)
+		require.Len(t, shards, 2)
+		assert.NotContains(t, shards[0].Data, "some-ns_first_first-uid.json")
+		assert.Contains(t, shards[1].Data, "some-ns_first_first-uid.json")
+		archived, err := a.List(context.Background(), "some-ns")
+		require.NoError(t, err)
+		require.Len(t, archived, 2)
+		for _, archivedAR := range archived {
+			if archivedAR.GetName() == "first" {
+				assert.Equal(t, ar.Spec.Justification, archivedAR.Spec.Justification)
+			}
+		}
+	})
+	t.Run("will list AccessRequests of the given namespace", func(t *testing.T) {
+		// Given
+		c := newFakeClient(t)
+		a := archive.NewConfigMapArchive(c, c, "archive-ns")
+		require.NoError(t, a.Archive(context.Background(), newAccessRequest("some-ar", "some-ns")))
+		require.NoError(t, a.Archive(context.Background(), newAccessRequest("other-ar", "other-ns")))
+
+		// When
+		archived, err := a.List(context.Background(), "some-ns")
+
+		// Then
+		require.NoError(t, err)
+		require.Len(t, archived, 1)
+		assert.Equal(t, "some-ar", archived[0].GetName())
+	})
+	t.Run("will return error if the AccessRequest is larger than a shard", func(t *testing.T) {
+		// Given
+		c := newFakeClient(t)
+		a := archive.NewConfigMapArchive(c, c, "archive-ns", archive.WithMaxShardSize(100))
+
+		// When
+		err := a.Archive(context.Background(), newAccessRequest("some-ar", "some-ns"))
+
+		// Then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "too large to be archived")
+	})
+}
@@ -0,0 +1,108 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
+)
+
+// FileArchive stores every AccessRequest as a JSON file in a directory of the
+// local filesystem. Files are grouped in subdirectories by creation month.
+// Any storage that can be mounted as a volume (e.g. NFS or S3-compatible
+// buckets through a CSI driver) can be used.
+type FileArchive struct {
+	root string
+}
+
+// NewFileArchive returns a FileArchive storing the files in the given root
+// directory. The directory is created if it doesn't exist.
+func NewFileArchive(root string) (*FileArchive, error) {
+	err := os.MkdirAll(root, 0o750)
+	if err != nil {
+		return nil, fmt.Errorf("error creating archive directory %s: %w", root, err)
+	}
+	return &FileArchive{root: root}, nil
+}
+
+// OpenFileArchive returns a FileArchive using the existing root directory.
+// Unlike NewFileArchive, the directory isn't created so a missing volume is
+// reported as an error instead of being read as an empty archive.
+func OpenFileArchive(root string) (*FileArchive, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf("error opening archive directory %s: %w", root, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("archive path %s is not a directory", root)
+	}
+	return &FileArchive{root: root}, nil
+}
+
+// Archive writes the given AccessRequest in the directory of its creation
+// month. The file is replaced atomically if it already exists.
+func (a *FileArchive) Archive(ctx context.Context, ar *api.AccessRequest) error {
+	data, err := marshal(ar)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(a.root, ar.GetCreationTimestamp().UTC().Format("2006-01"))
+	err = os.MkdirAll(dir, 0o750)
+	if err != nil {
+		return fmt.Errorf("error creating archive directory %s: %w", dir, err)
+	}
+	tmp, err := os.CreateTemp(dir, ".archive-*")
+	if err != nil {
+		return fmt.Errorf("error creating archive file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("error writing archive file: %w", err)
+	}
+	path := filepath.Join(dir, entryName(ar))
+	err = os.Rename(tmp.Name(), path)
+	if err != nil {
+		return fmt.Errorf("error renaming archive file %s: %w", path, err)
+	}
+	return nil
+}
+
+// List reads all AccessRequests archived in the root directory.
+func (a *FileArchive) List(ctx context.Context, namespace string) ([]*api.AccessRequest, error) {
+	result := []*api.AccessRequest{}
+	err := filepath.WalkDir(a.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".json") {
+			return nil
+		}
+		if namespace != "" && !strings.HasPrefix(d.Name(), namespace+"_") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading archive file %s: %w", path, err)
+		}
+		ar, err := unmarshal(data)
+		if err != nil {
+			return fmt.Errorf("error reading archive file %s: %w", path, err)
+		}
+		if namespace == "" || ar.GetNamespace() == namespace {
+			result = append(result, ar)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing archive files: %w", err)
+	}
+	return result, nil
+}
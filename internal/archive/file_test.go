@@ -0,0 +1,161 @@
+package archive_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
+	"github.com/argoproj-labs/argocd-ephemeral-access/internal/archive"
+	"github.com/argoproj-labs/argocd-ephemeral-access/test/utils"
+)
+
+func newAccessRequest(name, namespace string) *api.AccessRequest {
+	ar := utils.NewAccessRequest(name, namespace, "some-app", "argocd", "some-role", "ephemeral", "user-id", "some-user")
+	ar.SetUID(types.UID(name + "-uid"))
+	ar.SetResourceVersion("123")
+	ar.SetCreationTimestamp(metav1.NewTime(time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)))
+	ar.Status.RequestState = api.ExpiredStatus
+	return ar
+}
+
+func TestFileArchive(t *testing.T) {
+	t.Run("will archive AccessRequests by creation month", func(t *testing.T) {
+		// Given
+		root := t.TempDir()
+		a, err := archive.NewFileArchive(root)
+		require.NoError(t, err)
+		ar := newAccessRequest("some-ar", "some-ns")
+
+		// When
+		err = a.Archive(context.Background(), ar)
+
+		// Then
+		require.NoError(t, err)
+		assert.FileExists(t, filepath.Join(root, "2024-03", "some-ns_some-ar_some-ar-uid.json"))
+		archived, err := a.List(context.Background(), "")
+		require.NoError(t, err)
+		require.Len(t, archived, 1)
+		assert.Equal(t, "some-ar", archived[0].GetName())
+		assert.Equal(t, api.ExpiredStatus, archived[0].Status.RequestState)
+		assert.Empty(t, archived[0].GetResourceVersion())
+	})
+	t.Run("will replace AccessRequests archived again", func(t *testing.T) {
+		// Given
+		a, err := archive.NewFileArchive(t.TempDir())
+		require.NoError(t, err)
+		ar := newAccessRequest("some-ar", "some-ns")
+		require.NoError(t, a.Archive(context.Background(), ar))
+		ar.Status.RequestState = api.RevokedStatus
+
+		// When
+		err = a.Archive(context.Background(), ar)
+
+		// Then
+		require.NoError(t, err)
+		archived, err := a.List(context.Background(), "")
+		require.NoError(t, err)
+		require.Len(t, archived, 1)
+		assert.Equal(t, api.RevokedStatus, archived[0].Status.RequestState)
+	})
+	t.Run("will list AccessRequests of the given namespace", func(t *testing.T) {
+		// Given
+		a, err := archive.NewFileArchive(t.TempDir())
+		require.NoError(t, err)
+		require.NoError(t, a.Archive(context.Background(), newAccessRequest("some-ar", "some-ns")))
+		require.NoError(t, a.Archive(context.Background(), newAccessRequest("other-ar", "other-ns")))
+
+		// When
+		archived, err := a.List(context.Background(), "other-ns")
+
+		// Then
+		require.NoError(t, err)
+		require.Len(t, archived, 1)
+		assert.Equal(t, "other-ar", archived[0].GetName())
+	})
+	t.Run("will return error if an archived file is invalid", func(t *testing.T) {
+		// Given
+		root := t.TempDir()
+		a, err := archive.NewFileArchive(root)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(root, "some-ns_invalid.json"), []byte("not json"), 0o600))
+
+		// When
+		_, err = a.List(context.Background(), "")
+
+		// Then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "some-ns_invalid.json")
+	})
+}
+
+func TestNew(t *testing.T) {
+	t.Run("will return error if the type is not supported", func(t *testing.T) {
+		// When
+		a, err := archive.New("s3", "some-ns", "", nil, nil)
+
+		// Then
+		require.Error(t, err)
+		assert.Nil(t, a)
+		assert.Contains(t, err.Error(), `unsupported archive type "s3"`)
+	})
+	t.Run("will return error if the file archive has no path", func(t *testing.T) {
+		// When
+		_, err := archive.New(archive.TypeFile, "", "", nil, nil)
+
+		// Then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "path is required")
+	})
+}
+
+func TestNewReader(t *testing.T) {
+	t.Run("will read the existing file archive", func(t *testing.T) {
+		// Given
+		root := t.TempDir()
+		a, err := archive.NewFileArchive(root)
+		require.NoError(t, err)
+		require.NoError(t, a.Archive(context.Background(), newAccessRequest("some-ar", "some-ns")))
+
+		// When
+		reader, err := archive.NewReader(archive.TypeFile, "", root, nil)
+
+		// Then
+		require.NoError(t, err)
+		archived, err := reader.List(context.Background(), "")
+		require.NoError(t, err)
+		assert.Len(t, archived, 1)
+	})
+	t.Run("will return error if the file archive directory doesn't exist", func(t *testing.T) {
+		// Given
+		root := filepath.Join(t.TempDir(), "missing")
+
+		// When
+		reader, err := archive.NewReader(archive.TypeFile, "", root, nil)
+
+		// Then
+		require.Error(t, err)
+		assert.Nil(t, reader)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+		assert.NoDirExists(t, root, "the directory must not be created")
+	})
+	t.Run("will return error if the file archive path is not a directory", func(t *testing.T) {
+		// Given
+		path := filepath.Join(t.TempDir(), "some-file")
+		require.NoError(t, os.WriteFile(path, []byte("{}"), 0o600))
+
+		// When
+		_, err := archive.NewReader(archive.TypeFile, "", path, nil)
+
+		// Then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is not a directory")
+	})
+}
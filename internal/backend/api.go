@@ -1,15 +1,20 @@
 package backend
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
 	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
 	"github.com/argoproj-labs/argocd-ephemeral-access/pkg/log"
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/sse"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
@@ -19,15 +24,21 @@ const (
 	APIVersion = "0.0.1"
 )
 
+// ArgoCDUserHeaders defines the headers identifying the user that are sent
+// by Argo CD API server to proxy extensions.
+type ArgoCDUserHeaders struct {
+	ArgoCDUsername   string `header:"Argocd-Username" required:"true" example:"some-user@acme.org" doc:"The trusted ArgoCD username header. This should be automatically sent by Argo CD API server."`
+	ArgoCDUserGroups string `header:"Argocd-User-Groups" required:"true" example:"group1,group2" doc:"The trusted ArgoCD user groups header. This should be automatically sent by Argo CD API server."`
+	ArgoCDNamespace  string `header:"Argocd-Namespace" required:"true" example:"argocd" doc:"The trusted namespace of the ArgoCD control plane. This should be automatically sent by Argo CD API server."`
+	ArgoCDUserId     string `header:"Argocd-User-Id" required:"false" example:"some-user" doc:"The trusted ArgoCD user ID header. This should be automatically sent by Argo CD API server 3.2+."`
+}
+
 // ArgoCDHeaders defines the required headers that are sent by Argo CD
 // API server to proxy extensions.
 type ArgoCDHeaders struct {
-	ArgoCDUsername        string `header:"Argocd-Username" required:"true" example:"some-user@acme.org" doc:"The trusted ArgoCD username header. This should be automatically sent by Argo CD API server."`
-	ArgoCDUserGroups      string `header:"Argocd-User-Groups" required:"true" example:"group1,group2" doc:"The trusted ArgoCD user groups header. This should be automatically sent by Argo CD API server."`
+	ArgoCDUserHeaders
 	ArgoCDApplicationName string `header:"Argocd-Application-Name" required:"true" example:"some-namespace:app-name" doc:"The trusted ArgoCD application header. This should be automatically sent by Argo CD API server."`
 	ArgoCDProjectName     string `header:"Argocd-Project-Name" required:"true" example:"some-project-name" doc:"The trusted ArgoCD project header. This should be automatically sent by Argo CD API server."`
-	ArgoCDNamespace       string `header:"Argocd-Namespace" required:"true" example:"argocd" doc:"The trusted namespace of the ArgoCD control plane. This should be automatically sent by Argo CD API server."`
-	ArgoCDUserId          string `header:"Argocd-User-Id" required:"false" example:"some-user" doc:"The trusted ArgoCD user ID header. This should be automatically sent by Argo CD API server 3.2+."`
 }
 
 func (h *ArgoCDHeaders) Application() (namespace string, name string, err error) {
@@ -44,7 +55,7 @@ func (h *ArgoCDHeaders) Application() (namespace string, name string, err error)
 	return parts[0], parts[1], nil
 }
 
-func (h *ArgoCDHeaders) Groups() []string {
+func (h *ArgoCDUserHeaders) Groups() []string {
 	return strings.Split(h.ArgoCDUserGroups, ",")
 }
 
@@ -53,6 +64,123 @@ type ListAccessRequestInput struct {
 	ArgoCDHeaders
 }
 
+// ListUserAccessRequestsInput defines the input parameters to list the user
+// access requests across all applications.
+type ListUserAccessRequestsInput struct {
+	ArgoCDUserHeaders
+	Status   string `query:"status" enum:"INITIATED,REQUESTED,SCHEDULED,GRANTED,EXPIRED,DENIED,INVALID,TIMEOUT,REVOKED,CANCELLED" example:"GRANTED" doc:"Only return access requests in the given status."`
+	Limit    int    `query:"limit" minimum:"1" maximum:"500" default:"50" doc:"The maximum number of access requests to return."`
+	Continue string `query:"continue" doc:"The token returned in the previous page to retrieve the next page."`
+}
+
+// ListUserAccessRequestsResponse defines the response of the user access
+// requests list.
+type ListUserAccessRequestsResponse struct {
+	Body ListUserAccessRequestsResponseBody
+}
+
+// ListUserAccessRequestsResponseBody defines the response body of the user
+// access requests list.
+type ListUserAccessRequestsResponseBody struct {
+	Items    []AccessRequestResponseBody `json:"items"`
+	Continue string                      `json:"continue,omitempty" doc:"The token to retrieve the next page. Empty if there are no more access requests."`
+}
+
+// AdminAccessRequestsQuery defines the query parameters used by admins to
+// search access requests.
+type AdminAccessRequestsQuery struct {
+	Username    string    `query:"username" example:"some-user@acme.org" doc:"Only return access requests created by the given user."`
+	Application string    `query:"application" example:"some-namespace:app-name" doc:"Only return access requests for the given application in the <namespace>:<name> format. The namespace can be omitted to match applications in any namespace."`
+	Project     string    `query:"project" example:"some-project" doc:"Only return access requests targeting the given project."`
+	Role        string    `query:"role" example:"custom-role-template" doc:"Only return access requests for the given role template."`
+	Status      string    `query:"status" enum:"INITIATED,REQUESTED,SCHEDULED,GRANTED,EXPIRED,DENIED,INVALID,TIMEOUT,REVOKED,CANCELLED" example:"GRANTED" doc:"Only return access requests in the given status."`
+	From        time.Time `query:"from" example:"2024-01-01T00:00:00Z" doc:"Only return access requests created at or after the given timestamp (RFC3339 format)."`
+	To          time.Time `query:"to" example:"2024-04-01T00:00:00Z" doc:"Only return access requests created before the given timestamp (RFC3339 format)."`
+}
+
+// Filter returns the AccessRequestFilter defined by the query parameters.
+func (q *AdminAccessRequestsQuery) Filter() (*AccessRequestFilter, error) {
+	filter := &AccessRequestFilter{
+		Username: q.Username,
+		Project:  q.Project,
+		Role:     q.Role,
+		Status:   api.Status(strings.ToLower(q.Status)),
+	}
+	if q.Application != "" {
+		parts := strings.Split(q.Application, ":")
+		switch {
+		case len(parts) == 1:
+			filter.ApplicationName = parts[0]
+		case len(parts) == 2 && parts[1] != "":
+			filter.ApplicationNamespace = parts[0]
+			filter.ApplicationName = parts[1]
+		default:
+			return nil, fmt.Errorf("invalid value for %q parameter: expected format: [<namespace>:]<app-name>", "application")
+		}
+	}
+	if !q.From.IsZero() {
+		filter.CreatedAfter = &q.From
+	}
+	if !q.To.IsZero() {
+		filter.CreatedBefore = &q.To
+	}
+	if filter.CreatedAfter != nil && filter.CreatedBefore != nil && !filter.CreatedAfter.Before(*filter.CreatedBefore) {
+		return nil, fmt.Errorf("invalid time range: %q must be before %q", "from", "to")
+	}
+	return filter, nil
+}
+
+// AdminListAccessRequestsInput defines the input parameters used by admins to
+// list access requests.
+type AdminListAccessRequestsInput struct {
+	ArgoCDUserHeaders
+	AdminAccessRequestsQuery
+	Limit    int    `query:"limit" minimum:"1" maximum:"500" default:"50" doc:"The maximum number of access requests to return."`
+	Continue string `query:"continue" doc:"The token returned in the previous page to retrieve the next page."`
+}
+
+// AdminListAccessRequestsResponse defines the response of the admin access
+// requests list.
+type AdminListAccessRequestsResponse struct {
+	Body AdminListAccessRequestsResponseBody
+}
+
+// AdminListAccessRequestsResponseBody defines the response body of the admin
+// access requests list.
+type AdminListAccessRequestsResponseBody struct {
+	Items    []AdminAccessRequestResponseBody `json:"items"`
+	Continue string                           `json:"continue,omitempty" doc:"The token to retrieve the next page. Empty if there are no more access requests."`
+}
+
+// AdminAccessRequestResponseBody defines the access request fields returned
+// to admins.
+type AdminAccessRequestResponseBody struct {
+	AccessRequestResponseBody
+	TargetProject string `json:"targetProject,omitempty" example:"some-project" doc:"The project the access request is associated with."`
+	CreatedAt     string `json:"createdAt" example:"2024-02-14T18:25:50Z" doc:"The timestamp the access request was created (RFC3339 format)." format:"date-time"`
+}
+
+// AdminExportAccessRequestsInput defines the input parameters used by admins
+// to export access requests.
+type AdminExportAccessRequestsInput struct {
+	ArgoCDUserHeaders
+	AdminAccessRequestsQuery
+}
+
+// AdminExportAccessRequestsResponse defines the response of the access
+// requests export.
+type AdminExportAccessRequestsResponse struct {
+	ContentType        string `header:"Content-Type"`
+	ContentDisposition string `header:"Content-Disposition"`
+	Body               []byte
+}
+
+// WatchUserAccessRequestsInput defines the input parameters to watch the user
+// access requests status changes.
+type WatchUserAccessRequestsInput struct {
+	ArgoCDUserHeaders
+}
+
 // ListAllowedRolesInput defines the input parameters list of allowed roles.
 type ListAllowedRolesInput struct {
 	ArgoCDHeaders
@@ -72,6 +200,40 @@ type ListAllowedRolesResponseBody struct {
 type AllowedRoleResponseBody struct {
 	RoleName        string `json:"roleName" example:"custom-role-template" doc:"The role template name to request."`
 	RoleDisplayName string `json:"roleDisplayName" example:"Write (DevOps)" doc:"The human friendly name of the role that can be used to display to users."`
+	BreakGlass      bool   `json:"breakGlass,omitempty" doc:"If emergency break-glass access can be requested for this role."`
+}
+
+// ExplainRoleInput defines the input parameters to explain why the user is
+// or isn't allowed to request a role.
+type ExplainRoleInput struct {
+	ArgoCDHeaders
+	RoleName string `path:"roleName" example:"custom-role-template" doc:"The role template name to be explained."`
+}
+
+// ExplainRoleResponse defines the response of the role explanation.
+type ExplainRoleResponse struct {
+	Body ExplainRoleResponseBody
+}
+
+// ExplainRoleResponseBody defines the response body of the role explanation.
+type ExplainRoleResponseBody struct {
+	RoleName   string                         `json:"roleName" example:"custom-role-template" doc:"The explained role template name."`
+	Allowed    bool                           `json:"allowed" doc:"True if the user is allowed to request the role."`
+	UserGroups []string                       `json:"userGroups" example:"[\"group1\"]" doc:"The user groups used to match the AccessBinding subjects."`
+	Bindings   []AccessBindingExplanationBody `json:"bindings" doc:"The evaluation of every AccessBinding referencing the role."`
+}
+
+// AccessBindingExplanationBody describes how an AccessBinding was evaluated.
+type AccessBindingExplanationBody struct {
+	Name            string   `json:"name" example:"some-binding" doc:"The AccessBinding name."`
+	Namespace       string   `json:"namespace" example:"argocd" doc:"The AccessBinding namespace."`
+	Condition       string   `json:"condition,omitempty" example:"app.metadata.labels.env == 'prod'" doc:"The AccessBinding If condition."`
+	ConditionResult string   `json:"conditionResult" enum:"none,true,false,error" doc:"The result of the If condition evaluation. 'none' is returned if the binding has no condition."`
+	ConditionError  string   `json:"conditionError,omitempty" doc:"The error returned when evaluating the If condition."`
+	Subjects        []string `json:"subjects" example:"[\"group1\"]" doc:"The rendered AccessBinding subjects."`
+	SubjectsError   string   `json:"subjectsError,omitempty" doc:"The error returned when rendering the subjects."`
+	MatchedGroups   []string `json:"matchedGroups" example:"[\"group1\"]" doc:"The user groups matching the rendered subjects."`
+	Granting        bool     `json:"granting" doc:"True if this AccessBinding allows the user to request the role."`
 }
 
 // ListAccessRequestResponse defines the list access response parameters.
@@ -92,7 +254,46 @@ type CreateAccessRequestInput struct {
 
 // CreateAccessRequestBody defines the create access response body.
 type CreateAccessRequestBody struct {
-	RoleName string `json:"roleName" example:"custom-role-template" doc:"The role template name to request."`
+	RoleName      string `json:"roleName" example:"custom-role-template" doc:"The role template name to request."`
+	Duration      string `json:"duration,omitempty" example:"30m" doc:"The requested access duration (e.g. 30m, 8h). Must be within the range allowed by the role binding. If not provided, the default duration is used."`
+	Justification string `json:"justification,omitempty" maxLength:"1024" example:"Investigating production incident" doc:"The reason for requesting the elevated access. May be required by the role binding."`
+	TicketRef     string `json:"ticketRef,omitempty" maxLength:"256" example:"OPS-123" doc:"The ticket associated with the access request. May be required by the role binding."`
+	StartsAt      string `json:"startsAt,omitempty" example:"2024-02-14T18:00:00Z" doc:"The timestamp the access should begin (RFC3339 format). If not provided, the access starts as soon as it is granted." format:"date-time"`
+	BreakGlass    bool   `json:"breakGlass,omitempty" doc:"Request emergency access that is granted immediately without approvals. Only available for roles allowing break-glass access. Requires a justification and can't be scheduled."`
+}
+
+// requestedStartTime parses and returns the start time informed in the body.
+// Returns nil if the start time isn't provided.
+func (b *CreateAccessRequestBody) requestedStartTime() (*time.Time, error) {
+	if b.StartsAt == "" {
+		return nil, nil
+	}
+	startsAt, err := time.Parse(time.RFC3339, b.StartsAt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start time %q: %w", b.StartsAt, err)
+	}
+	return &startsAt, nil
+}
+
+// requestedDuration parses and returns the duration informed in the body.
+// Returns zero if the duration isn't provided.
+func (b *CreateAccessRequestBody) requestedDuration() (time.Duration, error) {
+	if b.Duration == "" {
+		return 0, nil
+	}
+	return parseDuration(b.Duration)
+}
+
+// parseDuration parses the given value making sure it is a positive duration.
+func parseDuration(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: %w", value, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid duration %q: must be positive", value)
+	}
+	return d, nil
 }
 
 // CreateAccessRequestResponse defines the create access response.
@@ -100,33 +301,172 @@ type CreateAccessRequestResponse struct {
 	Body AccessRequestResponseBody
 }
 
+// RevokeAccessRequestInput defines the revoke access input parameters.
+type RevokeAccessRequestInput struct {
+	ArgoCDHeaders
+	Name string `path:"name" example:"some-accessrequest" doc:"The access request name."`
+}
+
+// RevokeAccessRequestResponse defines the revoke access response.
+type RevokeAccessRequestResponse struct {
+	Body AccessRequestResponseBody
+}
+
+// CancelAccessRequestInput defines the cancel access input parameters.
+type CancelAccessRequestInput struct {
+	ArgoCDHeaders
+	Name string `path:"name" example:"some-accessrequest" doc:"The access request name."`
+}
+
+// CancelAccessRequestResponse defines the cancel access response.
+type CancelAccessRequestResponse struct {
+	Body AccessRequestResponseBody
+}
+
+// ExtendAccessRequestInput defines the extend access input parameters.
+type ExtendAccessRequestInput struct {
+	ArgoCDHeaders
+	Name string `path:"name" example:"some-accessrequest" doc:"The access request name."`
+	Body ExtendAccessRequestBody
+}
+
+// ExtendAccessRequestBody defines the extend access request body.
+type ExtendAccessRequestBody struct {
+	Duration string `json:"duration" example:"1h" doc:"The amount of time to be added to the access expiration (e.g. 30m, 1h). Must be within the limits allowed by the role binding."`
+}
+
+// ExtendAccessRequestResponse defines the extend access response.
+type ExtendAccessRequestResponse struct {
+	Body AccessRequestResponseBody
+}
+
+// ListApprovalsInput defines the list approvals input parameters.
+type ListApprovalsInput struct {
+	ArgoCDHeaders
+}
+
+// ListApprovalsResponse defines the list approvals response.
+type ListApprovalsResponse struct {
+	Body ListAccessRequestResponseBody
+}
+
+// ApprovalDecisionInput defines the approve and reject input parameters.
+type ApprovalDecisionInput struct {
+	ArgoCDHeaders
+	Name string `path:"name" example:"some-accessrequest" doc:"The access request name."`
+	Body ApprovalDecisionBody
+}
+
+// ApprovalDecisionBody defines the approve and reject request body.
+type ApprovalDecisionBody struct {
+	Comment string `json:"comment,omitempty" maxLength:"1024" example:"Approved as discussed in the incident channel" doc:"An optional comment explaining the decision."`
+}
+
+// ApprovalDecisionResponse defines the approve and reject response.
+type ApprovalDecisionResponse struct {
+	Body AccessRequestResponseBody
+}
+
+// ApprovalResponseBody defines the approver decision fields returned as part
+// of the access request response body.
+type ApprovalResponseBody struct {
+	Username string `json:"username" example:"some-approver@acme.org" doc:"The approver username."`
+	Decision string `json:"decision" example:"APPROVED" doc:"The approver decision." enum:"APPROVED,REJECTED"`
+	Comment  string `json:"comment,omitempty" example:"Approved as discussed in the incident channel" doc:"The comment provided by the approver."`
+	Time     string `json:"time" example:"2024-02-14T18:25:50Z" doc:"The timestamp of the decision (RFC3339 format)." format:"date-time"`
+}
+
+// GetAccessRequestInput defines the get access request input parameters.
+type GetAccessRequestInput struct {
+	ArgoCDHeaders
+	Name string `path:"name" example:"some-accessrequest" doc:"The access request name."`
+}
+
+// GetAccessRequestResponse defines the get access request response.
+type GetAccessRequestResponse struct {
+	Body AccessRequestDetailResponseBody
+}
+
+// AccessRequestDetailResponseBody defines the access request fields returned
+// when retrieving a single access request.
+type AccessRequestDetailResponseBody struct {
+	AccessRequestResponseBody
+	TargetProject   string                     `json:"targetProject,omitempty" example:"some-project" doc:"The Argo CD project where the access is granted."`
+	RoleName        string                     `json:"roleName,omitempty" example:"ephemeral-custom-role-template-argocd-some-app" doc:"The name of the AppProject role associated to this access request."`
+	RoleDescription string                     `json:"roleDescription,omitempty" example:"Write access to some-app" doc:"The rendered description of the role template associated to this access request."`
+	History         []AccessRequestHistoryBody `json:"history" doc:"The list of status transitions of this access request."`
+}
+
+// AccessRequestHistoryBody defines an access request status transition.
+type AccessRequestHistoryBody struct {
+	Status         string `json:"status" example:"GRANTED" doc:"The access request status." enum:"INITIATED,REQUESTED,SCHEDULED,GRANTED,EXPIRED,DENIED,INVALID,TIMEOUT,REVOKED,CANCELLED"`
+	TransitionTime string `json:"transitionTime" example:"2024-02-14T18:25:50Z" doc:"The timestamp of the transition (RFC3339 format)." format:"date-time"`
+	Details        string `json:"details,omitempty" example:"Access granted" doc:"A human readeable description of the transition."`
+}
+
 // AccessRequestResponseBody defines the access request fields returned as part of
 // the response body.
 type AccessRequestResponseBody struct {
-	Name        string `json:"name" example:"some-accessrequest" doc:"The access request name."`
-	Namespace   string `json:"namespace" example:"some-namespace" doc:"The access request namespace."`
-	Username    string `json:"username" example:"some-user@acme.org" doc:"The user associated with the access request."`
-	Permission  string `json:"permission" example:"Operator Access" doc:"The permission description of the role associated to this access request."`
-	Role        string `json:"role" example:"custom-role-template" doc:"The role template associated to this access request."`
-	RequestedAt string `json:"requestedAt,omitempty" example:"2024-02-14T18:25:50Z" doc:"The timestamp the access was requested (RFC3339 format)." format:"date-time"`
-	Status      string `json:"status,omitempty" example:"GRANTED" doc:"The current access request status." enum:"INITIATED,REQUESTED,GRANTED,EXPIRED,DENIED,INVALID"`
-	ExpiresAt   string `json:"expiresAt,omitempty" example:"2024-02-14T18:25:50Z" doc:"The timestamp the access will expire (RFC3339 format)." format:"date-time"`
-	Message     string `json:"message,omitempty" example:"Click the link to see more details: ..." doc:"A human readeable description with details about the access request."`
+	Name          string `json:"name" example:"some-accessrequest" doc:"The access request name."`
+	Application   string `json:"application" example:"some-namespace:app-name" doc:"The application associated with the access request in the <namespace>:<name> format."`
+	Namespace     string `json:"namespace" example:"some-namespace" doc:"The access request namespace."`
+	Username      string `json:"username" example:"some-user@acme.org" doc:"The user associated with the access request."`
+	Permission    string `json:"permission" example:"Operator Access" doc:"The permission description of the role associated to this access request."`
+	Role          string `json:"role" example:"custom-role-template" doc:"The role template associated to this access request."`
+	RequestedAt   string `json:"requestedAt,omitempty" example:"2024-02-14T18:25:50Z" doc:"The timestamp the access was requested (RFC3339 format)." format:"date-time"`
+	Status        string `json:"status,omitempty" example:"GRANTED" doc:"The current access request status." enum:"INITIATED,REQUESTED,SCHEDULED,GRANTED,EXPIRED,DENIED,INVALID,TIMEOUT,REVOKED,CANCELLED"`
+	StartsAt      string `json:"startsAt,omitempty" example:"2024-02-14T18:00:00Z" doc:"The timestamp the scheduled access will begin (RFC3339 format)." format:"date-time"`
+	ExpiresAt     string `json:"expiresAt,omitempty" example:"2024-02-14T18:25:50Z" doc:"The timestamp the access will expire (RFC3339 format)." format:"date-time"`
+	Message       string `json:"message,omitempty" example:"Click the link to see more details: ..." doc:"A human readeable description with details about the access request."`
+	Justification string `json:"justification,omitempty" example:"Investigating production incident" doc:"The reason provided for requesting the elevated access."`
+	TicketRef     string `json:"ticketRef,omitempty" example:"OPS-123" doc:"The ticket associated with the access request."`
+	BreakGlass    bool   `json:"breakGlass,omitempty" doc:"If this is an emergency break-glass access."`
+
+	RequiredApprovals int                    `json:"requiredApprovals,omitempty" example:"2" doc:"The number of approvals required to grant the access."`
+	Approvals         []ApprovalResponseBody `json:"approvals,omitempty" doc:"The decisions made by approvers."`
 }
 
 // APIHandler is responsible for defining all handlers available as part of the
 // AccessRequest REST API.
 type APIHandler struct {
-	service Service
-	logger  log.Logger
+	service     Service
+	logger      log.Logger
+	adminGroups []string
+}
+
+// APIHandlerOption defines the function signature used to configure the
+// APIHandler.
+type APIHandlerOption func(*APIHandler)
+
+// WithAdminGroups defines the groups allowed to use the admin endpoints. The
+// admin endpoints are disabled if no group is provided.
+func WithAdminGroups(groups []string) APIHandlerOption {
+	return func(h *APIHandler) {
+		h.adminGroups = groups
+	}
 }
 
 // NewAPIHandler will instantiate and return a new APIHandler.
-func NewAPIHandler(s Service, logger log.Logger) *APIHandler {
-	return &APIHandler{
+func NewAPIHandler(s Service, logger log.Logger, opts ...APIHandlerOption) *APIHandler {
+	h := &APIHandler{
 		service: s,
 		logger:  logger,
 	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// isAdmin returns true if at least one of the given groups is configured as
+// an admin group.
+func (h *APIHandler) isAdmin(groups []string) bool {
+	for _, group := range groups {
+		if group != "" && slices.Contains(h.adminGroups, group) {
+			return true
+		}
+	}
+	return false
 }
 
 func (h *APIHandler) listAllowedRolesHandler(ctx context.Context, input *ListAllowedRolesInput) (*ListAllowedRolesResponse, error) {
@@ -163,6 +503,64 @@ func (h *APIHandler) listAllowedRolesHandler(ctx context.Context, input *ListAll
 	return &ListAllowedRolesResponse{Body: toListAllowedRolesResponseBody(abList)}, nil
 }
 
+func (h *APIHandler) explainRoleHandler(ctx context.Context, input *ExplainRoleInput) (*ExplainRoleResponse, error) {
+	appNamespace, appName, err := input.Application()
+	if err != nil {
+		return nil, huma.Error400BadRequest("error getting application name", err)
+	}
+	app, err := h.service.GetApplication(ctx, appName, appNamespace)
+	if err != nil {
+		return nil, h.loggedError(huma.Error500InternalServerError("error getting application", err))
+	}
+	if app == nil {
+		return nil, huma.Error404NotFound("Argo CD Application not found")
+	}
+
+	project, err := h.service.GetAppProject(ctx, input.ArgoCDProjectName, input.ArgoCDNamespace)
+	if err != nil {
+		return nil, h.loggedError(huma.Error500InternalServerError("error getting project", err))
+	}
+	if project == nil {
+		return nil, huma.Error404NotFound("Argo CD AppProject not found")
+	}
+
+	groups := input.Groups()
+	explanations, err := h.service.ExplainAccessBindings(ctx, input.RoleName, input.ArgoCDNamespace, groups, app, project)
+	if err != nil {
+		return nil, h.loggedError(huma.Error500InternalServerError(fmt.Sprintf("error explaining role %s", input.RoleName), err))
+	}
+	return &ExplainRoleResponse{Body: toExplainRoleResponseBody(input.RoleName, groups, explanations)}, nil
+}
+
+func toExplainRoleResponseBody(roleName string, groups []string, explanations []*AccessBindingExplanation) ExplainRoleResponseBody {
+	result := ExplainRoleResponseBody{
+		RoleName:   roleName,
+		UserGroups: groups,
+		Bindings:   []AccessBindingExplanationBody{},
+	}
+	for _, e := range explanations {
+		condition := ""
+		if e.Binding.Spec.If != nil {
+			condition = *e.Binding.Spec.If
+		}
+		result.Bindings = append(result.Bindings, AccessBindingExplanationBody{
+			Name:            e.Binding.GetName(),
+			Namespace:       e.Binding.GetNamespace(),
+			Condition:       condition,
+			ConditionResult: string(e.Condition),
+			ConditionError:  e.ConditionError,
+			Subjects:        e.Subjects,
+			SubjectsError:   e.SubjectsError,
+			MatchedGroups:   e.MatchedGroups,
+			Granting:        e.Granting,
+		})
+		if e.Granting {
+			result.Allowed = true
+		}
+	}
+	return result
+}
+
 func toListAllowedRolesResponseBody(abList []*api.AccessBinding) ListAllowedRolesResponseBody {
 	result := ListAllowedRolesResponseBody{}
 	for _, ab := range abList {
@@ -175,6 +573,7 @@ func toListAllowedRolesResponseBody(abList []*api.AccessBinding) ListAllowedRole
 		item := AllowedRoleResponseBody{
 			RoleName:        ab.Spec.RoleTemplateRef.Name,
 			RoleDisplayName: displayName,
+			BreakGlass:      ab.Spec.BreakGlass != nil,
 		}
 		result.Items = append(result.Items, item)
 	}
@@ -202,12 +601,157 @@ func (h *APIHandler) listAccessRequestHandler(ctx context.Context, input *ListAc
 	return &ListAccessRequestResponse{Body: toListAccessRequestResponseBody(accessRequests)}, nil
 }
 
+func (h *APIHandler) listUserAccessRequestsHandler(ctx context.Context, input *ListUserAccessRequestsInput) (*ListUserAccessRequestsResponse, error) {
+	opts := &ListOptions{
+		Status:   api.Status(strings.ToLower(input.Status)),
+		Limit:    input.Limit,
+		Continue: input.Continue,
+	}
+	page, err := h.service.ListSubjectAccessRequests(ctx, input.ArgoCDNamespace, input.ArgoCDUsername, opts)
+	if err != nil {
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			return nil, huma.Error400BadRequest(validationErr.Error())
+		}
+		return nil, h.loggedError(huma.Error500InternalServerError(fmt.Sprintf("error listing access requests for user %s", input.ArgoCDUsername), err))
+	}
+	return &ListUserAccessRequestsResponse{
+		Body: ListUserAccessRequestsResponseBody{
+			Items:    toListAccessRequestResponseBody(page.Items).Items,
+			Continue: page.Continue,
+		},
+	}, nil
+}
+
+func (h *APIHandler) adminListAccessRequestsHandler(ctx context.Context, input *AdminListAccessRequestsInput) (*AdminListAccessRequestsResponse, error) {
+	if !h.isAdmin(input.Groups()) {
+		return nil, huma.Error403Forbidden(fmt.Sprintf("user %s is not an admin", input.ArgoCDUsername))
+	}
+	filter, err := input.Filter()
+	if err != nil {
+		return nil, huma.Error400BadRequest("invalid query parameters", err)
+	}
+	opts := &ListOptions{
+		Limit:    input.Limit,
+		Continue: input.Continue,
+	}
+	page, err := h.service.SearchAccessRequests(ctx, input.ArgoCDNamespace, filter, opts)
+	if err != nil {
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			return nil, huma.Error400BadRequest(validationErr.Error())
+		}
+		return nil, h.loggedError(huma.Error500InternalServerError("error searching access requests", err))
+	}
+	items := []AdminAccessRequestResponseBody{}
+	for _, ar := range page.Items {
+		items = append(items, toAdminAccessRequestResponseBody(ar))
+	}
+	return &AdminListAccessRequestsResponse{
+		Body: AdminListAccessRequestsResponseBody{
+			Items:    items,
+			Continue: page.Continue,
+		},
+	}, nil
+}
+
+func (h *APIHandler) adminListArchivedAccessRequestsHandler(ctx context.Context, input *AdminListAccessRequestsInput) (*AdminListAccessRequestsResponse, error) {
+	if !h.isAdmin(input.Groups()) {
+		return nil, huma.Error403Forbidden(fmt.Sprintf("user %s is not an admin", input.ArgoCDUsername))
+	}
+	filter, err := input.Filter()
+	if err != nil {
+		return nil, huma.Error400BadRequest("invalid query parameters", err)
+	}
+	opts := &ListOptions{
+		Limit:    input.Limit,
+		Continue: input.Continue,
+	}
+	page, err := h.service.SearchArchivedAccessRequests(ctx, input.ArgoCDNamespace, filter, opts)
+	if err != nil {
+		if errors.Is(err, ErrArchiveNotConfigured) {
+			return nil, huma.Error404NotFound(err.Error())
+		}
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			return nil, huma.Error400BadRequest(validationErr.Error())
+		}
+		return nil, h.loggedError(huma.Error500InternalServerError("error searching archived access requests", err))
+	}
+	items := []AdminAccessRequestResponseBody{}
+	for _, ar := range page.Items {
+		items = append(items, toAdminAccessRequestResponseBody(ar))
+	}
+	return &AdminListAccessRequestsResponse{
+		Body: AdminListAccessRequestsResponseBody{
+			Items:    items,
+			Continue: page.Continue,
+		},
+	}, nil
+}
+
+func (h *APIHandler) adminExportAccessRequestsHandler(ctx context.Context, input *AdminExportAccessRequestsInput) (*AdminExportAccessRequestsResponse, error) {
+	if !h.isAdmin(input.Groups()) {
+		return nil, huma.Error403Forbidden(fmt.Sprintf("user %s is not an admin", input.ArgoCDUsername))
+	}
+	filter, err := input.Filter()
+	if err != nil {
+		return nil, huma.Error400BadRequest("invalid query parameters", err)
+	}
+	page, err := h.service.SearchAccessRequests(ctx, input.ArgoCDNamespace, filter, nil)
+	if err != nil {
+		return nil, h.loggedError(huma.Error500InternalServerError("error searching access requests", err))
+	}
+	var buf bytes.Buffer
+	err = writeAccessRequestsCSV(&buf, page.Items)
+	if err != nil {
+		return nil, h.loggedError(huma.Error500InternalServerError("error exporting access requests", err))
+	}
+	return &AdminExportAccessRequestsResponse{
+		ContentType:        "text/csv",
+		ContentDisposition: `attachment; filename="accessrequests.csv"`,
+		Body:               buf.Bytes(),
+	}, nil
+}
+
+func (h *APIHandler) watchUserAccessRequestsHandler(ctx context.Context, input *WatchUserAccessRequestsInput, send sse.Sender) {
+	events, err := h.service.WatchSubjectAccessRequests(ctx, input.ArgoCDNamespace, input.ArgoCDUsername)
+	if err != nil {
+		h.logger.Error(err, "error watching access requests", "username", input.ArgoCDUsername)
+		return
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ar, ok := <-events:
+			if !ok {
+				return
+			}
+			err := send.Data(toAccessRequestResponseBody(ar))
+			if err != nil {
+				h.logger.Debug("Stopping access requests watch: error sending event", "username", input.ArgoCDUsername, "error", err)
+				return
+			}
+		}
+	}
+}
+
 func (h *APIHandler) createAccessRequestHandler(ctx context.Context, input *CreateAccessRequestInput) (*CreateAccessRequestResponse, error) {
 	appNamespace, appName, err := input.Application()
 	if err != nil {
 		return nil, huma.Error400BadRequest("invalid application", err)
 	}
 
+	duration, err := input.Body.requestedDuration()
+	if err != nil {
+		return nil, huma.Error400BadRequest("invalid duration", err)
+	}
+	startsAt, err := input.Body.requestedStartTime()
+	if err != nil {
+		return nil, huma.Error400BadRequest("invalid start time", err)
+	}
+
 	// Check if AR already exist
 	key := &AccessRequestKey{
 		Namespace:            input.ArgoCDNamespace,
@@ -251,8 +795,19 @@ func (h *APIHandler) createAccessRequestHandler(ctx context.Context, input *Crea
 	}
 
 	// Create Access Request
-	ar, err = h.service.CreateAccessRequest(ctx, key, grantingBinding)
+	opts := &AccessRequestOptions{
+		Duration:      duration,
+		Justification: input.Body.Justification,
+		TicketRef:     input.Body.TicketRef,
+		StartsAt:      startsAt,
+		BreakGlass:    input.Body.BreakGlass,
+	}
+	ar, err = h.service.CreateAccessRequest(ctx, key, grantingBinding, opts)
 	if err != nil {
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			return nil, huma.Error400BadRequest(validationErr.Error())
+		}
 		return nil, h.loggedError(huma.Error500InternalServerError(fmt.Sprintf("error creating access request for role %s", grantingBinding.Spec.RoleTemplateRef.Name), err))
 	}
 
@@ -260,17 +815,209 @@ func (h *APIHandler) createAccessRequestHandler(ctx context.Context, input *Crea
 
 }
 
+func (h *APIHandler) getAccessRequestHandler(ctx context.Context, input *GetAccessRequestInput) (*GetAccessRequestResponse, error) {
+	ar, err := h.getApplicationAccessRequest(ctx, &input.ArgoCDHeaders, input.Name)
+	if err != nil {
+		return nil, err
+	}
+	isSubject := ar.Spec.Subject.Username == input.ArgoCDUsername
+	isApprover := ar.RequiresApproval() && ar.Spec.Approval.IsApprover(input.Groups())
+	if !isSubject && !isApprover {
+		return nil, huma.Error403Forbidden("AccessRequest can only be viewed by the requester or approvers")
+	}
+
+	projName := ar.Status.TargetProject
+	if projName == "" {
+		projName = input.ArgoCDProjectName
+	}
+	rt, err := h.service.GetRenderedRoleTemplate(ctx, ar, projName)
+	if err != nil {
+		return nil, h.loggedError(huma.Error500InternalServerError(fmt.Sprintf("error retrieving role template for access request %s", input.Name), err))
+	}
+	return &GetAccessRequestResponse{Body: toAccessRequestDetailResponseBody(ar, rt)}, nil
+}
+
+func (h *APIHandler) revokeAccessRequestHandler(ctx context.Context, input *RevokeAccessRequestInput) (*RevokeAccessRequestResponse, error) {
+	ar, err := h.getSubjectAccessRequest(ctx, &input.ArgoCDHeaders, input.Name)
+	if err != nil {
+		return nil, err
+	}
+	if ar.Status.RequestState != api.GrantedStatus {
+		return nil, huma.Error409Conflict(fmt.Sprintf("only granted AccessRequests can be revoked: current status is %s", ar.Status.RequestState))
+	}
+
+	ar, err = h.service.RevokeAccessRequest(ctx, ar)
+	if err != nil {
+		return nil, h.loggedError(huma.Error500InternalServerError(fmt.Sprintf("error revoking access request %s", input.Name), err))
+	}
+	return &RevokeAccessRequestResponse{Body: toAccessRequestResponseBody(ar)}, nil
+}
+
+func (h *APIHandler) cancelAccessRequestHandler(ctx context.Context, input *CancelAccessRequestInput) (*CancelAccessRequestResponse, error) {
+	ar, err := h.getSubjectAccessRequest(ctx, &input.ArgoCDHeaders, input.Name)
+	if err != nil {
+		return nil, err
+	}
+	if ar.Status.RequestState == api.GrantedStatus || ar.IsConcluded() {
+		return nil, huma.Error409Conflict(fmt.Sprintf("only pending AccessRequests can be cancelled: current status is %s", ar.Status.RequestState))
+	}
+
+	ar, err = h.service.CancelAccessRequest(ctx, ar)
+	if err != nil {
+		return nil, h.loggedError(huma.Error500InternalServerError(fmt.Sprintf("error cancelling access request %s", input.Name), err))
+	}
+	return &CancelAccessRequestResponse{Body: toAccessRequestResponseBody(ar)}, nil
+}
+
+func (h *APIHandler) extendAccessRequestHandler(ctx context.Context, input *ExtendAccessRequestInput) (*ExtendAccessRequestResponse, error) {
+	duration, err := parseDuration(input.Body.Duration)
+	if err != nil {
+		return nil, huma.Error400BadRequest("invalid duration", err)
+	}
+
+	ar, err := h.getSubjectAccessRequest(ctx, &input.ArgoCDHeaders, input.Name)
+	if err != nil {
+		return nil, err
+	}
+	if ar.Status.RequestState != api.GrantedStatus || ar.Spec.Revoke {
+		return nil, huma.Error409Conflict(fmt.Sprintf("only granted AccessRequests can be extended: current status is %s", ar.Status.RequestState))
+	}
+	if ar.GetPendingExtension() != nil {
+		return nil, huma.Error409Conflict("AccessRequest already has a pending extension")
+	}
+
+	ar, err = h.service.ExtendAccessRequest(ctx, ar, duration)
+	if err != nil {
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			return nil, huma.Error400BadRequest(validationErr.Error())
+		}
+		return nil, h.loggedError(huma.Error500InternalServerError(fmt.Sprintf("error extending access request %s", input.Name), err))
+	}
+	return &ExtendAccessRequestResponse{Body: toAccessRequestResponseBody(ar)}, nil
+}
+
+func (h *APIHandler) listApprovalsHandler(ctx context.Context, input *ListApprovalsInput) (*ListApprovalsResponse, error) {
+	appNamespace, appName, err := input.Application()
+	if err != nil {
+		return nil, huma.Error400BadRequest("invalid application", err)
+	}
+	key := &AccessRequestKey{
+		Namespace:            input.ArgoCDNamespace,
+		ApplicationName:      appName,
+		ApplicationNamespace: appNamespace,
+		UserId:               input.ArgoCDUserId,
+		Username:             input.ArgoCDUsername,
+	}
+	accessRequests, err := h.service.ListPendingApprovals(ctx, key, input.Groups())
+	if err != nil {
+		return nil, h.loggedError(huma.Error500InternalServerError("error listing pending approvals", err))
+	}
+	return &ListApprovalsResponse{Body: toListAccessRequestResponseBody(accessRequests)}, nil
+}
+
+func (h *APIHandler) approveAccessRequestHandler(ctx context.Context, input *ApprovalDecisionInput) (*ApprovalDecisionResponse, error) {
+	return h.decideAccessRequest(ctx, input, api.ApprovedDecision)
+}
+
+func (h *APIHandler) rejectAccessRequestHandler(ctx context.Context, input *ApprovalDecisionInput) (*ApprovalDecisionResponse, error) {
+	return h.decideAccessRequest(ctx, input, api.RejectedDecision)
+}
+
+// decideAccessRequest will record the given decision made by the user informed
+// in the input headers. The user must belong to one of the approver groups and
+// can't decide on their own access requests.
+func (h *APIHandler) decideAccessRequest(ctx context.Context, input *ApprovalDecisionInput, decision api.ApprovalDecisionType) (*ApprovalDecisionResponse, error) {
+	ar, err := h.getApplicationAccessRequest(ctx, &input.ArgoCDHeaders, input.Name)
+	if err != nil {
+		return nil, err
+	}
+	if !ar.RequiresApproval() {
+		return nil, huma.Error409Conflict("AccessRequest does not require approval")
+	}
+	if ar.IsConcluded() || ar.Status.RequestState == api.GrantedStatus {
+		return nil, huma.Error409Conflict(fmt.Sprintf("AccessRequest is not pending approval: current status is %s", ar.Status.RequestState))
+	}
+	if ar.Spec.Subject.Username == input.ArgoCDUsername {
+		return nil, huma.Error403Forbidden("self-approval is not allowed")
+	}
+	if !ar.Spec.Approval.IsApprover(input.Groups()) {
+		return nil, huma.Error403Forbidden("not allowed to approve or reject this AccessRequest")
+	}
+	if ar.GetApprovalDecision(input.ArgoCDUsername) != nil {
+		return nil, huma.Error409Conflict("decision already recorded for this user")
+	}
+
+	approval := &api.ApprovalDecision{
+		Username: input.ArgoCDUsername,
+		Decision: decision,
+		Comment:  input.Body.Comment,
+		Time:     metav1.Now(),
+	}
+	ar, err = h.service.AddApprovalDecision(ctx, ar, approval)
+	if err != nil {
+		return nil, h.loggedError(huma.Error500InternalServerError(fmt.Sprintf("error recording decision for access request %s", input.Name), err))
+	}
+	return &ApprovalDecisionResponse{Body: toAccessRequestResponseBody(ar)}, nil
+}
+
+// getSubjectAccessRequest will retrieve the AccessRequest with the given name
+// making sure that it is associated with the application and the user informed
+// in the given headers. The returned error is always a huma.StatusError.
+func (h *APIHandler) getSubjectAccessRequest(ctx context.Context, headers *ArgoCDHeaders, name string) (*api.AccessRequest, error) {
+	ar, err := h.getApplicationAccessRequest(ctx, headers, name)
+	if err != nil {
+		return nil, err
+	}
+	if ar.Spec.Subject.Username != headers.ArgoCDUsername {
+		return nil, huma.Error403Forbidden("AccessRequest can only be managed by the requester")
+	}
+	return ar, nil
+}
+
+// getApplicationAccessRequest will retrieve the AccessRequest with the given
+// name making sure that it is associated with the application informed in the
+// given headers. The returned error is always a huma.StatusError.
+func (h *APIHandler) getApplicationAccessRequest(ctx context.Context, headers *ArgoCDHeaders, name string) (*api.AccessRequest, error) {
+	appNamespace, appName, err := headers.Application()
+	if err != nil {
+		return nil, huma.Error400BadRequest("invalid application", err)
+	}
+	ar, err := h.service.GetAccessRequest(ctx, name, headers.ArgoCDNamespace)
+	if err != nil {
+		return nil, h.loggedError(huma.Error500InternalServerError(fmt.Sprintf("error retrieving access request %s", name), err))
+	}
+	if ar == nil ||
+		ar.Spec.Application.Name != appName ||
+		ar.Spec.Application.Namespace != appNamespace {
+		return nil, huma.Error404NotFound("AccessRequest not found")
+	}
+	return ar, nil
+}
+
 func (h *APIHandler) loggedError(err huma.StatusError) huma.StatusError {
 	h.logger.Error(err, "backend error")
 	return err
 }
 
 // toAccessRequestResponseBody will convert the given ar into an AccessRequestResponseBody.
+func toAdminAccessRequestResponseBody(ar *api.AccessRequest) AdminAccessRequestResponseBody {
+	return AdminAccessRequestResponseBody{
+		AccessRequestResponseBody: toAccessRequestResponseBody(ar),
+		TargetProject:             ar.Status.TargetProject,
+		CreatedAt:                 ar.GetCreationTimestamp().Format(time.RFC3339),
+	}
+}
+
 func toAccessRequestResponseBody(ar *api.AccessRequest) AccessRequestResponseBody {
 	expiresAt := ""
 	if ar.Status.ExpiresAt != nil {
 		expiresAt = ar.Status.ExpiresAt.Format(time.RFC3339)
 	}
+	startsAt := ""
+	if ar.Spec.StartsAt != nil {
+		startsAt = ar.Spec.StartsAt.Format(time.RFC3339)
+	}
 	requestedAt := ""
 	if len(ar.Status.History) > 0 {
 		for _, h := range ar.Status.History {
@@ -296,16 +1043,66 @@ func toAccessRequestResponseBody(ar *api.AccessRequest) AccessRequestResponseBod
 		permission = *ar.Spec.Role.FriendlyName
 	}
 
+	requiredApprovals := 0
+	if ar.RequiresApproval() {
+		requiredApprovals = ar.Spec.Approval.GetRequiredApprovals()
+	}
+	var approvals []ApprovalResponseBody
+	for _, decision := range ar.Spec.Approvals {
+		approvals = append(approvals, ApprovalResponseBody{
+			Username: decision.Username,
+			Decision: strings.ToUpper(string(decision.Decision)),
+			Comment:  decision.Comment,
+			Time:     decision.Time.Format(time.RFC3339),
+		})
+	}
+
 	return AccessRequestResponseBody{
-		Name:        ar.GetName(),
-		Namespace:   ar.GetNamespace(),
-		Username:    ar.Spec.Subject.Username,
-		Permission:  permission,
-		RequestedAt: requestedAt,
-		Role:        ar.Spec.Role.TemplateRef.Name,
-		Status:      strings.ToUpper(string(ar.Status.RequestState)),
-		ExpiresAt:   expiresAt,
-		Message:     message,
+		Name:          ar.GetName(),
+		Application:   fmt.Sprintf("%s:%s", ar.Spec.Application.Namespace, ar.Spec.Application.Name),
+		Namespace:     ar.GetNamespace(),
+		Username:      ar.Spec.Subject.Username,
+		Permission:    permission,
+		RequestedAt:   requestedAt,
+		Role:          ar.Spec.Role.TemplateRef.Name,
+		Status:        strings.ToUpper(string(ar.Status.RequestState)),
+		StartsAt:      startsAt,
+		ExpiresAt:     expiresAt,
+		Message:       message,
+		Justification: ar.Spec.Justification,
+		TicketRef:     ar.Spec.TicketRef,
+		BreakGlass:    ar.Spec.BreakGlass,
+
+		RequiredApprovals: requiredApprovals,
+		Approvals:         approvals,
+	}
+}
+
+// toAccessRequestDetailResponseBody will convert the given ar into an
+// AccessRequestDetailResponseBody. The given rt is optional.
+func toAccessRequestDetailResponseBody(ar *api.AccessRequest, rt *api.RoleTemplate) AccessRequestDetailResponseBody {
+	history := []AccessRequestHistoryBody{}
+	for _, h := range ar.Status.History {
+		details := ""
+		if h.Details != nil {
+			details = *h.Details
+		}
+		history = append(history, AccessRequestHistoryBody{
+			Status:         strings.ToUpper(string(h.RequestState)),
+			TransitionTime: h.TransitionTime.Format(time.RFC3339),
+			Details:        details,
+		})
+	}
+	roleDescription := ""
+	if rt != nil {
+		roleDescription = rt.Spec.Description
+	}
+	return AccessRequestDetailResponseBody{
+		AccessRequestResponseBody: toAccessRequestResponseBody(ar),
+		TargetProject:             ar.Status.TargetProject,
+		RoleName:                  ar.Status.RoleName,
+		RoleDescription:           roleDescription,
+		History:                   history,
 	}
 }
 
@@ -328,6 +1125,74 @@ func listAccessRequestOperation() huma.Operation {
 	}
 }
 
+// watchUserAccessRequestsOperation defines the operation to stream the user
+// access requests status changes using Server-Sent Events.
+func watchUserAccessRequestsOperation() huma.Operation {
+	return huma.Operation{
+		OperationID: "watch-user-accessrequests",
+		Method:      http.MethodGet,
+		Path:        "/user/accessrequests/events",
+		Summary:     "Watch user AccessRequests",
+		Description: "Will stream the current state of the user access requests followed by every status change as Server-Sent Events",
+	}
+}
+
+// adminListAccessRequestsOperation defines the operation used by admins to
+// search all access requests.
+func adminListAccessRequestsOperation() huma.Operation {
+	return huma.Operation{
+		OperationID: "admin-list-accessrequests",
+		Method:      http.MethodGet,
+		Path:        "/admin/accessrequests",
+		Summary:     "Search AccessRequests",
+		Description: "Will retrieve a paginated list of all access requests matching the given filters. Only available to admin groups.",
+	}
+}
+
+// adminListArchivedAccessRequestsOperation defines the operation used by
+// admins to search the access requests archived before deletion.
+func adminListArchivedAccessRequestsOperation() huma.Operation {
+	return huma.Operation{
+		OperationID: "admin-list-archived-accessrequests",
+		Method:      http.MethodGet,
+		Path:        "/admin/accessrequests/archive",
+		Summary:     "Search archived AccessRequests",
+		Description: "Will retrieve a paginated list of the access requests deleted by the controller that match the given filters. Only available to admin groups when the archive is configured.",
+	}
+}
+
+// adminExportAccessRequestsOperation defines the operation used by admins to
+// export access requests as CSV.
+func adminExportAccessRequestsOperation() huma.Operation {
+	return huma.Operation{
+		OperationID: "admin-export-accessrequests",
+		Method:      http.MethodGet,
+		Path:        "/admin/accessrequests/export",
+		Summary:     "Export AccessRequests",
+		Description: "Will export all access requests matching the given filters in CSV format. Only available to admin groups.",
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "The access requests in CSV format",
+				Content: map[string]*huma.MediaType{
+					"text/csv": {Schema: &huma.Schema{Type: huma.TypeString}},
+				},
+			},
+		},
+	}
+}
+
+// listUserAccessRequestsOperation defines the operation to list the user
+// access requests across all applications.
+func listUserAccessRequestsOperation() huma.Operation {
+	return huma.Operation{
+		OperationID: "list-user-accessrequests",
+		Method:      http.MethodGet,
+		Path:        "/user/accessrequests",
+		Summary:     "List user AccessRequests",
+		Description: "Will retrieve a paginated list of the user access requests across all applications",
+	}
+}
+
 // listAllowedRolesOperation defines the operation to list the user's available
 // roles.
 func listAllowedRolesOperation() huma.Operation {
@@ -340,6 +1205,18 @@ func listAllowedRolesOperation() huma.Operation {
 	}
 }
 
+// explainRoleOperation defines the operation to explain why the user is or
+// isn't allowed to request a role.
+func explainRoleOperation() huma.Operation {
+	return huma.Operation{
+		OperationID: "explain-role",
+		Method:      http.MethodGet,
+		Path:        "/roles/{roleName}/explain",
+		Summary:     "Explain role",
+		Description: "Will evaluate every AccessBinding referencing the role without creating an access request and describe why the user is or isn't allowed to request it",
+	}
+}
+
 // createAccessRequestOperation defines the create access request operation.
 func createAccessRequestOperation() huma.Operation {
 	return huma.Operation{
@@ -351,10 +1228,103 @@ func createAccessRequestOperation() huma.Operation {
 	}
 }
 
+// getAccessRequestOperation defines the get access request operation.
+func getAccessRequestOperation() huma.Operation {
+	return huma.Operation{
+		OperationID: "get-accessrequest",
+		Method:      http.MethodGet,
+		Path:        "/accessrequests/{name}",
+		Summary:     "Get AccessRequest",
+		Description: "Will retrieve the details of the access request including its history",
+	}
+}
+
+// revokeAccessRequestOperation defines the revoke access request operation.
+func revokeAccessRequestOperation() huma.Operation {
+	return huma.Operation{
+		OperationID: "revoke-accessrequest",
+		Method:      http.MethodPost,
+		Path:        "/accessrequests/{name}/revoke",
+		Summary:     "Revoke AccessRequest",
+		Description: "Will remove the elevated access granted by the access request before it expires",
+	}
+}
+
+// cancelAccessRequestOperation defines the cancel access request operation.
+func cancelAccessRequestOperation() huma.Operation {
+	return huma.Operation{
+		OperationID: "cancel-accessrequest",
+		Method:      http.MethodPost,
+		Path:        "/accessrequests/{name}/cancel",
+		Summary:     "Cancel AccessRequest",
+		Description: "Will cancel the access request that wasn't granted yet allowing a new access request to be created",
+	}
+}
+
+// extendAccessRequestOperation defines the extend access request operation.
+func extendAccessRequestOperation() huma.Operation {
+	return huma.Operation{
+		OperationID: "extend-accessrequest",
+		Method:      http.MethodPost,
+		Path:        "/accessrequests/{name}/extend",
+		Summary:     "Extend AccessRequest",
+		Description: "Will request to extend the expiration of the elevated access granted by the access request",
+	}
+}
+
+// listApprovalsOperation defines the operation to list the access requests
+// waiting for the user approval.
+func listApprovalsOperation() huma.Operation {
+	return huma.Operation{
+		OperationID: "list-approvals",
+		Method:      http.MethodGet,
+		Path:        "/approvals",
+		Summary:     "List pending approvals",
+		Description: "Will retrieve the list of access requests for the given context waiting for the user decision",
+	}
+}
+
+// approveAccessRequestOperation defines the approve access request operation.
+func approveAccessRequestOperation() huma.Operation {
+	return huma.Operation{
+		OperationID: "approve-accessrequest",
+		Method:      http.MethodPost,
+		Path:        "/accessrequests/{name}/approve",
+		Summary:     "Approve AccessRequest",
+		Description: "Will record the user approval for the access request",
+	}
+}
+
+// rejectAccessRequestOperation defines the reject access request operation.
+func rejectAccessRequestOperation() huma.Operation {
+	return huma.Operation{
+		OperationID: "reject-accessrequest",
+		Method:      http.MethodPost,
+		Path:        "/accessrequests/{name}/reject",
+		Summary:     "Reject AccessRequest",
+		Description: "Will record the user rejection for the access request",
+	}
+}
+
 // RegisterRoutes will register all routes provided by the access request REST API
 // in the given api.
 func RegisterRoutes(api huma.API, h *APIHandler) {
 	huma.Register(api, listAccessRequestOperation(), h.listAccessRequestHandler)
 	huma.Register(api, listAllowedRolesOperation(), h.listAllowedRolesHandler)
+	huma.Register(api, explainRoleOperation(), h.explainRoleHandler)
+	huma.Register(api, listUserAccessRequestsOperation(), h.listUserAccessRequestsHandler)
+	sse.Register(api, watchUserAccessRequestsOperation(), map[string]any{
+		"accessrequest": AccessRequestResponseBody{},
+	}, h.watchUserAccessRequestsHandler)
+	huma.Register(api, adminListAccessRequestsOperation(), h.adminListAccessRequestsHandler)
+	huma.Register(api, adminListArchivedAccessRequestsOperation(), h.adminListArchivedAccessRequestsHandler)
+	huma.Register(api, adminExportAccessRequestsOperation(), h.adminExportAccessRequestsHandler)
 	huma.Register(api, createAccessRequestOperation(), h.createAccessRequestHandler)
+	huma.Register(api, getAccessRequestOperation(), h.getAccessRequestHandler)
+	huma.Register(api, revokeAccessRequestOperation(), h.revokeAccessRequestHandler)
+	huma.Register(api, cancelAccessRequestOperation(), h.cancelAccessRequestHandler)
+	huma.Register(api, extendAccessRequestOperation(), h.extendAccessRequestHandler)
+	huma.Register(api, listApprovalsOperation(), h.listApprovalsHandler)
+	huma.Register(api, approveAccessRequestOperation(), h.approveAccessRequestHandler)
+	huma.Register(api, rejectAccessRequestOperation(), h.rejectAccessRequestHandler)
 }
@@ -1,6 +1,7 @@
 package backend_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -17,6 +18,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -26,12 +28,12 @@ type apiFixture struct {
 	logger  *mocks.MockLogger
 }
 
-func apiSetup(t *testing.T) *apiFixture {
+func apiSetup(t *testing.T, opts ...backend.APIHandlerOption) *apiFixture {
 	t.Helper()
 	_, api := humatest.New(t)
 	service := mocks.NewMockService(t)
 	logger := mocks.NewMockLogger(t)
-	handler := backend.NewAPIHandler(service, logger)
+	handler := backend.NewAPIHandler(service, logger, opts...)
 	backend.RegisterRoutes(api, handler)
 	return &apiFixture{
 		api:     api,
@@ -59,10 +61,12 @@ func headers(namespace, userId, username, groups, appNs, appName, projName strin
 
 func newArgoCDHeaders(namespace, userId, username, groups, appNs, appName, projName string) *backend.ArgoCDHeaders {
 	return &backend.ArgoCDHeaders{
-		ArgoCDNamespace:       namespace,
-		ArgoCDUserId:          userId,
-		ArgoCDUsername:        username,
-		ArgoCDUserGroups:      groups,
+		ArgoCDUserHeaders: backend.ArgoCDUserHeaders{
+			ArgoCDNamespace:  namespace,
+			ArgoCDUserId:     userId,
+			ArgoCDUsername:   username,
+			ArgoCDUserGroups: groups,
+		},
 		ArgoCDApplicationName: fmt.Sprintf("%s:%s", appNs, appName),
 		ArgoCDProjectName:     projName,
 	}
@@ -100,7 +104,7 @@ func TestApiCreateAccessRequest(t *testing.T) {
 		f.service.EXPECT().GetApplication(mock.Anything, key.ApplicationName, key.ApplicationNamespace).Return(app, nil)
 		f.service.EXPECT().GetAppProject(mock.Anything, projectName, key.Namespace).Return(project, nil)
 		f.service.EXPECT().GetGrantingAccessBinding(mock.Anything, roleName, key.Namespace, []string{group}, app, project).Return(arBinding, nil)
-		f.service.EXPECT().CreateAccessRequest(mock.Anything, key, arBinding).Return(ar, nil)
+		f.service.EXPECT().CreateAccessRequest(mock.Anything, key, arBinding, &backend.AccessRequestOptions{}).Return(ar, nil)
 
 		// When
 		payload := backend.CreateAccessRequestBody{
@@ -118,6 +122,240 @@ func TestApiCreateAccessRequest(t *testing.T) {
 		assert.Equal(t, ar.GetName(), respBody.Name)
 	})
 
+	t.Run("will create access request with the requested duration", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		projectName := "some-project"
+		roleName := "my-custom-role"
+		group := "group1"
+		ar := utils.NewAccessRequestCreated(utils.WithName("created"))
+		arBinding := newDefaultAccessBinding()
+		key := &backend.AccessRequestKey{
+			Namespace:            ar.GetNamespace(),
+			ApplicationName:      ar.Spec.Application.Name,
+			ApplicationNamespace: ar.Spec.Application.Namespace,
+			UserId:               *ar.Spec.Subject.UserId,
+			Username:             ar.Spec.Subject.Username,
+		}
+		headers := headers(key.Namespace, key.UserId, key.Username, group, key.ApplicationNamespace, key.ApplicationName, projectName)
+		project := &unstructured.Unstructured{}
+		app := &unstructured.Unstructured{}
+		expectedOpts := &backend.AccessRequestOptions{Duration: 30 * time.Minute}
+		f.service.EXPECT().GetAccessRequestByRole(mock.Anything, key, roleName).Return(nil, nil)
+		f.service.EXPECT().GetApplication(mock.Anything, key.ApplicationName, key.ApplicationNamespace).Return(app, nil)
+		f.service.EXPECT().GetAppProject(mock.Anything, projectName, key.Namespace).Return(project, nil)
+		f.service.EXPECT().GetGrantingAccessBinding(mock.Anything, roleName, key.Namespace, []string{group}, app, project).Return(arBinding, nil)
+		f.service.EXPECT().CreateAccessRequest(mock.Anything, key, arBinding, expectedOpts).Return(ar, nil)
+
+		// When
+		payload := backend.CreateAccessRequestBody{
+			RoleName: roleName,
+			Duration: "30m",
+		}
+		resp := f.api.Post("/accessrequests", append(headers, payload)...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 200, resp.Result().StatusCode)
+	})
+
+	t.Run("will create access request with justification and ticket reference", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		projectName := "some-project"
+		roleName := "my-custom-role"
+		group := "group1"
+		ar := utils.NewAccessRequestCreated(utils.WithName("created"))
+		ar.Spec.Justification = "some reason"
+		ar.Spec.TicketRef = "OPS-123"
+		arBinding := newDefaultAccessBinding()
+		key := &backend.AccessRequestKey{
+			Namespace:            ar.GetNamespace(),
+			ApplicationName:      ar.Spec.Application.Name,
+			ApplicationNamespace: ar.Spec.Application.Namespace,
+			UserId:               *ar.Spec.Subject.UserId,
+			Username:             ar.Spec.Subject.Username,
+		}
+		headers := headers(key.Namespace, key.UserId, key.Username, group, key.ApplicationNamespace, key.ApplicationName, projectName)
+		project := &unstructured.Unstructured{}
+		app := &unstructured.Unstructured{}
+		expectedOpts := &backend.AccessRequestOptions{Justification: "some reason", TicketRef: "OPS-123"}
+		f.service.EXPECT().GetAccessRequestByRole(mock.Anything, key, roleName).Return(nil, nil)
+		f.service.EXPECT().GetApplication(mock.Anything, key.ApplicationName, key.ApplicationNamespace).Return(app, nil)
+		f.service.EXPECT().GetAppProject(mock.Anything, projectName, key.Namespace).Return(project, nil)
+		f.service.EXPECT().GetGrantingAccessBinding(mock.Anything, roleName, key.Namespace, []string{group}, app, project).Return(arBinding, nil)
+		f.service.EXPECT().CreateAccessRequest(mock.Anything, key, arBinding, expectedOpts).Return(ar, nil)
+
+		// When
+		payload := backend.CreateAccessRequestBody{
+			RoleName:      roleName,
+			Justification: "some reason",
+			TicketRef:     "OPS-123",
+		}
+		resp := f.api.Post("/accessrequests", append(headers, payload)...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 200, resp.Result().StatusCode)
+		var respBody backend.AccessRequestResponseBody
+		err := json.Unmarshal(resp.Body.Bytes(), &respBody)
+		assert.NoError(t, err)
+		assert.Equal(t, "some reason", respBody.Justification)
+		assert.Equal(t, "OPS-123", respBody.TicketRef)
+	})
+
+	t.Run("will create access request with the requested start time", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		projectName := "some-project"
+		roleName := "my-custom-role"
+		group := "group1"
+		startsAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+		ar := utils.NewAccessRequestCreated(utils.WithName("created"))
+		ar.Spec.StartsAt = &metav1.Time{Time: startsAt}
+		ar.Status.RequestState = api.ScheduledStatus
+		arBinding := newDefaultAccessBinding()
+		key := &backend.AccessRequestKey{
+			Namespace:            ar.GetNamespace(),
+			ApplicationName:      ar.Spec.Application.Name,
+			ApplicationNamespace: ar.Spec.Application.Namespace,
+			UserId:               *ar.Spec.Subject.UserId,
+			Username:             ar.Spec.Subject.Username,
+		}
+		headers := headers(key.Namespace, key.UserId, key.Username, group, key.ApplicationNamespace, key.ApplicationName, projectName)
+		project := &unstructured.Unstructured{}
+		app := &unstructured.Unstructured{}
+		f.service.EXPECT().GetAccessRequestByRole(mock.Anything, key, roleName).Return(nil, nil)
+		f.service.EXPECT().GetApplication(mock.Anything, key.ApplicationName, key.ApplicationNamespace).Return(app, nil)
+		f.service.EXPECT().GetAppProject(mock.Anything, projectName, key.Namespace).Return(project, nil)
+		f.service.EXPECT().GetGrantingAccessBinding(mock.Anything, roleName, key.Namespace, []string{group}, app, project).Return(arBinding, nil)
+		f.service.EXPECT().CreateAccessRequest(mock.Anything, key, arBinding, mock.Anything).
+			RunAndReturn(func(ctx context.Context, key *backend.AccessRequestKey, ab *api.AccessBinding, opts *backend.AccessRequestOptions) (*api.AccessRequest, error) {
+				require.NotNil(t, opts.StartsAt)
+				assert.True(t, startsAt.Equal(*opts.StartsAt))
+				return ar, nil
+			})
+
+		// When
+		payload := backend.CreateAccessRequestBody{
+			RoleName: roleName,
+			StartsAt: startsAt.Format(time.RFC3339),
+		}
+		resp := f.api.Post("/accessrequests", append(headers, payload)...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 200, resp.Result().StatusCode)
+		var respBody backend.AccessRequestResponseBody
+		err := json.Unmarshal(resp.Body.Bytes(), &respBody)
+		assert.NoError(t, err)
+		assert.Equal(t, "SCHEDULED", respBody.Status)
+		assert.Equal(t, startsAt.Format(time.RFC3339), respBody.StartsAt)
+	})
+
+	t.Run("will create break-glass access request", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		projectName := "some-project"
+		roleName := "my-custom-role"
+		group := "group1"
+		ar := utils.NewAccessRequestCreated(utils.WithName("created"))
+		ar.Spec.BreakGlass = true
+		ar.Spec.Justification = "production is down"
+		arBinding := newDefaultAccessBinding()
+		key := &backend.AccessRequestKey{
+			Namespace:            ar.GetNamespace(),
+			ApplicationName:      ar.Spec.Application.Name,
+			ApplicationNamespace: ar.Spec.Application.Namespace,
+			UserId:               *ar.Spec.Subject.UserId,
+			Username:             ar.Spec.Subject.Username,
+		}
+		headers := headers(key.Namespace, key.UserId, key.Username, group, key.ApplicationNamespace, key.ApplicationName, projectName)
+		project := &unstructured.Unstructured{}
+		app := &unstructured.Unstructured{}
+		f.service.EXPECT().GetAccessRequestByRole(mock.Anything, key, roleName).Return(nil, nil)
+		f.service.EXPECT().GetApplication(mock.Anything, key.ApplicationName, key.ApplicationNamespace).Return(app, nil)
+		f.service.EXPECT().GetAppProject(mock.Anything, projectName, key.Namespace).Return(project, nil)
+		f.service.EXPECT().GetGrantingAccessBinding(mock.Anything, roleName, key.Namespace, []string{group}, app, project).Return(arBinding, nil)
+		f.service.EXPECT().CreateAccessRequest(mock.Anything, key, arBinding, mock.Anything).
+			RunAndReturn(func(ctx context.Context, key *backend.AccessRequestKey, ab *api.AccessBinding, opts *backend.AccessRequestOptions) (*api.AccessRequest, error) {
+				assert.True(t, opts.BreakGlass)
+				assert.Equal(t, "production is down", opts.Justification)
+				return ar, nil
+			})
+
+		// When
+		payload := backend.CreateAccessRequestBody{
+			RoleName:      roleName,
+			Justification: "production is down",
+			BreakGlass:    true,
+		}
+		resp := f.api.Post("/accessrequests", append(headers, payload)...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 200, resp.Result().StatusCode)
+		var respBody backend.AccessRequestResponseBody
+		err := json.Unmarshal(resp.Body.Bytes(), &respBody)
+		assert.NoError(t, err)
+		assert.True(t, respBody.BreakGlass)
+	})
+
+	t.Run("will return 400 if the duration can not be parsed", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		headers := headers("some-namespace", "", "some-user", "group1", "app-ns", "some-app", "some-project")
+
+		// When
+		payload := backend.CreateAccessRequestBody{
+			RoleName: "my-custom-role",
+			Duration: "one hour",
+		}
+		resp := f.api.Post("/accessrequests", append(headers, payload)...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 400, resp.Result().StatusCode)
+		assert.Contains(t, resp.Body.String(), "invalid duration")
+	})
+
+	t.Run("will return 400 if the duration is out of the allowed range", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		projectName := "some-project"
+		roleName := "my-custom-role"
+		group := "group1"
+		ar := utils.NewAccessRequestCreated(utils.WithName("created"))
+		arBinding := newDefaultAccessBinding()
+		key := &backend.AccessRequestKey{
+			Namespace:            ar.GetNamespace(),
+			ApplicationName:      ar.Spec.Application.Name,
+			ApplicationNamespace: ar.Spec.Application.Namespace,
+			Username:             ar.Spec.Subject.Username,
+		}
+		headers := headers(key.Namespace, key.UserId, key.Username, group, key.ApplicationNamespace, key.ApplicationName, projectName)
+		project := &unstructured.Unstructured{}
+		app := &unstructured.Unstructured{}
+		f.service.EXPECT().GetAccessRequestByRole(mock.Anything, key, roleName).Return(nil, nil)
+		f.service.EXPECT().GetApplication(mock.Anything, key.ApplicationName, key.ApplicationNamespace).Return(app, nil)
+		f.service.EXPECT().GetAppProject(mock.Anything, projectName, key.Namespace).Return(project, nil)
+		f.service.EXPECT().GetGrantingAccessBinding(mock.Anything, roleName, key.Namespace, []string{group}, app, project).Return(arBinding, nil)
+		f.service.EXPECT().CreateAccessRequest(mock.Anything, key, arBinding, mock.Anything).
+			Return(nil, backend.NewValidationError("invalid duration: duration 24h0m0s is longer than the maximum allowed (8h0m0s)"))
+
+		// When
+		payload := backend.CreateAccessRequestBody{
+			RoleName: roleName,
+			Duration: "24h",
+		}
+		resp := f.api.Post("/accessrequests", append(headers, payload)...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 400, resp.Result().StatusCode)
+		assert.Contains(t, resp.Body.String(), "longer than the maximum allowed")
+	})
+
 	t.Run("will return 422 on invalid headers", func(t *testing.T) {
 		// Given
 		f := apiSetup(t)
@@ -414,7 +652,7 @@ func TestApiCreateAccessRequest(t *testing.T) {
 		f.service.EXPECT().GetApplication(mock.Anything, key.ApplicationName, key.ApplicationNamespace).Return(app, nil)
 		f.service.EXPECT().GetAppProject(mock.Anything, projectName, key.Namespace).Return(project, nil)
 		f.service.EXPECT().GetGrantingAccessBinding(mock.Anything, roleName, key.Namespace, []string{group}, app, project).Return(arBinding, nil)
-		f.service.EXPECT().CreateAccessRequest(mock.Anything, key, arBinding).Return(nil, fmt.Errorf("some-error"))
+		f.service.EXPECT().CreateAccessRequest(mock.Anything, key, arBinding, mock.Anything).Return(nil, fmt.Errorf("some-error"))
 		f.logger.EXPECT().Error(mock.Anything, mock.Anything)
 
 		// When
@@ -429,117 +667,1081 @@ func TestApiCreateAccessRequest(t *testing.T) {
 	})
 }
 
-func TestApiListAccessRequest(t *testing.T) {
-	t.Run("will return access requests successfully", func(t *testing.T) {
+func TestApiGetAccessRequest(t *testing.T) {
+	newHeaders := func(ar *api.AccessRequest, username, groups string) []any {
+		return headers(ar.GetNamespace(), "", username, groups, ar.Spec.Application.Namespace, ar.Spec.Application.Name, "header-project")
+	}
+	t.Run("will return the access request details to the requester", func(t *testing.T) {
 		// Given
 		f := apiSetup(t)
-		ar1 := utils.NewAccessRequestRequested(utils.WithName("first"))
-		ar2 := utils.NewAccessRequestRequested(utils.WithName("second"))
-		key := &backend.AccessRequestKey{
-			Namespace:            ar1.GetNamespace(),
-			ApplicationName:      ar1.Spec.Application.Name,
-			ApplicationNamespace: ar1.Spec.Application.Namespace,
-			Username:             ar1.Spec.Subject.Username,
-		}
-		headers := headers(key.Namespace, key.UserId, key.Username, "group1", key.ApplicationNamespace, key.ApplicationName, "some-project")
-		f.service.EXPECT().ListAccessRequests(mock.Anything, key, true).Return([]*api.AccessRequest{ar1, ar2}, nil)
+		ar := utils.NewAccessRequestGranted(utils.WithName("granted"))
+		ar.Status.TargetProject = "some-project"
+		ar.Status.RoleName = "ephemeral-some-role"
+		rt := &api.RoleTemplate{Spec: api.RoleTemplateSpec{Description: "rendered description"}}
+		f.service.EXPECT().GetAccessRequest(mock.Anything, ar.GetName(), ar.GetNamespace()).Return(ar, nil)
+		f.service.EXPECT().GetRenderedRoleTemplate(mock.Anything, ar, "some-project").Return(rt, nil)
 
 		// When
-		resp := f.api.Get("/accessrequests", headers...)
+		resp := f.api.Get("/accessrequests/granted", newHeaders(ar, ar.Spec.Subject.Username, "group1")...)
 
 		// Then
 		assert.NotNil(t, resp)
 		assert.Equal(t, 200, resp.Result().StatusCode)
-		var respBody backend.ListAccessRequestResponseBody
+		var respBody backend.AccessRequestDetailResponseBody
 		err := json.Unmarshal(resp.Body.Bytes(), &respBody)
 		assert.NoError(t, err)
-		require.Equal(t, 2, len(respBody.Items))
-		assert.Equal(t, ar1.GetNamespace(), respBody.Items[0].Namespace)
-		assert.Equal(t, ar1.GetName(), respBody.Items[0].Name)
-		assert.Equal(t, ar2.GetNamespace(), respBody.Items[1].Namespace)
-		assert.Equal(t, ar2.GetName(), respBody.Items[1].Name)
+		assert.Equal(t, ar.GetName(), respBody.Name)
+		assert.Equal(t, "GRANTED", respBody.Status)
+		assert.Equal(t, "some-project", respBody.TargetProject)
+		assert.Equal(t, "ephemeral-some-role", respBody.RoleName)
+		assert.Equal(t, "rendered description", respBody.RoleDescription)
+		require.Len(t, respBody.History, len(ar.Status.History))
+		assert.Equal(t, "GRANTED", respBody.History[len(respBody.History)-1].Status)
 	})
-	t.Run("will return 422 on invalid headers", func(t *testing.T) {
+	t.Run("will return the access request details to approvers", func(t *testing.T) {
 		// Given
 		f := apiSetup(t)
-		key := &backend.AccessRequestKey{
-			Namespace:            "some-namespace",
-			ApplicationName:      "some-app:with-invalid-colon",
-			ApplicationNamespace: "app-ns",
-			Username:             "some-user",
-		}
-		headers := headers(key.Namespace, key.UserId, key.Username, "group1", key.ApplicationNamespace, key.ApplicationName, "some-project")
-		headers = headers[1:]
+		ar := utils.NewAccessRequestCreated(utils.WithName("created"))
+		ar.Spec.Approval = &api.ApprovalPolicy{Approvers: []string{"approvers"}}
+		f.service.EXPECT().GetAccessRequest(mock.Anything, ar.GetName(), ar.GetNamespace()).Return(ar, nil)
+		f.service.EXPECT().GetRenderedRoleTemplate(mock.Anything, ar, "header-project").Return(nil, nil)
 
 		// When
-		resp := f.api.Get("/accessrequests", headers...)
+		resp := f.api.Get("/accessrequests/created", newHeaders(ar, "some-approver", "approvers")...)
 
 		// Then
 		assert.NotNil(t, resp)
-		assert.Equal(t, 422, resp.Result().StatusCode)
+		assert.Equal(t, 200, resp.Result().StatusCode)
+		var respBody backend.AccessRequestDetailResponseBody
+		err := json.Unmarshal(resp.Body.Bytes(), &respBody)
+		assert.NoError(t, err)
+		assert.Empty(t, respBody.RoleDescription)
+		assert.NotNil(t, respBody.History)
 	})
-	t.Run("will return 400 on invalid header format", func(t *testing.T) {
+	t.Run("will return 403 if user is not the requester nor an approver", func(t *testing.T) {
 		// Given
 		f := apiSetup(t)
-		key := &backend.AccessRequestKey{
-			Namespace:            "some-namespace",
-			ApplicationName:      "some-app:with-invalid-colon",
-			ApplicationNamespace: "app-ns",
-			Username:             "some-user",
-		}
-		headers := headers(key.Namespace, key.UserId, key.Username, "group1", key.ApplicationNamespace, key.ApplicationName, "some-project")
+		ar := utils.NewAccessRequestGranted(utils.WithName("granted"))
+		f.service.EXPECT().GetAccessRequest(mock.Anything, ar.GetName(), ar.GetNamespace()).Return(ar, nil)
 
 		// When
-		resp := f.api.Get("/accessrequests", headers...)
+		resp := f.api.Get("/accessrequests/granted", newHeaders(ar, "another-user", "group1")...)
 
 		// Then
 		assert.NotNil(t, resp)
-		assert.Equal(t, 400, resp.Result().StatusCode)
+		assert.Equal(t, 403, resp.Result().StatusCode)
 	})
-	t.Run("will return 500 on service error", func(t *testing.T) {
+	t.Run("will return 404 if access request does not exist", func(t *testing.T) {
 		// Given
 		f := apiSetup(t)
-		key := &backend.AccessRequestKey{
-			Namespace:            "some-namespace",
-			ApplicationName:      "some-app",
-			ApplicationNamespace: "app-ns",
-			Username:             "some-user",
-		}
-		headers := headers(key.Namespace, key.UserId, key.Username, "group1", key.ApplicationNamespace, key.ApplicationName, "some-project")
-		f.service.EXPECT().ListAccessRequests(mock.Anything, key, mock.Anything).Return(nil, fmt.Errorf("some-error"))
-		f.logger.EXPECT().Error(mock.Anything, mock.Anything)
+		ar := utils.NewAccessRequestGranted(utils.WithName("granted"))
+		f.service.EXPECT().GetAccessRequest(mock.Anything, ar.GetName(), ar.GetNamespace()).Return(nil, nil)
 
 		// When
-		resp := f.api.Get("/accessrequests", headers...)
+		resp := f.api.Get("/accessrequests/granted", newHeaders(ar, ar.Spec.Subject.Username, "group1")...)
 
 		// Then
 		assert.NotNil(t, resp)
-		assert.Equal(t, 500, resp.Result().StatusCode)
+		assert.Equal(t, 404, resp.Result().StatusCode)
 	})
-	t.Run("will return empty list if no access request exist", func(t *testing.T) {
+	t.Run("will return 500 if role template retrieval fails", func(t *testing.T) {
 		// Given
 		f := apiSetup(t)
-		key := &backend.AccessRequestKey{
-			Namespace:            "some-namespace",
-			ApplicationName:      "some-app",
-			ApplicationNamespace: "app-ns",
-			Username:             "some-user",
-		}
-		headers := headers(key.Namespace, key.UserId, key.Username, "group1", key.ApplicationNamespace, key.ApplicationName, "some-project")
-		f.service.EXPECT().ListAccessRequests(mock.Anything, key, mock.Anything).Return(nil, nil)
+		ar := utils.NewAccessRequestGranted(utils.WithName("granted"))
+		f.service.EXPECT().GetAccessRequest(mock.Anything, ar.GetName(), ar.GetNamespace()).Return(ar, nil)
+		f.service.EXPECT().GetRenderedRoleTemplate(mock.Anything, ar, mock.Anything).Return(nil, fmt.Errorf("some-error"))
+		f.logger.EXPECT().Error(mock.Anything, mock.Anything)
 
 		// When
-		resp := f.api.Get("/accessrequests", headers...)
+		resp := f.api.Get("/accessrequests/granted", newHeaders(ar, ar.Spec.Subject.Username, "group1")...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 500, resp.Result().StatusCode)
+	})
+}
+
+func TestApiRevokeAccessRequest(t *testing.T) {
+	newKey := func(ar *api.AccessRequest) *backend.AccessRequestKey {
+		return &backend.AccessRequestKey{
+			Namespace:            ar.GetNamespace(),
+			ApplicationName:      ar.Spec.Application.Name,
+			ApplicationNamespace: ar.Spec.Application.Namespace,
+			Username:             ar.Spec.Subject.Username,
+		}
+	}
+	t.Run("will revoke access request successfully", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		ar := utils.NewAccessRequestGranted(utils.WithName("granted"))
+		revoked := ar.DeepCopy()
+		revoked.Spec.Revoke = true
+		key := newKey(ar)
+		headers := headers(key.Namespace, key.UserId, key.Username, "group1", key.ApplicationNamespace, key.ApplicationName, "some-project")
+		f.service.EXPECT().GetAccessRequest(mock.Anything, ar.GetName(), key.Namespace).Return(ar, nil)
+		f.service.EXPECT().RevokeAccessRequest(mock.Anything, ar).Return(revoked, nil)
+
+		// When
+		resp := f.api.Post("/accessrequests/granted/revoke", headers...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 200, resp.Result().StatusCode)
+		var respBody backend.AccessRequestResponseBody
+		err := json.Unmarshal(resp.Body.Bytes(), &respBody)
+		assert.NoError(t, err)
+		assert.Equal(t, ar.GetName(), respBody.Name)
+	})
+	t.Run("will return 404 if access request does not exist", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		ar := utils.NewAccessRequestGranted(utils.WithName("granted"))
+		key := newKey(ar)
+		headers := headers(key.Namespace, key.UserId, key.Username, "group1", key.ApplicationNamespace, key.ApplicationName, "some-project")
+		f.service.EXPECT().GetAccessRequest(mock.Anything, ar.GetName(), key.Namespace).Return(nil, nil)
+
+		// When
+		resp := f.api.Post("/accessrequests/granted/revoke", headers...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 404, resp.Result().StatusCode)
+	})
+	t.Run("will return 404 if access request belongs to a different application", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		ar := utils.NewAccessRequestGranted(utils.WithName("granted"))
+		key := newKey(ar)
+		headers := headers(key.Namespace, key.UserId, key.Username, "group1", key.ApplicationNamespace, "another-app", "some-project")
+		f.service.EXPECT().GetAccessRequest(mock.Anything, ar.GetName(), key.Namespace).Return(ar, nil)
+
+		// When
+		resp := f.api.Post("/accessrequests/granted/revoke", headers...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 404, resp.Result().StatusCode)
+	})
+	t.Run("will return 403 if caller is not the requester", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		ar := utils.NewAccessRequestGranted(utils.WithName("granted"))
+		key := newKey(ar)
+		headers := headers(key.Namespace, key.UserId, "another-user", "group1", key.ApplicationNamespace, key.ApplicationName, "some-project")
+		f.service.EXPECT().GetAccessRequest(mock.Anything, ar.GetName(), key.Namespace).Return(ar, nil)
+
+		// When
+		resp := f.api.Post("/accessrequests/granted/revoke", headers...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 403, resp.Result().StatusCode)
+	})
+	t.Run("will return 409 if access request is not granted", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		ar := utils.NewAccessRequestRequested(utils.WithName("requested"))
+		key := newKey(ar)
+		headers := headers(key.Namespace, key.UserId, key.Username, "group1", key.ApplicationNamespace, key.ApplicationName, "some-project")
+		f.service.EXPECT().GetAccessRequest(mock.Anything, ar.GetName(), key.Namespace).Return(ar, nil)
+
+		// When
+		resp := f.api.Post("/accessrequests/requested/revoke", headers...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 409, resp.Result().StatusCode)
+	})
+	t.Run("will return 500 on service error revoking access request", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		ar := utils.NewAccessRequestGranted(utils.WithName("granted"))
+		key := newKey(ar)
+		headers := headers(key.Namespace, key.UserId, key.Username, "group1", key.ApplicationNamespace, key.ApplicationName, "some-project")
+		f.service.EXPECT().GetAccessRequest(mock.Anything, ar.GetName(), key.Namespace).Return(ar, nil)
+		f.service.EXPECT().RevokeAccessRequest(mock.Anything, ar).Return(nil, fmt.Errorf("some-error"))
+		f.logger.EXPECT().Error(mock.Anything, mock.Anything)
+
+		// When
+		resp := f.api.Post("/accessrequests/granted/revoke", headers...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 500, resp.Result().StatusCode)
+	})
+}
+
+func TestApiCancelAccessRequest(t *testing.T) {
+	newHeaders := func(ar *api.AccessRequest, username string) []any {
+		return headers(ar.GetNamespace(), "", username, "group1", ar.Spec.Application.Namespace, ar.Spec.Application.Name, "some-project")
+	}
+	t.Run("will cancel access request successfully", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		ar := utils.NewAccessRequestRequested(utils.WithName("requested"))
+		cancelled := ar.DeepCopy()
+		cancelled.Spec.Cancel = true
+		f.service.EXPECT().GetAccessRequest(mock.Anything, ar.GetName(), ar.GetNamespace()).Return(ar, nil)
+		f.service.EXPECT().CancelAccessRequest(mock.Anything, ar).Return(cancelled, nil)
+
+		// When
+		resp := f.api.Post("/accessrequests/requested/cancel", newHeaders(ar, ar.Spec.Subject.Username)...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 200, resp.Result().StatusCode)
+		var respBody backend.AccessRequestResponseBody
+		err := json.Unmarshal(resp.Body.Bytes(), &respBody)
+		assert.NoError(t, err)
+		assert.Equal(t, ar.GetName(), respBody.Name)
+	})
+	t.Run("will return 403 if caller is not the requester", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		ar := utils.NewAccessRequestRequested(utils.WithName("requested"))
+		f.service.EXPECT().GetAccessRequest(mock.Anything, ar.GetName(), ar.GetNamespace()).Return(ar, nil)
+
+		// When
+		resp := f.api.Post("/accessrequests/requested/cancel", newHeaders(ar, "another-user")...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 403, resp.Result().StatusCode)
+	})
+	t.Run("will return 409 if access request is granted", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		ar := utils.NewAccessRequestGranted(utils.WithName("granted"))
+		f.service.EXPECT().GetAccessRequest(mock.Anything, ar.GetName(), ar.GetNamespace()).Return(ar, nil)
+
+		// When
+		resp := f.api.Post("/accessrequests/granted/cancel", newHeaders(ar, ar.Spec.Subject.Username)...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 409, resp.Result().StatusCode)
+	})
+	t.Run("will return 409 if access request is concluded", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		ar := utils.NewAccessRequestExpired(utils.WithName("expired"))
+		f.service.EXPECT().GetAccessRequest(mock.Anything, ar.GetName(), ar.GetNamespace()).Return(ar, nil)
+
+		// When
+		resp := f.api.Post("/accessrequests/expired/cancel", newHeaders(ar, ar.Spec.Subject.Username)...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 409, resp.Result().StatusCode)
+	})
+	t.Run("will return 500 on service error cancelling access request", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		ar := utils.NewAccessRequestRequested(utils.WithName("requested"))
+		f.service.EXPECT().GetAccessRequest(mock.Anything, ar.GetName(), ar.GetNamespace()).Return(ar, nil)
+		f.service.EXPECT().CancelAccessRequest(mock.Anything, ar).Return(nil, fmt.Errorf("some-error"))
+		f.logger.EXPECT().Error(mock.Anything, mock.Anything)
+
+		// When
+		resp := f.api.Post("/accessrequests/requested/cancel", newHeaders(ar, ar.Spec.Subject.Username)...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 500, resp.Result().StatusCode)
+	})
+}
+
+func TestApiExtendAccessRequest(t *testing.T) {
+	newKey := func(ar *api.AccessRequest) *backend.AccessRequestKey {
+		return &backend.AccessRequestKey{
+			Namespace:            ar.GetNamespace(),
+			ApplicationName:      ar.Spec.Application.Name,
+			ApplicationNamespace: ar.Spec.Application.Namespace,
+			Username:             ar.Spec.Subject.Username,
+		}
+	}
+	t.Run("will extend access request successfully", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		ar := utils.NewAccessRequestGranted(utils.WithName("granted"))
+		extended := ar.DeepCopy()
+		extended.Spec.Extensions = []api.AccessExtension{{Duration: metav1.Duration{Duration: time.Hour}}}
+		key := newKey(ar)
+		headers := headers(key.Namespace, key.UserId, key.Username, "group1", key.ApplicationNamespace, key.ApplicationName, "some-project")
+		f.service.EXPECT().GetAccessRequest(mock.Anything, ar.GetName(), key.Namespace).Return(ar, nil)
+		f.service.EXPECT().ExtendAccessRequest(mock.Anything, ar, time.Hour).Return(extended, nil)
+
+		// When
+		payload := backend.ExtendAccessRequestBody{Duration: "1h"}
+		resp := f.api.Post("/accessrequests/granted/extend", append(headers, payload)...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 200, resp.Result().StatusCode)
+		var respBody backend.AccessRequestResponseBody
+		err := json.Unmarshal(resp.Body.Bytes(), &respBody)
+		assert.NoError(t, err)
+		assert.Equal(t, ar.GetName(), respBody.Name)
+	})
+	t.Run("will return 400 if duration is invalid", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		ar := utils.NewAccessRequestGranted(utils.WithName("granted"))
+		key := newKey(ar)
+		headers := headers(key.Namespace, key.UserId, key.Username, "group1", key.ApplicationNamespace, key.ApplicationName, "some-project")
+
+		// When
+		payload := backend.ExtendAccessRequestBody{Duration: "-1h"}
+		resp := f.api.Post("/accessrequests/granted/extend", append(headers, payload)...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 400, resp.Result().StatusCode)
+	})
+	t.Run("will return 400 if extension is not allowed by the binding", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		ar := utils.NewAccessRequestGranted(utils.WithName("granted"))
+		key := newKey(ar)
+		headers := headers(key.Namespace, key.UserId, key.Username, "group1", key.ApplicationNamespace, key.ApplicationName, "some-project")
+		f.service.EXPECT().GetAccessRequest(mock.Anything, ar.GetName(), key.Namespace).Return(ar, nil)
+		f.service.EXPECT().ExtendAccessRequest(mock.Anything, ar, 2*time.Hour).
+			Return(nil, backend.NewValidationError("invalid extension: too long"))
+
+		// When
+		payload := backend.ExtendAccessRequestBody{Duration: "2h"}
+		resp := f.api.Post("/accessrequests/granted/extend", append(headers, payload)...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 400, resp.Result().StatusCode)
+		assert.Contains(t, resp.Body.String(), "invalid extension: too long")
+	})
+	t.Run("will return 403 if caller is not the requester", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		ar := utils.NewAccessRequestGranted(utils.WithName("granted"))
+		key := newKey(ar)
+		headers := headers(key.Namespace, key.UserId, "another-user", "group1", key.ApplicationNamespace, key.ApplicationName, "some-project")
+		f.service.EXPECT().GetAccessRequest(mock.Anything, ar.GetName(), key.Namespace).Return(ar, nil)
+
+		// When
+		payload := backend.ExtendAccessRequestBody{Duration: "1h"}
+		resp := f.api.Post("/accessrequests/granted/extend", append(headers, payload)...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 403, resp.Result().StatusCode)
+	})
+	t.Run("will return 409 if access request is not granted", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		ar := utils.NewAccessRequestRequested(utils.WithName("requested"))
+		key := newKey(ar)
+		headers := headers(key.Namespace, key.UserId, key.Username, "group1", key.ApplicationNamespace, key.ApplicationName, "some-project")
+		f.service.EXPECT().GetAccessRequest(mock.Anything, ar.GetName(), key.Namespace).Return(ar, nil)
+
+		// When
+		payload := backend.ExtendAccessRequestBody{Duration: "1h"}
+		resp := f.api.Post("/accessrequests/requested/extend", append(headers, payload)...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 409, resp.Result().StatusCode)
+	})
+	t.Run("will return 409 if access request has a pending extension", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		ar := utils.NewAccessRequestGranted(utils.WithName("granted"))
+		ar.Spec.Extensions = []api.AccessExtension{{Duration: metav1.Duration{Duration: time.Hour}}}
+		key := newKey(ar)
+		headers := headers(key.Namespace, key.UserId, key.Username, "group1", key.ApplicationNamespace, key.ApplicationName, "some-project")
+		f.service.EXPECT().GetAccessRequest(mock.Anything, ar.GetName(), key.Namespace).Return(ar, nil)
+
+		// When
+		payload := backend.ExtendAccessRequestBody{Duration: "1h"}
+		resp := f.api.Post("/accessrequests/granted/extend", append(headers, payload)...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 409, resp.Result().StatusCode)
+		assert.Contains(t, resp.Body.String(), "pending extension")
+	})
+	t.Run("will return 500 on service error extending access request", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		ar := utils.NewAccessRequestGranted(utils.WithName("granted"))
+		key := newKey(ar)
+		headers := headers(key.Namespace, key.UserId, key.Username, "group1", key.ApplicationNamespace, key.ApplicationName, "some-project")
+		f.service.EXPECT().GetAccessRequest(mock.Anything, ar.GetName(), key.Namespace).Return(ar, nil)
+		f.service.EXPECT().ExtendAccessRequest(mock.Anything, ar, time.Hour).Return(nil, fmt.Errorf("some-error"))
+		f.logger.EXPECT().Error(mock.Anything, mock.Anything)
+
+		// When
+		payload := backend.ExtendAccessRequestBody{Duration: "1h"}
+		resp := f.api.Post("/accessrequests/granted/extend", append(headers, payload)...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 500, resp.Result().StatusCode)
+	})
+}
+
+func TestApiApprovals(t *testing.T) {
+	newPendingApproval := func() *api.AccessRequest {
+		ar := utils.NewAccessRequestRequested(utils.WithName("pending"))
+		ar.Spec.Approval = &api.ApprovalPolicy{
+			Approvers:         []string{"approvers"},
+			RequiredApprovals: 2,
+		}
+		return ar
+	}
+	approverHeaders := func(ar *api.AccessRequest, username, groups string) []any {
+		return headers(ar.GetNamespace(), "", username, groups, ar.Spec.Application.Namespace, ar.Spec.Application.Name, "some-project")
+	}
+	t.Run("will list pending approvals for the user groups", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		ar := newPendingApproval()
+		key := &backend.AccessRequestKey{
+			Namespace:            ar.GetNamespace(),
+			ApplicationName:      ar.Spec.Application.Name,
+			ApplicationNamespace: ar.Spec.Application.Namespace,
+			Username:             "some-approver",
+		}
+		f.service.EXPECT().ListPendingApprovals(mock.Anything, key, []string{"approvers", "group1"}).
+			Return([]*api.AccessRequest{ar}, nil)
+
+		// When
+		resp := f.api.Get("/approvals", approverHeaders(ar, "some-approver", "approvers,group1")...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 200, resp.Result().StatusCode)
+		var respBody backend.ListAccessRequestResponseBody
+		err := json.Unmarshal(resp.Body.Bytes(), &respBody)
+		assert.NoError(t, err)
+		require.Len(t, respBody.Items, 1)
+		assert.Equal(t, ar.GetName(), respBody.Items[0].Name)
+		assert.Equal(t, 2, respBody.Items[0].RequiredApprovals)
+	})
+	t.Run("will approve access request successfully", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		ar := newPendingApproval()
+		f.service.EXPECT().GetAccessRequest(mock.Anything, ar.GetName(), ar.GetNamespace()).Return(ar, nil)
+		var recorded *api.ApprovalDecision
+		f.service.EXPECT().AddApprovalDecision(mock.Anything, ar, mock.Anything).
+			RunAndReturn(func(ctx context.Context, ar *api.AccessRequest, decision *api.ApprovalDecision) (*api.AccessRequest, error) {
+				recorded = decision
+				result := ar.DeepCopy()
+				result.Spec.Approvals = append(result.Spec.Approvals, *decision)
+				return result, nil
+			})
+
+		// When
+		payload := backend.ApprovalDecisionBody{Comment: "looks good"}
+		resp := f.api.Post("/accessrequests/pending/approve", append(approverHeaders(ar, "some-approver", "approvers"), payload)...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 200, resp.Result().StatusCode)
+		require.NotNil(t, recorded)
+		assert.Equal(t, "some-approver", recorded.Username)
+		assert.Equal(t, api.ApprovedDecision, recorded.Decision)
+		assert.Equal(t, "looks good", recorded.Comment)
+		var respBody backend.AccessRequestResponseBody
+		err := json.Unmarshal(resp.Body.Bytes(), &respBody)
+		assert.NoError(t, err)
+		require.Len(t, respBody.Approvals, 1)
+		assert.Equal(t, "APPROVED", respBody.Approvals[0].Decision)
+	})
+	t.Run("will reject access request successfully", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		ar := newPendingApproval()
+		f.service.EXPECT().GetAccessRequest(mock.Anything, ar.GetName(), ar.GetNamespace()).Return(ar, nil)
+		f.service.EXPECT().AddApprovalDecision(mock.Anything, ar, mock.MatchedBy(func(d *api.ApprovalDecision) bool {
+			return d.Username == "some-approver" && d.Decision == api.RejectedDecision
+		})).Return(ar, nil)
+
+		// When
+		payload := backend.ApprovalDecisionBody{}
+		resp := f.api.Post("/accessrequests/pending/reject", append(approverHeaders(ar, "some-approver", "approvers"), payload)...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 200, resp.Result().StatusCode)
+	})
+	t.Run("will return 403 on self-approval", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		ar := newPendingApproval()
+		f.service.EXPECT().GetAccessRequest(mock.Anything, ar.GetName(), ar.GetNamespace()).Return(ar, nil)
+
+		// When
+		payload := backend.ApprovalDecisionBody{}
+		resp := f.api.Post("/accessrequests/pending/approve", append(approverHeaders(ar, ar.Spec.Subject.Username, "approvers"), payload)...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 403, resp.Result().StatusCode)
+		assert.Contains(t, resp.Body.String(), "self-approval is not allowed")
+	})
+	t.Run("will return 403 if user is not an approver", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		ar := newPendingApproval()
+		f.service.EXPECT().GetAccessRequest(mock.Anything, ar.GetName(), ar.GetNamespace()).Return(ar, nil)
+
+		// When
+		payload := backend.ApprovalDecisionBody{}
+		resp := f.api.Post("/accessrequests/pending/approve", append(approverHeaders(ar, "some-approver", "group1"), payload)...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 403, resp.Result().StatusCode)
+	})
+	t.Run("will return 409 if user already decided", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		ar := newPendingApproval()
+		ar.Spec.Approvals = []api.ApprovalDecision{{Username: "some-approver", Decision: api.ApprovedDecision}}
+		f.service.EXPECT().GetAccessRequest(mock.Anything, ar.GetName(), ar.GetNamespace()).Return(ar, nil)
+
+		// When
+		payload := backend.ApprovalDecisionBody{}
+		resp := f.api.Post("/accessrequests/pending/reject", append(approverHeaders(ar, "some-approver", "approvers"), payload)...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 409, resp.Result().StatusCode)
+	})
+	t.Run("will return 409 if access request does not require approval", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		ar := newPendingApproval()
+		ar.Spec.Approval = nil
+		f.service.EXPECT().GetAccessRequest(mock.Anything, ar.GetName(), ar.GetNamespace()).Return(ar, nil)
+
+		// When
+		payload := backend.ApprovalDecisionBody{}
+		resp := f.api.Post("/accessrequests/pending/approve", append(approverHeaders(ar, "some-approver", "approvers"), payload)...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 409, resp.Result().StatusCode)
+	})
+	t.Run("will return 409 if access request is already concluded", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		ar := newPendingApproval()
+		ar.Status.RequestState = api.DeniedStatus
+		f.service.EXPECT().GetAccessRequest(mock.Anything, ar.GetName(), ar.GetNamespace()).Return(ar, nil)
+
+		// When
+		payload := backend.ApprovalDecisionBody{}
+		resp := f.api.Post("/accessrequests/pending/approve", append(approverHeaders(ar, "some-approver", "approvers"), payload)...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 409, resp.Result().StatusCode)
+	})
+}
+
+func TestApiListAccessRequest(t *testing.T) {
+	t.Run("will return access requests successfully", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		ar1 := utils.NewAccessRequestRequested(utils.WithName("first"))
+		ar2 := utils.NewAccessRequestRequested(utils.WithName("second"))
+		key := &backend.AccessRequestKey{
+			Namespace:            ar1.GetNamespace(),
+			ApplicationName:      ar1.Spec.Application.Name,
+			ApplicationNamespace: ar1.Spec.Application.Namespace,
+			Username:             ar1.Spec.Subject.Username,
+		}
+		headers := headers(key.Namespace, key.UserId, key.Username, "group1", key.ApplicationNamespace, key.ApplicationName, "some-project")
+		f.service.EXPECT().ListAccessRequests(mock.Anything, key, true).Return([]*api.AccessRequest{ar1, ar2}, nil)
+
+		// When
+		resp := f.api.Get("/accessrequests", headers...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 200, resp.Result().StatusCode)
+		var respBody backend.ListAccessRequestResponseBody
+		err := json.Unmarshal(resp.Body.Bytes(), &respBody)
+		assert.NoError(t, err)
+		require.Equal(t, 2, len(respBody.Items))
+		assert.Equal(t, ar1.GetNamespace(), respBody.Items[0].Namespace)
+		assert.Equal(t, ar1.GetName(), respBody.Items[0].Name)
+		assert.Equal(t, ar2.GetNamespace(), respBody.Items[1].Namespace)
+		assert.Equal(t, ar2.GetName(), respBody.Items[1].Name)
+	})
+	t.Run("will return 422 on invalid headers", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		key := &backend.AccessRequestKey{
+			Namespace:            "some-namespace",
+			ApplicationName:      "some-app:with-invalid-colon",
+			ApplicationNamespace: "app-ns",
+			Username:             "some-user",
+		}
+		headers := headers(key.Namespace, key.UserId, key.Username, "group1", key.ApplicationNamespace, key.ApplicationName, "some-project")
+		headers = headers[1:]
+
+		// When
+		resp := f.api.Get("/accessrequests", headers...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 422, resp.Result().StatusCode)
+	})
+	t.Run("will return 400 on invalid header format", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		key := &backend.AccessRequestKey{
+			Namespace:            "some-namespace",
+			ApplicationName:      "some-app:with-invalid-colon",
+			ApplicationNamespace: "app-ns",
+			Username:             "some-user",
+		}
+		headers := headers(key.Namespace, key.UserId, key.Username, "group1", key.ApplicationNamespace, key.ApplicationName, "some-project")
+
+		// When
+		resp := f.api.Get("/accessrequests", headers...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 400, resp.Result().StatusCode)
+	})
+	t.Run("will return 500 on service error", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		key := &backend.AccessRequestKey{
+			Namespace:            "some-namespace",
+			ApplicationName:      "some-app",
+			ApplicationNamespace: "app-ns",
+			Username:             "some-user",
+		}
+		headers := headers(key.Namespace, key.UserId, key.Username, "group1", key.ApplicationNamespace, key.ApplicationName, "some-project")
+		f.service.EXPECT().ListAccessRequests(mock.Anything, key, mock.Anything).Return(nil, fmt.Errorf("some-error"))
+		f.logger.EXPECT().Error(mock.Anything, mock.Anything)
+
+		// When
+		resp := f.api.Get("/accessrequests", headers...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 500, resp.Result().StatusCode)
+	})
+	t.Run("will return empty list if no access request exist", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		key := &backend.AccessRequestKey{
+			Namespace:            "some-namespace",
+			ApplicationName:      "some-app",
+			ApplicationNamespace: "app-ns",
+			Username:             "some-user",
+		}
+		headers := headers(key.Namespace, key.UserId, key.Username, "group1", key.ApplicationNamespace, key.ApplicationName, "some-project")
+		f.service.EXPECT().ListAccessRequests(mock.Anything, key, mock.Anything).Return(nil, nil)
+
+		// When
+		resp := f.api.Get("/accessrequests", headers...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 200, resp.Result().StatusCode)
+		var respBody backend.ListAccessRequestResponseBody
+		err := json.Unmarshal(resp.Body.Bytes(), &respBody)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, len(respBody.Items))
+	})
+
+}
+
+func TestApiListUserAccessRequests(t *testing.T) {
+	userHeaders := []any{
+		"Argocd-Namespace: some-namespace",
+		"Argocd-Username: some-user",
+		"Argocd-User-Groups: group1",
+	}
+	t.Run("will return the user access requests without application headers", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		ar1 := utils.NewAccessRequestGranted(utils.WithName("first"))
+		ar2 := utils.NewAccessRequestGranted(utils.WithName("second"))
+		opts := &backend.ListOptions{Status: api.GrantedStatus, Limit: 2, Continue: "some-token"}
+		page := &backend.AccessRequestPage{Items: []*api.AccessRequest{ar1, ar2}, Continue: "next-token"}
+		f.service.EXPECT().ListSubjectAccessRequests(mock.Anything, "some-namespace", "some-user", opts).Return(page, nil)
+
+		// When
+		resp := f.api.Get("/user/accessrequests?status=GRANTED&limit=2&continue=some-token", userHeaders...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 200, resp.Result().StatusCode)
+		var respBody backend.ListUserAccessRequestsResponseBody
+		err := json.Unmarshal(resp.Body.Bytes(), &respBody)
+		assert.NoError(t, err)
+		require.Len(t, respBody.Items, 2)
+		assert.Equal(t, ar1.GetName(), respBody.Items[0].Name)
+		assert.Equal(t, fmt.Sprintf("%s:%s", ar1.Spec.Application.Namespace, ar1.Spec.Application.Name), respBody.Items[0].Application)
+		assert.Equal(t, ar2.GetName(), respBody.Items[1].Name)
+		assert.Equal(t, "next-token", respBody.Continue)
+	})
+	t.Run("will use the default limit if not provided", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		opts := &backend.ListOptions{Limit: backend.DefaultPageLimit}
+		page := &backend.AccessRequestPage{Items: []*api.AccessRequest{}}
+		f.service.EXPECT().ListSubjectAccessRequests(mock.Anything, "some-namespace", "some-user", opts).Return(page, nil)
+
+		// When
+		resp := f.api.Get("/user/accessrequests", userHeaders...)
 
 		// Then
 		assert.NotNil(t, resp)
 		assert.Equal(t, 200, resp.Result().StatusCode)
-		var respBody backend.ListAccessRequestResponseBody
+		var respBody backend.ListUserAccessRequestsResponseBody
 		err := json.Unmarshal(resp.Body.Bytes(), &respBody)
 		assert.NoError(t, err)
-		assert.Equal(t, 0, len(respBody.Items))
+		assert.Empty(t, respBody.Items)
+		assert.Empty(t, respBody.Continue)
+	})
+	t.Run("will filter by the cancelled status", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		opts := &backend.ListOptions{Status: api.CancelledStatus, Limit: backend.DefaultPageLimit}
+		page := &backend.AccessRequestPage{Items: []*api.AccessRequest{}}
+		f.service.EXPECT().ListSubjectAccessRequests(mock.Anything, "some-namespace", "some-user", opts).Return(page, nil)
+
+		// When
+		resp := f.api.Get("/user/accessrequests?status=CANCELLED", userHeaders...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 200, resp.Result().StatusCode)
+	})
+	t.Run("will filter by the timeout status", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		opts := &backend.ListOptions{Status: api.TimeoutStatus, Limit: backend.DefaultPageLimit}
+		page := &backend.AccessRequestPage{Items: []*api.AccessRequest{}}
+		f.service.EXPECT().ListSubjectAccessRequests(mock.Anything, "some-namespace", "some-user", opts).Return(page, nil)
+
+		// When
+		resp := f.api.Get("/user/accessrequests?status=TIMEOUT", userHeaders...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 200, resp.Result().StatusCode)
+	})
+	t.Run("will return 422 on invalid status", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+
+		// When
+		resp := f.api.Get("/user/accessrequests?status=UNKNOWN", userHeaders...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 422, resp.Result().StatusCode)
+	})
+	t.Run("will return 400 on invalid continue token", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		f.service.EXPECT().ListSubjectAccessRequests(mock.Anything, "some-namespace", "some-user", mock.Anything).
+			Return(nil, backend.NewValidationError("invalid continue token: malformed token"))
+
+		// When
+		resp := f.api.Get("/user/accessrequests?continue=invalid", userHeaders...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 400, resp.Result().StatusCode)
+		assert.Contains(t, resp.Body.String(), "invalid continue token")
+	})
+	t.Run("will return 500 on service error", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		f.service.EXPECT().ListSubjectAccessRequests(mock.Anything, "some-namespace", "some-user", mock.Anything).
+			Return(nil, fmt.Errorf("some-error"))
+		f.logger.EXPECT().Error(mock.Anything, mock.Anything)
+
+		// When
+		resp := f.api.Get("/user/accessrequests", userHeaders...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 500, resp.Result().StatusCode)
+	})
+}
+
+func TestApiWatchUserAccessRequests(t *testing.T) {
+	userHeaders := []any{
+		"Argocd-Namespace: some-namespace",
+		"Argocd-Username: some-user",
+		"Argocd-User-Groups: group1",
+	}
+	t.Run("will stream access request status changes", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		events := make(chan *api.AccessRequest, 2)
+		events <- utils.NewAccessRequestRequested(utils.WithName("some-ar"))
+		events <- utils.NewAccessRequestGranted(utils.WithName("some-ar"))
+		close(events)
+		f.service.EXPECT().WatchSubjectAccessRequests(mock.Anything, "some-namespace", "some-user").
+			Return((<-chan *api.AccessRequest)(events), nil)
+
+		// When
+		resp := f.api.Get("/user/accessrequests/events", userHeaders...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 200, resp.Result().StatusCode)
+		assert.Equal(t, "text/event-stream", resp.Result().Header.Get("Content-Type"))
+		body := resp.Body.String()
+		assert.Equal(t, 2, strings.Count(body, "event: accessrequest\n"))
+		requestedIdx := strings.Index(body, `"status":"REQUESTED"`)
+		grantedIdx := strings.Index(body, `"status":"GRANTED"`)
+		assert.True(t, requestedIdx >= 0 && grantedIdx > requestedIdx)
+	})
+	t.Run("will end the stream on service error", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		f.service.EXPECT().WatchSubjectAccessRequests(mock.Anything, "some-namespace", "some-user").
+			Return(nil, fmt.Errorf("some-error"))
+		f.logger.EXPECT().Error(mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+		// When
+		resp := f.api.Get("/user/accessrequests/events", userHeaders...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Empty(t, resp.Body.String())
+	})
+}
+
+func TestApiAdminAccessRequests(t *testing.T) {
+	adminHeaders := func(groups string) []any {
+		return []any{
+			"Argocd-Namespace: some-namespace",
+			"Argocd-Username: some-auditor",
+			fmt.Sprintf("Argocd-User-Groups: %s", groups),
+		}
+	}
+	withAdmins := backend.WithAdminGroups([]string{"auditors"})
+	t.Run("will search access requests with the given filters", func(t *testing.T) {
+		// Given
+		f := apiSetup(t, withAdmins)
+		ar := utils.NewAccessRequestGranted(utils.WithName("some-ar"))
+		ar.Status.TargetProject = "some-project"
+		from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+		expectedFilter := &backend.AccessRequestFilter{
+			Username:             "some-user",
+			ApplicationName:      "some-app",
+			ApplicationNamespace: "app-ns",
+			Project:              "some-project",
+			Role:                 "some-role",
+			Status:               api.GrantedStatus,
+			CreatedAfter:         &from,
+			CreatedBefore:        &to,
+		}
+		expectedOpts := &backend.ListOptions{Limit: 10, Continue: "some-token"}
+		page := &backend.AccessRequestPage{Items: []*api.AccessRequest{ar}, Continue: "next-token"}
+		f.service.EXPECT().SearchAccessRequests(mock.Anything, "some-namespace", expectedFilter, expectedOpts).Return(page, nil)
+		query := "username=some-user&application=app-ns:some-app&project=some-project&role=some-role&status=GRANTED" +
+			"&from=2024-01-01T00:00:00Z&to=2024-04-01T00:00:00Z&limit=10&continue=some-token"
+
+		// When
+		resp := f.api.Get("/admin/accessrequests?"+query, adminHeaders("group1,auditors")...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 200, resp.Result().StatusCode)
+		var respBody backend.AdminListAccessRequestsResponseBody
+		err := json.Unmarshal(resp.Body.Bytes(), &respBody)
+		assert.NoError(t, err)
+		require.Len(t, respBody.Items, 1)
+		assert.Equal(t, "some-ar", respBody.Items[0].Name)
+		assert.Equal(t, "some-project", respBody.Items[0].TargetProject)
+		assert.Equal(t, "next-token", respBody.Continue)
+	})
+	t.Run("will search access requests by application name only", func(t *testing.T) {
+		// Given
+		f := apiSetup(t, withAdmins)
+		expectedFilter := &backend.AccessRequestFilter{ApplicationName: "some-app"}
+		page := &backend.AccessRequestPage{Items: []*api.AccessRequest{}}
+		f.service.EXPECT().SearchAccessRequests(mock.Anything, "some-namespace", expectedFilter, mock.Anything).Return(page, nil)
+
+		// When
+		resp := f.api.Get("/admin/accessrequests?application=some-app", adminHeaders("auditors")...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 200, resp.Result().StatusCode)
+	})
+	t.Run("will return 403 if user is not an admin", func(t *testing.T) {
+		// Given
+		f := apiSetup(t, withAdmins)
+
+		// When
+		resp := f.api.Get("/admin/accessrequests", adminHeaders("group1")...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 403, resp.Result().StatusCode)
+	})
+	t.Run("will return 403 if admin groups are not configured", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+
+		// When
+		resp := f.api.Get("/admin/accessrequests", adminHeaders("auditors")...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 403, resp.Result().StatusCode)
+	})
+	t.Run("will return 400 on invalid time range", func(t *testing.T) {
+		// Given
+		f := apiSetup(t, withAdmins)
+
+		// When
+		resp := f.api.Get("/admin/accessrequests?from=2024-04-01T00:00:00Z&to=2024-01-01T00:00:00Z", adminHeaders("auditors")...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 400, resp.Result().StatusCode)
+	})
+	t.Run("will return 400 on invalid application", func(t *testing.T) {
+		// Given
+		f := apiSetup(t, withAdmins)
+
+		// When
+		resp := f.api.Get("/admin/accessrequests?application=app-ns:", adminHeaders("auditors")...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 400, resp.Result().StatusCode)
+	})
+	t.Run("will return 400 on invalid continue token", func(t *testing.T) {
+		// Given
+		f := apiSetup(t, withAdmins)
+		f.service.EXPECT().SearchAccessRequests(mock.Anything, "some-namespace", mock.Anything, mock.Anything).
+			Return(nil, backend.NewValidationError("invalid continue token: malformed token"))
+
+		// When
+		resp := f.api.Get("/admin/accessrequests?continue=invalid", adminHeaders("auditors")...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 400, resp.Result().StatusCode)
+	})
+	t.Run("will return 500 on service error", func(t *testing.T) {
+		// Given
+		f := apiSetup(t, withAdmins)
+		f.service.EXPECT().SearchAccessRequests(mock.Anything, "some-namespace", mock.Anything, mock.Anything).
+			Return(nil, fmt.Errorf("some-error"))
+		f.logger.EXPECT().Error(mock.Anything, mock.Anything)
+
+		// When
+		resp := f.api.Get("/admin/accessrequests", adminHeaders("auditors")...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 500, resp.Result().StatusCode)
+	})
+	t.Run("will search archived access requests with the given filters", func(t *testing.T) {
+		// Given
+		f := apiSetup(t, withAdmins)
+		ar := utils.NewAccessRequestExpired(utils.WithName("archived-ar"))
+		expectedFilter := &backend.AccessRequestFilter{Username: "some-user", Status: api.ExpiredStatus}
+		expectedOpts := &backend.ListOptions{Limit: 5}
+		page := &backend.AccessRequestPage{Items: []*api.AccessRequest{ar}}
+		f.service.EXPECT().SearchArchivedAccessRequests(mock.Anything, "some-namespace", expectedFilter, expectedOpts).Return(page, nil)
+
+		// When
+		resp := f.api.Get("/admin/accessrequests/archive?username=some-user&status=EXPIRED&limit=5", adminHeaders("auditors")...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 200, resp.Result().StatusCode)
+		var respBody backend.AdminListAccessRequestsResponseBody
+		err := json.Unmarshal(resp.Body.Bytes(), &respBody)
+		assert.NoError(t, err)
+		require.Len(t, respBody.Items, 1)
+		assert.Equal(t, "archived-ar", respBody.Items[0].Name)
+	})
+	t.Run("will return 404 if the archive is not configured", func(t *testing.T) {
+		// Given
+		f := apiSetup(t, withAdmins)
+		f.service.EXPECT().SearchArchivedAccessRequests(mock.Anything, "some-namespace", mock.Anything, mock.Anything).
+			Return(nil, backend.ErrArchiveNotConfigured)
+
+		// When
+		resp := f.api.Get("/admin/accessrequests/archive", adminHeaders("auditors")...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 404, resp.Result().StatusCode)
+	})
+	t.Run("will return 403 on archive search if user is not an admin", func(t *testing.T) {
+		// Given
+		f := apiSetup(t, withAdmins)
+
+		// When
+		resp := f.api.Get("/admin/accessrequests/archive", adminHeaders("group1")...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 403, resp.Result().StatusCode)
+	})
+	t.Run("will export all matching access requests as csv", func(t *testing.T) {
+		// Given
+		f := apiSetup(t, withAdmins)
+		ar1 := utils.NewAccessRequestGranted(utils.WithName("first"))
+		ar1.Spec.Justification = "incident, with comma"
+		ar1.Spec.Approvals = []api.ApprovalDecision{
+			{Username: "approver1", Decision: api.ApprovedDecision},
+			{Username: "approver2", Decision: api.RejectedDecision},
+		}
+		ar2 := utils.NewAccessRequestExpired(utils.WithName("second"))
+		expectedFilter := &backend.AccessRequestFilter{Status: api.GrantedStatus}
+		page := &backend.AccessRequestPage{Items: []*api.AccessRequest{ar1, ar2}}
+		f.service.EXPECT().SearchAccessRequests(mock.Anything, "some-namespace", expectedFilter, (*backend.ListOptions)(nil)).Return(page, nil)
+
+		// When
+		resp := f.api.Get("/admin/accessrequests/export?status=GRANTED", adminHeaders("auditors")...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 200, resp.Result().StatusCode)
+		assert.Equal(t, "text/csv", resp.Result().Header.Get("Content-Type"))
+		assert.Contains(t, resp.Result().Header.Get("Content-Disposition"), "accessrequests.csv")
+		lines := strings.Split(strings.TrimSpace(resp.Body.String()), "\n")
+		require.Len(t, lines, 3)
+		assert.True(t, strings.HasPrefix(lines[0], "name,namespace,application,project,role,username,status"))
+		assert.True(t, strings.HasPrefix(lines[1], "first,"))
+		assert.Contains(t, lines[1], `"incident, with comma"`)
+		assert.Contains(t, lines[1], "approver1:approved;approver2:rejected")
+		assert.True(t, strings.HasPrefix(lines[2], "second,"))
 	})
+	t.Run("will return 403 on export if user is not an admin", func(t *testing.T) {
+		// Given
+		f := apiSetup(t, withAdmins)
+
+		// When
+		resp := f.api.Get("/admin/accessrequests/export", adminHeaders("group1")...)
 
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 403, resp.Result().StatusCode)
+	})
 }
 
 func TestArgoCDHeaders_Application(t *testing.T) {
@@ -613,6 +1815,7 @@ func Test_toAccessRequestResponseBody(t *testing.T) {
 				return backend.AccessRequestResponseBody{
 					Name:        ar.GetName(),
 					Namespace:   ar.GetNamespace(),
+					Application: fmt.Sprintf("%s:%s", ar.Spec.Application.Namespace, ar.Spec.Application.Name),
 					Username:    ar.Spec.Subject.Username,
 					Role:        ar.Spec.Role.TemplateRef.Name,
 					Permission:  ar.Spec.Role.TemplateRef.Name,
@@ -630,6 +1833,7 @@ func Test_toAccessRequestResponseBody(t *testing.T) {
 				return backend.AccessRequestResponseBody{
 					Name:        ar.GetName(),
 					Namespace:   ar.GetNamespace(),
+					Application: fmt.Sprintf("%s:%s", ar.Spec.Application.Namespace, ar.Spec.Application.Name),
 					Username:    ar.Spec.Subject.Username,
 					Role:        ar.Spec.Role.TemplateRef.Name,
 					Permission:  *ar.Spec.Role.FriendlyName,
@@ -647,6 +1851,7 @@ func Test_toAccessRequestResponseBody(t *testing.T) {
 				return backend.AccessRequestResponseBody{
 					Name:        ar.GetName(),
 					Namespace:   ar.GetNamespace(),
+					Application: fmt.Sprintf("%s:%s", ar.Spec.Application.Namespace, ar.Spec.Application.Name),
 					Username:    ar.Spec.Subject.Username,
 					Role:        ar.Spec.Role.TemplateRef.Name,
 					Permission:  *ar.Spec.Role.FriendlyName,
@@ -664,6 +1869,7 @@ func Test_toAccessRequestResponseBody(t *testing.T) {
 				return backend.AccessRequestResponseBody{
 					Name:        ar.GetName(),
 					Namespace:   ar.GetNamespace(),
+					Application: fmt.Sprintf("%s:%s", ar.Spec.Application.Namespace, ar.Spec.Application.Name),
 					Username:    ar.Spec.Subject.Username,
 					Role:        ar.Spec.Role.TemplateRef.Name,
 					Permission:  *ar.Spec.Role.FriendlyName,
@@ -681,6 +1887,7 @@ func Test_toAccessRequestResponseBody(t *testing.T) {
 				return backend.AccessRequestResponseBody{
 					Name:        ar.GetName(),
 					Namespace:   ar.GetNamespace(),
+					Application: fmt.Sprintf("%s:%s", ar.Spec.Application.Namespace, ar.Spec.Application.Name),
 					Username:    ar.Spec.Subject.Username,
 					Role:        ar.Spec.Role.TemplateRef.Name,
 					Permission:  *ar.Spec.Role.FriendlyName,
@@ -698,6 +1905,7 @@ func Test_toAccessRequestResponseBody(t *testing.T) {
 				return backend.AccessRequestResponseBody{
 					Name:        ar.GetName(),
 					Namespace:   ar.GetNamespace(),
+					Application: fmt.Sprintf("%s:%s", ar.Spec.Application.Namespace, ar.Spec.Application.Name),
 					Username:    ar.Spec.Subject.Username,
 					Role:        ar.Spec.Role.TemplateRef.Name,
 					Permission:  *ar.Spec.Role.FriendlyName,
@@ -715,6 +1923,7 @@ func Test_toAccessRequestResponseBody(t *testing.T) {
 				return backend.AccessRequestResponseBody{
 					Name:        ar.GetName(),
 					Namespace:   ar.GetNamespace(),
+					Application: fmt.Sprintf("%s:%s", ar.Spec.Application.Namespace, ar.Spec.Application.Name),
 					Username:    ar.Spec.Subject.Username,
 					Role:        ar.Spec.Role.TemplateRef.Name,
 					Permission:  *ar.Spec.Role.FriendlyName,
@@ -732,6 +1941,7 @@ func Test_toAccessRequestResponseBody(t *testing.T) {
 				return backend.AccessRequestResponseBody{
 					Name:        ar.GetName(),
 					Namespace:   ar.GetNamespace(),
+					Application: fmt.Sprintf("%s:%s", ar.Spec.Application.Namespace, ar.Spec.Application.Name),
 					Username:    ar.Spec.Subject.Username,
 					Role:        ar.Spec.Role.TemplateRef.Name,
 					Permission:  *ar.Spec.Role.FriendlyName,
@@ -754,6 +1964,117 @@ func Test_toAccessRequestResponseBody(t *testing.T) {
 	}
 }
 
+func TestApiExplainRole(t *testing.T) {
+	newExplanation := func(name string, condition *string, result backend.ConditionResult, granting bool) *backend.AccessBindingExplanation {
+		ab := newAccessBinding("argocd-namespace", "some-role", "group1")
+		ab.SetName(name)
+		ab.Spec.If = condition
+		e := &backend.AccessBindingExplanation{
+			Binding:   ab,
+			Condition: result,
+			Subjects:  ab.Spec.Subjects,
+			Granting:  granting,
+		}
+		if granting {
+			e.MatchedGroups = []string{"group1"}
+		}
+		return e
+	}
+	reqHeaders := headers("argocd-namespace", "", "some-user", "group1,group2", "app-ns", "some-app", "some-project")
+	t.Run("will explain all bindings for the role", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		app := &unstructured.Unstructured{}
+		project := &unstructured.Unstructured{}
+		explanations := []*backend.AccessBindingExplanation{
+			newExplanation("false-condition", strPtr("false"), backend.ConditionFalse, false),
+			newExplanation("granting", nil, backend.ConditionNotDefined, true),
+		}
+		f.service.EXPECT().GetApplication(mock.Anything, "some-app", "app-ns").Return(app, nil)
+		f.service.EXPECT().GetAppProject(mock.Anything, "some-project", "argocd-namespace").Return(project, nil)
+		f.service.EXPECT().ExplainAccessBindings(mock.Anything, "some-role", "argocd-namespace", []string{"group1", "group2"}, app, project).
+			Return(explanations, nil)
+
+		// When
+		resp := f.api.Get("/roles/some-role/explain", reqHeaders...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 200, resp.Result().StatusCode)
+		var respBody backend.ExplainRoleResponseBody
+		err := json.Unmarshal(resp.Body.Bytes(), &respBody)
+		assert.NoError(t, err)
+		assert.Equal(t, "some-role", respBody.RoleName)
+		assert.True(t, respBody.Allowed)
+		assert.Equal(t, []string{"group1", "group2"}, respBody.UserGroups)
+		require.Len(t, respBody.Bindings, 2)
+		assert.Equal(t, "false-condition", respBody.Bindings[0].Name)
+		assert.Equal(t, "false", respBody.Bindings[0].Condition)
+		assert.Equal(t, "false", respBody.Bindings[0].ConditionResult)
+		assert.False(t, respBody.Bindings[0].Granting)
+		assert.Equal(t, "granting", respBody.Bindings[1].Name)
+		assert.Equal(t, "none", respBody.Bindings[1].ConditionResult)
+		assert.Equal(t, []string{"group1"}, respBody.Bindings[1].MatchedGroups)
+		assert.True(t, respBody.Bindings[1].Granting)
+	})
+	t.Run("will return not allowed if no binding is granting", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		app := &unstructured.Unstructured{}
+		project := &unstructured.Unstructured{}
+		explanations := []*backend.AccessBindingExplanation{
+			newExplanation("error-condition", strPtr("1 + 1"), backend.ConditionError, false),
+		}
+		f.service.EXPECT().GetApplication(mock.Anything, "some-app", "app-ns").Return(app, nil)
+		f.service.EXPECT().GetAppProject(mock.Anything, "some-project", "argocd-namespace").Return(project, nil)
+		f.service.EXPECT().ExplainAccessBindings(mock.Anything, "some-role", "argocd-namespace", mock.Anything, app, project).
+			Return(explanations, nil)
+
+		// When
+		resp := f.api.Get("/roles/some-role/explain", reqHeaders...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 200, resp.Result().StatusCode)
+		var respBody backend.ExplainRoleResponseBody
+		err := json.Unmarshal(resp.Body.Bytes(), &respBody)
+		assert.NoError(t, err)
+		assert.False(t, respBody.Allowed)
+		require.Len(t, respBody.Bindings, 1)
+		assert.Equal(t, "error", respBody.Bindings[0].ConditionResult)
+	})
+	t.Run("will return 404 if application not found", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		f.service.EXPECT().GetApplication(mock.Anything, "some-app", "app-ns").Return(nil, nil)
+
+		// When
+		resp := f.api.Get("/roles/some-role/explain", reqHeaders...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 404, resp.Result().StatusCode)
+	})
+	t.Run("will return 500 on service error", func(t *testing.T) {
+		// Given
+		f := apiSetup(t)
+		app := &unstructured.Unstructured{}
+		project := &unstructured.Unstructured{}
+		f.service.EXPECT().GetApplication(mock.Anything, "some-app", "app-ns").Return(app, nil)
+		f.service.EXPECT().GetAppProject(mock.Anything, "some-project", "argocd-namespace").Return(project, nil)
+		f.service.EXPECT().ExplainAccessBindings(mock.Anything, "some-role", "argocd-namespace", mock.Anything, app, project).
+			Return(nil, fmt.Errorf("some-error"))
+		f.logger.EXPECT().Error(mock.Anything, mock.Anything)
+
+		// When
+		resp := f.api.Get("/roles/some-role/explain", reqHeaders...)
+
+		// Then
+		assert.NotNil(t, resp)
+		assert.Equal(t, 500, resp.Result().StatusCode)
+	})
+}
+
 func TestApiListAllowedRoles(t *testing.T) {
 	newAccessBinding := func(roleName, friendlyName string) *api.AccessBinding {
 		return &api.AccessBinding{
@@ -0,0 +1,82 @@
+package backend
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
+)
+
+// csvHeader defines the columns of the access requests CSV export.
+var csvHeader = []string{
+	"name",
+	"namespace",
+	"application",
+	"project",
+	"role",
+	"username",
+	"status",
+	"createdAt",
+	"startsAt",
+	"expiresAt",
+	"duration",
+	"justification",
+	"ticketRef",
+	"approvals",
+}
+
+// writeAccessRequestsCSV writes the given access requests in CSV format to w.
+// Approval decisions are written in a single column in the
+// <username>:<decision> format separated by semicolons.
+func writeAccessRequestsCSV(w io.Writer, accessRequests []*api.AccessRequest) error {
+	writer := csv.NewWriter(w)
+	err := writer.Write(csvHeader)
+	if err != nil {
+		return fmt.Errorf("error writing csv header: %w", err)
+	}
+	for _, ar := range accessRequests {
+		err = writer.Write(toCSVRecord(ar))
+		if err != nil {
+			return fmt.Errorf("error writing csv record for access request %s: %w", ar.GetName(), err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("error flushing csv: %w", err)
+	}
+	return nil
+}
+
+func toCSVRecord(ar *api.AccessRequest) []string {
+	startsAt := ""
+	if ar.Spec.StartsAt != nil {
+		startsAt = ar.Spec.StartsAt.Format(time.RFC3339)
+	}
+	expiresAt := ""
+	if ar.Status.ExpiresAt != nil {
+		expiresAt = ar.Status.ExpiresAt.Format(time.RFC3339)
+	}
+	approvals := []string{}
+	for _, decision := range ar.Spec.Approvals {
+		approvals = append(approvals, fmt.Sprintf("%s:%s", decision.Username, decision.Decision))
+	}
+	return []string{
+		ar.GetName(),
+		ar.GetNamespace(),
+		fmt.Sprintf("%s:%s", ar.Spec.Application.Namespace, ar.Spec.Application.Name),
+		ar.Status.TargetProject,
+		ar.Spec.Role.TemplateRef.Name,
+		ar.Spec.Subject.Username,
+		string(ar.Status.RequestState),
+		ar.GetCreationTimestamp().Format(time.RFC3339),
+		startsAt,
+		expiresAt,
+		ar.Spec.Duration.Duration.String(),
+		ar.Spec.Justification,
+		ar.Spec.TicketRef,
+		strings.Join(approvals, ";"),
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sync"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -26,6 +27,9 @@ const (
 	accessRequestUsernameField     = "spec.subject.username"
 	accessRequestAppNameField      = "spec.application.name"
 	accessRequestAppNamespaceField = "spec.application.namespace"
+	accessRequestStatusField       = "status.requestState"
+	accessRequestRoleField         = "spec.role.templateRef.name"
+	accessRequestProjectField      = "status.targetProject"
 
 	accessBindingRoleField = "spec.roleTemplateRef.name"
 )
@@ -38,7 +42,28 @@ type Persister interface {
 	CreateAccessRequest(ctx context.Context, ar *api.AccessRequest) (*api.AccessRequest, error)
 	// ListAccessRequests returns all the AccessRequest matching the key criterias
 	ListAccessRequests(ctx context.Context, key *AccessRequestKey) (*api.AccessRequestList, error)
-
+	// ListSubjectAccessRequests returns the AccessRequests of the given username across all applications.
+	// If status is provided, only AccessRequests in that status are returned.
+	ListSubjectAccessRequests(ctx context.Context, namespace, username string, status api.Status) (*api.AccessRequestList, error)
+	// SearchAccessRequests returns all the AccessRequests in the given namespace matching the filter
+	// indexed fields. The filter time range isn't evaluated.
+	SearchAccessRequests(ctx context.Context, namespace string, filter *AccessRequestFilter) (*api.AccessRequestList, error)
+	// WatchSubjectAccessRequests returns a channel receiving the AccessRequests of the given username every
+	// time they are added or updated in the cache. All existing AccessRequests are sent when the watch starts.
+	// The watch is stopped and the returned channel is closed when the given context is done.
+	WatchSubjectAccessRequests(ctx context.Context, namespace, username string) (<-chan *api.AccessRequest, error)
+	// ListApplicationAccessRequests returns all the AccessRequests for the given application
+	ListApplicationAccessRequests(ctx context.Context, namespace, appName, appNamespace string) (*api.AccessRequestList, error)
+	// GetAccessRequest returns the AccessRequest with the given name and namespace
+	GetAccessRequest(ctx context.Context, name, namespace string) (*api.AccessRequest, error)
+	// UpdateAccessRequest updates the given AccessRequest and returns the updated object
+	UpdateAccessRequest(ctx context.Context, ar *api.AccessRequest) (*api.AccessRequest, error)
+
+	// GetRoleTemplate returns the RoleTemplate with the given name and namespace
+	GetRoleTemplate(ctx context.Context, name, namespace string) (*api.RoleTemplate, error)
+
+	// GetAccessBinding returns the AccessBinding with the given name and namespace
+	GetAccessBinding(ctx context.Context, name, namespace string) (*api.AccessBinding, error)
 	// ListAccessBindings returns all the AccessBindings matching the specified role and namespace
 	ListAccessBindings(ctx context.Context, roleName, namespace string) (*api.AccessBindingList, error)
 
@@ -132,6 +157,39 @@ func NewK8sPersister(config *rest.Config, logger log.Logger) (*K8sPersister, err
 		return nil, fmt.Errorf("error adding AccessRequest index for field %s: %w", accessRequestAppNameField, err)
 	}
 
+	err = cache.IndexField(context.Background(), &api.AccessRequest{}, accessRequestStatusField, func(obj client.Object) []string {
+		ar := obj.(*api.AccessRequest)
+		if ar.Status.RequestState == "" {
+			return nil
+		}
+		return []string{string(ar.Status.RequestState)}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error adding AccessRequest index for field %s: %w", accessRequestStatusField, err)
+	}
+
+	err = cache.IndexField(context.Background(), &api.AccessRequest{}, accessRequestRoleField, func(obj client.Object) []string {
+		ar := obj.(*api.AccessRequest)
+		if ar.Spec.Role.TemplateRef.Name == "" {
+			return nil
+		}
+		return []string{ar.Spec.Role.TemplateRef.Name}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error adding AccessRequest index for field %s: %w", accessRequestRoleField, err)
+	}
+
+	err = cache.IndexField(context.Background(), &api.AccessRequest{}, accessRequestProjectField, func(obj client.Object) []string {
+		ar := obj.(*api.AccessRequest)
+		if ar.Status.TargetProject == "" {
+			return nil
+		}
+		return []string{ar.Status.TargetProject}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error adding AccessRequest index for field %s: %w", accessRequestProjectField, err)
+	}
+
 	err = cache.IndexField(context.Background(), &api.AccessBinding{}, accessBindingRoleField, func(obj client.Object) []string {
 		b := obj.(*api.AccessBinding)
 		if b.Spec.RoleTemplateRef.Name == "" {
@@ -236,6 +294,174 @@ func (c *K8sPersister) ListAccessRequests(ctx context.Context, key *AccessReques
 	return list, nil
 }
 
+// ListSubjectAccessRequests lists the AccessRequests of the given user in all applications.
+func (c *K8sPersister) ListSubjectAccessRequests(ctx context.Context, namespace, username string, status api.Status) (*api.AccessRequestList, error) {
+	set := fields.Set{
+		accessRequestUsernameField: username,
+	}
+	if status != "" {
+		set[accessRequestStatusField] = string(status)
+	}
+
+	list := &api.AccessRequestList{}
+	err := c.client.List(ctx, list, &client.ListOptions{Namespace: namespace, FieldSelector: fields.SelectorFromSet(set)})
+	if err != nil {
+		return nil, fmt.Errorf("error listing access request for user %s from k8s: %w", username, err)
+	}
+	return list, nil
+}
+
+// SearchAccessRequests lists the AccessRequests matching the given filter using the cache indexes.
+func (c *K8sPersister) SearchAccessRequests(ctx context.Context, namespace string, filter *AccessRequestFilter) (*api.AccessRequestList, error) {
+	set := fields.Set{}
+	if filter != nil {
+		indexed := map[string]string{
+			accessRequestUsernameField:     filter.Username,
+			accessRequestAppNameField:      filter.ApplicationName,
+			accessRequestAppNamespaceField: filter.ApplicationNamespace,
+			accessRequestProjectField:      filter.Project,
+			accessRequestRoleField:         filter.Role,
+			accessRequestStatusField:       string(filter.Status),
+		}
+		for field, value := range indexed {
+			if value != "" {
+				set[field] = value
+			}
+		}
+	}
+
+	opts := &client.ListOptions{Namespace: namespace}
+	if len(set) > 0 {
+		opts.FieldSelector = fields.SelectorFromSet(set)
+	}
+	list := &api.AccessRequestList{}
+	err := c.client.List(ctx, list, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error searching access requests from k8s: %w", err)
+	}
+	return list, nil
+}
+
+// WatchSubjectAccessRequests registers an event handler in the AccessRequest informer started by
+// StartCache. The handler is removed and the returned channel is closed once the given context is done.
+func (c *K8sPersister) WatchSubjectAccessRequests(ctx context.Context, namespace, username string) (<-chan *api.AccessRequest, error) {
+	informer, err := c.cache.GetInformer(ctx, &api.AccessRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("error getting access request informer: %w", err)
+	}
+
+	events := make(chan *api.AccessRequest, 10)
+	// lock prevents the handler from sending to the events channel once it is closed
+	var lock sync.Mutex
+	closed := false
+	send := func(obj any) {
+		ar, ok := obj.(*api.AccessRequest)
+		if !ok || ar.GetNamespace() != namespace || ar.Spec.Subject.Username != username {
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		if closed {
+			return
+		}
+		select {
+		case events <- ar.DeepCopy():
+		case <-ctx.Done():
+		}
+	}
+	registration, err := informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: send,
+		UpdateFunc: func(_, newObj any) {
+			send(newObj)
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error adding access request event handler: %w", err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		err := informer.RemoveEventHandler(registration)
+		if err != nil {
+			c.logger.Error(err, "error removing access request event handler", "username", username)
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		closed = true
+		close(events)
+	}()
+	return events, nil
+}
+
+// ListApplicationAccessRequests lists the AccessRequests of all users for the given application.
+func (c *K8sPersister) ListApplicationAccessRequests(ctx context.Context, namespace, appName, appNamespace string) (*api.AccessRequestList, error) {
+	var selector = fields.SelectorFromSet(
+		fields.Set{
+			accessRequestAppNameField:      appName,
+			accessRequestAppNamespaceField: appNamespace,
+		},
+	)
+
+	list := &api.AccessRequestList{}
+	err := c.client.List(ctx, list, &client.ListOptions{Namespace: namespace, FieldSelector: selector})
+	if err != nil {
+		return nil, fmt.Errorf("error listing access request in app %s/%s from k8s: %w", appNamespace, appName, err)
+	}
+	return list, nil
+}
+
+func (c *K8sPersister) GetAccessRequest(ctx context.Context, name, namespace string) (*api.AccessRequest, error) {
+	ar := &api.AccessRequest{}
+	key := client.ObjectKey{
+		Namespace: namespace,
+		Name:      name,
+	}
+	err := c.client.Get(ctx, key, ar)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving access request %s/%s from k8s: %w", namespace, name, err)
+	}
+	return ar, nil
+}
+
+func (c *K8sPersister) UpdateAccessRequest(ctx context.Context, ar *api.AccessRequest) (*api.AccessRequest, error) {
+	obj := ar.DeepCopy()
+	err := c.client.Update(ctx, obj, &client.UpdateOptions{
+		FieldManager: managerName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error updating access request %s/%s: %w", ar.GetNamespace(), ar.GetName(), err)
+	}
+	return obj, nil
+}
+
+// GetRoleTemplate retrieves the RoleTemplate with the given name and namespace.
+func (c *K8sPersister) GetRoleTemplate(ctx context.Context, name, namespace string) (*api.RoleTemplate, error) {
+	rt := &api.RoleTemplate{}
+	key := client.ObjectKey{
+		Namespace: namespace,
+		Name:      name,
+	}
+	err := c.client.Get(ctx, key, rt)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving role template %s/%s from k8s: %w", namespace, name, err)
+	}
+	return rt, nil
+}
+
+// GetAccessBinding retrieves the AccessBinding with the given name and namespace.
+func (c *K8sPersister) GetAccessBinding(ctx context.Context, name, namespace string) (*api.AccessBinding, error) {
+	ab := &api.AccessBinding{}
+	key := client.ObjectKey{
+		Namespace: namespace,
+		Name:      name,
+	}
+	err := c.client.Get(ctx, key, ab)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving access binding %s/%s from k8s: %w", namespace, name, err)
+	}
+	return ab, nil
+}
+
 func (c *K8sPersister) ListAccessBindings(ctx context.Context, roleName, namespace string) (*api.AccessBindingList, error) {
 	var selector = fields.SelectorFromSet(
 		fields.Set{
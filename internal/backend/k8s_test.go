@@ -255,6 +255,77 @@ func TestK8sPersister(t *testing.T) {
 		assert.Equal(t, 0, len(result.Items))
 	})
 
+	t.Run("will get and update AccessRequest successfully", func(t *testing.T) {
+		// Given
+		nsName := "update-ar-success"
+		ns := utils.NewNamespace(nsName)
+		err = k8sClient.Create(ctx, ns)
+		require.NoError(t, err)
+
+		ar := utils.NewAccessRequestCreated()
+		ar.ObjectMeta.Namespace = nsName
+		err = k8sClient.Create(ctx, ar)
+		require.NoError(t, err)
+
+		// When
+		var current *api.AccessRequest
+		utils.Eventually(func() (bool, error) {
+			current, err = p.GetAccessRequest(ctx, ar.GetName(), nsName)
+			return current != nil, nil
+		}, 5*time.Second, time.Second)
+		require.NotNil(t, current)
+		current.Spec.Revoke = true
+		result, err := p.UpdateAccessRequest(ctx, current)
+
+		// Then
+		assert.NoError(t, err)
+		require.NotNil(t, result)
+		assert.True(t, result.Spec.Revoke)
+	})
+
+	t.Run("will watch the subject AccessRequests", func(t *testing.T) {
+		// Given
+		nsName := "watch-ar"
+		ns := utils.NewNamespace(nsName)
+		err = k8sClient.Create(ctx, ns)
+		require.NoError(t, err)
+
+		key := &backend.AccessRequestKey{
+			Namespace:            nsName,
+			ApplicationName:      "some-app",
+			ApplicationNamespace: "app-ns",
+			Username:             "watched-user",
+		}
+		other := newAccessRequest(key, "some-role")
+		other.SetName("other-user-ar")
+		other.Spec.Subject.Username = "other-user"
+		ar := newAccessRequest(key, "some-role")
+		watchCtx, watchCancel := context.WithCancel(ctx)
+		defer watchCancel()
+
+		// When
+		events, err := p.WatchSubjectAccessRequests(watchCtx, nsName, key.Username)
+		require.NoError(t, err)
+		err = k8sClient.Create(ctx, other)
+		require.NoError(t, err)
+		err = k8sClient.Create(ctx, ar)
+		require.NoError(t, err)
+
+		// Then
+		select {
+		case received := <-events:
+			assert.Equal(t, ar.GetName(), received.GetName())
+			assert.Equal(t, key.Username, received.Spec.Subject.Username)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for AccessRequest event")
+		}
+		watchCancel()
+		assert.Eventually(t, func() bool {
+			_, ok := <-events
+			return !ok
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
 	t.Run("will list AccessBindings successfully", func(t *testing.T) {
 		// Given
 		nsName := "list-ab-success"
@@ -43,3 +43,22 @@ func TestMetricsMiddleware(t *testing.T) {
 	assert.Equal(t, 1, testutil.CollectAndCount(apiRequestsTotal, "api_requests_total"))
 	assert.True(t, testutil.CollectAndCount(apiRequestDuration, "api_request_duration_milliseconds") > 0)
 }
+
+func TestMetricsMiddlewareFlush(t *testing.T) {
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		require.True(t, ok)
+		_, err := w.Write([]byte("data: some-event\n\n"))
+		require.NoError(t, err)
+		flusher.Flush()
+	})
+	testHandler := MetricsMiddleware(nextHandler)
+
+	req := httptest.NewRequest("GET", "/user/accessrequests/events", nil)
+	rec := httptest.NewRecorder()
+
+	testHandler.ServeHTTP(rec, req)
+
+	assert.True(t, rec.Flushed)
+	assert.Equal(t, "data: some-event\n\n", rec.Body.String())
+}
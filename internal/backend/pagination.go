@@ -0,0 +1,184 @@
+package backend
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultPageLimit is the number of items returned in a page when the
+	// limit isn't provided.
+	DefaultPageLimit = 50
+)
+
+// ListOptions defines the filter and pagination options used when listing
+// AccessRequests.
+type ListOptions struct {
+	// Status will only include AccessRequests in the given status if provided.
+	Status api.Status
+	// Limit is the maximum number of items returned in the page. If zero,
+	// DefaultPageLimit is used.
+	Limit int
+	// Continue is the token returned in the previous page. If empty, the
+	// first page is returned.
+	Continue string
+}
+
+// AccessRequestFilter defines the criteria used to search AccessRequests.
+// Empty fields are ignored.
+type AccessRequestFilter struct {
+	// Username will only include AccessRequests created by the given user.
+	Username string
+	// ApplicationName will only include AccessRequests for applications with
+	// the given name.
+	ApplicationName string
+	// ApplicationNamespace will only include AccessRequests for applications
+	// in the given namespace.
+	ApplicationNamespace string
+	// Project will only include AccessRequests targeting the given project.
+	Project string
+	// Role will only include AccessRequests for the given role template.
+	Role string
+	// Status will only include AccessRequests in the given status.
+	Status api.Status
+	// CreatedAfter will only include AccessRequests created at or after the
+	// given time.
+	CreatedAfter *time.Time
+	// CreatedBefore will only include AccessRequests created before the given
+	// time.
+	CreatedBefore *time.Time
+}
+
+// matchesTimeRange returns true if the given AccessRequest was created within
+// the filter time range.
+func (f *AccessRequestFilter) matchesTimeRange(ar *api.AccessRequest) bool {
+	created := ar.GetCreationTimestamp().Time
+	if f.CreatedAfter != nil && created.Before(*f.CreatedAfter) {
+		return false
+	}
+	if f.CreatedBefore != nil && !created.Before(*f.CreatedBefore) {
+		return false
+	}
+	return true
+}
+
+// matches returns true if the given AccessRequest matches all the filter
+// criteria. Used when the AccessRequests can't be selected by the indexed
+// fields (e.g. when searching the archive).
+func (f *AccessRequestFilter) matches(ar *api.AccessRequest) bool {
+	criteria := [][2]string{
+		{f.Username, ar.Spec.Subject.Username},
+		{f.ApplicationName, ar.Spec.Application.Name},
+		{f.ApplicationNamespace, ar.Spec.Application.Namespace},
+		{f.Project, ar.Status.TargetProject},
+		{f.Role, ar.Spec.Role.TemplateRef.Name},
+		{string(f.Status), string(ar.Status.RequestState)},
+	}
+	for _, c := range criteria {
+		if c[0] != "" && c[0] != c[1] {
+			return false
+		}
+	}
+	return f.matchesTimeRange(ar)
+}
+
+// AccessRequestPage defines a page of AccessRequests.
+type AccessRequestPage struct {
+	// Items is the list of AccessRequests in the page.
+	Items []*api.AccessRequest
+	// Continue is the token to be used to retrieve the next page. Empty if
+	// this is the last page.
+	Continue string
+}
+
+// paginate will return the page of the given items defined by the given opts.
+// The items must be sorted with auditAccessRequestSort so pages are built on
+// immutable fields. The continue token references the last item of the
+// previous page so the next page resumes right after it, even if items were
+// created, deleted or updated in between. A ValidationError is returned if the
+// continue token is invalid.
+func paginate(items []*api.AccessRequest, opts *ListOptions) (*AccessRequestPage, error) {
+	if opts == nil {
+		opts = &ListOptions{}
+	}
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultPageLimit
+	}
+	cursor, err := decodeContinueToken(opts.Continue)
+	if err != nil {
+		return nil, NewValidationError(fmt.Sprintf("invalid continue token: %s", err))
+	}
+
+	start := 0
+	if cursor != nil {
+		last := cursor.accessRequest()
+		start = slices.IndexFunc(items, func(ar *api.AccessRequest) bool {
+			return auditAccessRequestSort(ar, last) > 0
+		})
+		if start < 0 {
+			start = len(items)
+		}
+	}
+	end := min(start+limit, len(items))
+	page := &AccessRequestPage{Items: items[start:end]}
+	if end < len(items) {
+		page.Continue = encodeContinueToken(items[end-1])
+	}
+	return page, nil
+}
+
+// continueToken is the cursor referencing the last item of a page. It holds
+// the immutable fields used to sort the paginated AccessRequests so the
+// position of the item can be found even if it no longer exists.
+type continueToken struct {
+	CreationTimestamp time.Time `json:"creationTimestamp"`
+	Namespace         string    `json:"namespace"`
+	Name              string    `json:"name"`
+}
+
+// accessRequest returns an AccessRequest with the sort fields of the token
+// so it can be compared with the listed items.
+func (t *continueToken) accessRequest() *api.AccessRequest {
+	ar := &api.AccessRequest{}
+	ar.SetCreationTimestamp(metav1.NewTime(t.CreationTimestamp))
+	ar.SetNamespace(t.Namespace)
+	ar.SetName(t.Name)
+	return ar
+}
+
+// encodeContinueToken returns an opaque token referencing the given item.
+func encodeContinueToken(ar *api.AccessRequest) string {
+	token := continueToken{
+		CreationTimestamp: ar.GetCreationTimestamp().Time,
+		Namespace:         ar.GetNamespace(),
+		Name:              ar.GetName(),
+	}
+	// marshaling a struct with only basic types can't fail
+	data, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeContinueToken returns the cursor represented by the given token.
+// Returns nil if the token is empty.
+func decodeContinueToken(token string) (*continueToken, error) {
+	if token == "" {
+		return nil, nil
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding token: %w", err)
+	}
+	cursor := &continueToken{}
+	err = json.Unmarshal(decoded, cursor)
+	if err != nil || cursor.Name == "" {
+		return nil, fmt.Errorf("malformed token")
+	}
+	return cursor, nil
+}
@@ -1,28 +1,76 @@
 package backend
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
 	"slices"
 	"strings"
 	"time"
 
 	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
+	"github.com/argoproj-labs/argocd-ephemeral-access/internal/archive"
 	"github.com/argoproj-labs/argocd-ephemeral-access/internal/backend/generator"
 	"github.com/argoproj-labs/argocd-ephemeral-access/pkg/log"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/retry"
 )
 
 // Service defines the operations provided by the backend. Backend business
 // logic should be added in implementations of this interface.
 type Service interface {
 	// CreateAccessRequest will create an AccessRequest for the given key requesting the role specified by the AccessBinding.
-	CreateAccessRequest(ctx context.Context, key *AccessRequestKey, binding *api.AccessBinding) (*api.AccessRequest, error)
+	// The given opts are validated against the AccessBinding constraints and a ValidationError is returned if they aren't met.
+	CreateAccessRequest(ctx context.Context, key *AccessRequestKey, binding *api.AccessBinding, opts *AccessRequestOptions) (*api.AccessRequest, error)
 	// GetAccessRequestByRole will retrieve the access request for the specified role.
 	// Will return a nil value without any error if an access request isn't found for this role.
 	GetAccessRequestByRole(ctx context.Context, key *AccessRequestKey, roleName string) (*api.AccessRequest, error)
+	// GetAccessRequest will retrieve the access request with the given name and namespace.
+	// Will return a nil value without any error if the access request isn't found.
+	GetAccessRequest(ctx context.Context, name, namespace string) (*api.AccessRequest, error)
+	// GetRenderedRoleTemplate will retrieve the role template associated with the given access request rendered
+	// with the given project and the access request application. Will return a nil value without any error if the
+	// role template isn't found.
+	GetRenderedRoleTemplate(ctx context.Context, ar *api.AccessRequest, projName string) (*api.RoleTemplate, error)
+	// RevokeAccessRequest will signal the controller that the access granted by the given
+	// access request must be removed before the expiration time.
+	RevokeAccessRequest(ctx context.Context, ar *api.AccessRequest) (*api.AccessRequest, error)
+	// CancelAccessRequest will signal the controller that the given access request that wasn't
+	// granted yet is no longer needed. The access request is no longer returned by
+	// GetAccessRequestByRole so a new one can be created immediately.
+	CancelAccessRequest(ctx context.Context, ar *api.AccessRequest) (*api.AccessRequest, error)
+	// ExtendAccessRequest will request the controller to extend the access granted by the given
+	// access request by the given duration. A ValidationError is returned if the extension isn't
+	// allowed by the AccessBinding used to create the access request.
+	ExtendAccessRequest(ctx context.Context, ar *api.AccessRequest, duration time.Duration) (*api.AccessRequest, error)
+	// ListSubjectAccessRequests will list the access requests of the given username across all applications
+	// in the given namespace. The result is paginated on the creation order according to the given opts and the
+	// items of each page are sorted by importance.
+	// A ValidationError is returned if the opts continue token is invalid.
+	ListSubjectAccessRequests(ctx context.Context, namespace, username string, opts *ListOptions) (*AccessRequestPage, error)
+	// SearchAccessRequests will list all access requests in the given namespace matching the given filter. The
+	// result is sorted by creation date with newer requests first and paginated according to the given opts. The
+	// opts status is ignored in favor of the filter status. A ValidationError is returned if the opts continue
+	// token is invalid. If opts is nil, all matching access requests are returned in a single page.
+	SearchAccessRequests(ctx context.Context, namespace string, filter *AccessRequestFilter, opts *ListOptions) (*AccessRequestPage, error)
+	// SearchArchivedAccessRequests will list the access requests archived by the controller before being deleted
+	// that match the given filter. The result is sorted and paginated the same way as SearchAccessRequests.
+	// ErrArchiveNotConfigured is returned if the service has no archive configured.
+	SearchArchivedAccessRequests(ctx context.Context, namespace string, filter *AccessRequestFilter, opts *ListOptions) (*AccessRequestPage, error)
+	// WatchSubjectAccessRequests will return a channel receiving the access requests of the given username every
+	// time their status changes. The current state of all access requests is sent when the watch starts. The
+	// returned channel is closed once the given context is done.
+	WatchSubjectAccessRequests(ctx context.Context, namespace, username string) (<-chan *api.AccessRequest, error)
+	// ListPendingApprovals will list the access requests for the application defined in the key that are waiting
+	// for a decision from the key user. Only access requests listing at least one of the given groups as approver
+	// are returned. Access requests created by the key user are never returned.
+	ListPendingApprovals(ctx context.Context, key *AccessRequestKey, groups []string) ([]*api.AccessRequest, error)
+	// AddApprovalDecision will append the given approver decision to the access request. The controller is
+	// responsible for evaluating the decisions and updating the access request status.
+	AddApprovalDecision(ctx context.Context, ar *api.AccessRequest, decision *api.ApprovalDecision) (*api.AccessRequest, error)
 	// ListAccessRequests will list non-expired access requests and optionally sort them by importance.
 	// The importance sort is based on status, role ordinal, name and creation date.
 	ListAccessRequests(ctx context.Context, key *AccessRequestKey, sort bool) ([]*api.AccessRequest, error)
@@ -32,6 +80,12 @@ type Service interface {
 	// If no bindings are granting access, nil is returned.
 	GetGrantingAccessBinding(ctx context.Context, roleName string, namespace string, groups []string, app *unstructured.Unstructured, project *unstructured.Unstructured) (*api.AccessBinding, error)
 
+	// ExplainAccessBindings will evaluate all AccessBindings referencing the specified role without creating
+	// any access request. The result describes, for each binding, the condition result, the rendered subjects
+	// and the groups matching them. The binding that would be used by GetGrantingAccessBinding is flagged as
+	// granting. AccessBindings are searched in the specified namespace and in the controller namespace.
+	ExplainAccessBindings(ctx context.Context, roleName string, namespace string, groups []string, app *unstructured.Unstructured, project *unstructured.Unstructured) ([]*AccessBindingExplanation, error)
+
 	// GetAccessBindingsForGroups will retrieve the list of AccessBindings allowed by at least one of the given groups.
 	// The list will be ordered by the AccessBinding.Ordinal field in descending order. This means that AccessBindings
 	// associated with roles with lesser privileges will come first.
@@ -53,12 +107,98 @@ type AccessRequestKey struct {
 	UserId               string
 }
 
+// AccessRequestOptions defines the optional values provided by users when
+// creating AccessRequests.
+type AccessRequestOptions struct {
+	// Duration is the requested access duration. If zero, the duration
+	// will be defined based on the AccessBinding and the service defaults.
+	Duration time.Duration
+	// Justification is the reason provided by the user for requesting
+	// the access.
+	Justification string
+	// TicketRef is the ticket identifier associated with the request.
+	TicketRef string
+	// StartsAt is the time the access should begin. If nil, the access
+	// is granted as soon as it is allowed.
+	StartsAt *time.Time
+	// BreakGlass requests emergency access granted without approvals.
+	BreakGlass bool
+}
+
+// ConditionResult defines the outcome of an AccessBinding If condition
+// evaluation.
+type ConditionResult string
+
+const (
+	// ConditionNotDefined is used when the AccessBinding has no If condition.
+	ConditionNotDefined ConditionResult = "none"
+	// ConditionTrue is used when the If condition evaluated to true.
+	ConditionTrue ConditionResult = "true"
+	// ConditionFalse is used when the If condition evaluated to false.
+	ConditionFalse ConditionResult = "false"
+	// ConditionError is used when the If condition could not be evaluated.
+	ConditionError ConditionResult = "error"
+)
+
+// AccessBindingExplanation describes how an AccessBinding was evaluated
+// for a given user.
+type AccessBindingExplanation struct {
+	// Binding is the evaluated AccessBinding.
+	Binding *api.AccessBinding
+	// Condition is the result of the If condition evaluation.
+	Condition ConditionResult
+	// ConditionError is the error returned when evaluating the If condition.
+	ConditionError string
+	// Subjects are the rendered subjects. Subjects are rendered even if the
+	// If condition is false to help debugging the templates.
+	Subjects []string
+	// SubjectsError is the error returned when rendering the subjects.
+	SubjectsError string
+	// MatchedGroups are the user groups matching the rendered subjects.
+	MatchedGroups []string
+	// Granting is true if this is the AccessBinding allowing the user to
+	// request the role.
+	Granting bool
+}
+
+// ValidationError is returned by the Service when the values provided by
+// users don't satisfy the constraints required by the operation.
+type ValidationError struct {
+	message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.message
+}
+
+func NewValidationError(msg string) *ValidationError {
+	return &ValidationError{
+		message: msg,
+	}
+}
+
 // DefaultService is the real Service implementation.
 type DefaultService struct {
 	k8s                   Persister
 	logger                log.Logger
 	namespace             string
 	accessRequestDuration time.Duration
+	archive               archive.Reader
+}
+
+// ErrArchiveNotConfigured is returned when searching archived access requests
+// without an archive configured.
+var ErrArchiveNotConfigured = errors.New("access request archive is not configured")
+
+// ServiceOption defines an optional configuration of the DefaultService.
+type ServiceOption func(*DefaultService)
+
+// WithArchive configures the archive used to search the access requests
+// deleted by the controller.
+func WithArchive(reader archive.Reader) ServiceOption {
+	return func(s *DefaultService) {
+		s.archive = reader
+	}
 }
 
 // requestStateOrder returns a map with AccessRequest.Status as the key
@@ -69,11 +209,14 @@ func requestStateOrder() map[api.Status]int {
 		"":                  0,
 		api.InitiatedStatus: 0,
 		api.RequestedStatus: 0,
+		api.ScheduledStatus: 0,
 		api.GrantedStatus:   1,
 		api.DeniedStatus:    2,
 		api.TimeoutStatus:   2,
 		api.InvalidStatus:   3,
 		api.ExpiredStatus:   4,
+		api.RevokedStatus:   4,
+		api.CancelledStatus: 4,
 	}
 }
 
@@ -85,13 +228,17 @@ const (
 )
 
 // NewDefaultService will return a new DefaultService instance.
-func NewDefaultService(c Persister, l log.Logger, namespace string, arDuration time.Duration) *DefaultService {
-	return &DefaultService{
+func NewDefaultService(c Persister, l log.Logger, namespace string, arDuration time.Duration, opts ...ServiceOption) *DefaultService {
+	s := &DefaultService{
 		k8s:                   c,
 		logger:                l,
 		namespace:             namespace,
 		accessRequestDuration: arDuration,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // GetAccessRequestByRole will find the AccessRequest based on the given key and roleName.
@@ -109,7 +256,7 @@ func (s *DefaultService) GetAccessRequestByRole(ctx context.Context, key *Access
 
 	// find the first access request matching the requested role
 	for _, ar := range accessRequests {
-		if ar.Spec.Role.TemplateRef.Name == roleName && !ar.IsConcluded() {
+		if ar.Spec.Role.TemplateRef.Name == roleName && !ar.IsConcluded() && !isCancelling(ar) {
 			return ar, nil
 		}
 	}
@@ -177,6 +324,57 @@ func (s *DefaultService) GetGrantingAccessBinding(ctx context.Context, roleName
 	return grantingBinding, nil
 }
 
+// ExplainAccessBindings will evaluate every AccessBinding for the given role
+// reporting why the user is or isn't allowed to request it.
+func (s *DefaultService) ExplainAccessBindings(ctx context.Context, roleName string, namespace string, groups []string, app *unstructured.Unstructured, project *unstructured.Unstructured) ([]*AccessBindingExplanation, error) {
+	s.logger.Debug("Explaining AccessBindings", "namespace", namespace, "roleName", roleName, "groups", strings.Join(groups, ","))
+	bindings, err := s.listAccessBindings(ctx, roleName, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving access bindings for role %s: %w", roleName, err)
+	}
+
+	result := []*AccessBindingExplanation{}
+	granted := false
+	for i := range bindings {
+		binding := &bindings[i]
+		explanation := &AccessBindingExplanation{
+			Binding:   binding,
+			Condition: ConditionNotDefined,
+		}
+		if binding.Spec.If != nil {
+			allowed, err := binding.EvaluateCondition(app, project)
+			switch {
+			case err != nil:
+				explanation.Condition = ConditionError
+				explanation.ConditionError = err.Error()
+			case allowed:
+				explanation.Condition = ConditionTrue
+			default:
+				explanation.Condition = ConditionFalse
+			}
+		}
+
+		subjects, err := binding.RenderSubjectTemplates(app, project)
+		if err != nil {
+			explanation.SubjectsError = err.Error()
+		}
+		explanation.Subjects = subjects
+		for _, subject := range subjects {
+			if slices.Contains(groups, subject) {
+				explanation.MatchedGroups = append(explanation.MatchedGroups, subject)
+			}
+		}
+
+		conditionPassed := explanation.Condition == ConditionNotDefined || explanation.Condition == ConditionTrue
+		if !granted && conditionPassed && explanation.SubjectsError == "" && len(explanation.MatchedGroups) > 0 {
+			explanation.Granting = true
+			granted = true
+		}
+		result = append(result, explanation)
+	}
+	return result, nil
+}
+
 // GetAccessBindingsForGroups will retrieve the list of AccessBindings allowed by at least one of the given groups.
 // The list will be ordered by the AccessBinding.Ordinal field in descending order. This means that AccessBindings
 // associated with roles with lesser privileges will come first.
@@ -225,13 +423,49 @@ func (s *DefaultService) matchSubject(subjects, groups []string) bool {
 	return false
 }
 
-func (s *DefaultService) CreateAccessRequest(ctx context.Context, key *AccessRequestKey, binding *api.AccessBinding) (*api.AccessRequest, error) {
+func (s *DefaultService) CreateAccessRequest(ctx context.Context, key *AccessRequestKey, binding *api.AccessBinding, opts *AccessRequestOptions) (*api.AccessRequest, error) {
 	logKeys := []interface{}{
 		"namespace", key.Namespace, "app", key.ApplicationName, "username", key.Username, "appNamespace", key.ApplicationNamespace,
 		"accessBinding", binding.GetName(),
 	}
 	s.logger.Debug(fmt.Sprintf("Creating AccessRequest"), logKeys...)
+	if opts == nil {
+		opts = &AccessRequestOptions{}
+	}
+	duration, err := binding.ResolveDuration(opts.Duration, s.accessRequestDuration)
+	if err != nil {
+		// a binding with an invalid default is misconfigured and can't be
+		// fixed by the user
+		if errors.Is(err, api.ErrInvalidDefaultDuration) {
+			return nil, fmt.Errorf("error resolving duration of AccessBinding %s/%s: %w", binding.GetNamespace(), binding.GetName(), err)
+		}
+		return nil, NewValidationError(fmt.Sprintf("invalid duration: %s", err))
+	}
+	err = binding.ValidateJustification(opts.Justification, opts.TicketRef)
+	if err != nil {
+		return nil, NewValidationError(fmt.Sprintf("invalid justification: %s", err))
+	}
+	if opts.BreakGlass {
+		duration, err = validateBreakGlass(binding, opts, duration)
+		if err != nil {
+			return nil, NewValidationError(fmt.Sprintf("invalid break-glass request: %s", err))
+		}
+	}
+	var startsAt *metav1.Time
+	if opts.StartsAt != nil {
+		if !opts.StartsAt.After(time.Now()) {
+			return nil, NewValidationError("invalid start time: must be in the future")
+		}
+		t := metav1.NewTime(*opts.StartsAt)
+		startsAt = &t
+	}
 	roleName := binding.Spec.RoleTemplateRef.Name
+	var annotations map[string]string
+	approval := binding.Spec.Approval.DeepCopy()
+	if opts.BreakGlass {
+		annotations = map[string]string{api.BreakGlassAnnotation: "true"}
+		approval = nil
+	}
 	ar := &api.AccessRequest{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "AccessRequest",
@@ -240,10 +474,11 @@ func (s *DefaultService) CreateAccessRequest(ctx context.Context, key *AccessReq
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace:    key.Namespace,
 			GenerateName: getAccessRequestPrefix(key.Username, roleName),
+			Annotations:  annotations,
 		},
 		Spec: api.AccessRequestSpec{
 			Duration: metav1.Duration{
-				Duration: s.accessRequestDuration,
+				Duration: duration,
 			},
 			Role: api.TargetRole{
 				TemplateRef: api.TargetRoleTemplate{
@@ -261,15 +496,344 @@ func (s *DefaultService) CreateAccessRequest(ctx context.Context, key *AccessReq
 				Username: key.Username,
 				UserId:   &key.UserId,
 			},
+			AccessBindingRef: &api.AccessBindingReference{
+				Name:      binding.GetName(),
+				Namespace: binding.GetNamespace(),
+			},
+			Justification: opts.Justification,
+			TicketRef:     opts.TicketRef,
+			StartsAt:      startsAt,
+			BreakGlass:    opts.BreakGlass,
+			Approval:      approval,
 		},
 	}
-	ar, err := s.k8s.CreateAccessRequest(ctx, ar)
+	ar, err = s.k8s.CreateAccessRequest(ctx, ar)
 	if err != nil {
 		return nil, fmt.Errorf("error creating access request from k8s: %w", err)
 	}
 	return ar, nil
 }
 
+// validateBreakGlass returns an error if the break-glass access can't be
+// requested with the given options. Returns the duration of the break-glass
+// access. If the duration wasn't requested, the resolved duration is capped
+// to the break-glass maximum duration.
+func validateBreakGlass(binding *api.AccessBinding, opts *AccessRequestOptions, duration time.Duration) (time.Duration, error) {
+	if opts.Duration == 0 && binding.Spec.BreakGlass != nil {
+		duration = min(duration, binding.Spec.BreakGlass.MaxDuration.Duration)
+	}
+	err := binding.ValidateBreakGlass(duration)
+	if err != nil {
+		return 0, err
+	}
+	if strings.TrimSpace(opts.Justification) == "" {
+		return 0, fmt.Errorf("justification is required")
+	}
+	if opts.StartsAt != nil {
+		return 0, fmt.Errorf("break-glass access can't be scheduled")
+	}
+	return duration, nil
+}
+
+// GetAccessRequest will retrieve the AccessRequest with the given name and namespace.
+// Returns nil without error if the AccessRequest is not found.
+func (s *DefaultService) GetAccessRequest(ctx context.Context, name, namespace string) (*api.AccessRequest, error) {
+	s.logger.Debug(fmt.Sprintf("Getting AccessRequest %s/%s", namespace, name))
+	ar, err := s.k8s.GetAccessRequest(ctx, name, namespace)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return ar, nil
+}
+
+// GetRenderedRoleTemplate will retrieve and render the RoleTemplate referenced
+// by the given AccessRequest. Returns nil without error if the RoleTemplate is
+// not found.
+func (s *DefaultService) GetRenderedRoleTemplate(ctx context.Context, ar *api.AccessRequest, projName string) (*api.RoleTemplate, error) {
+	ref := ar.Spec.Role.TemplateRef
+	s.logger.Debug(fmt.Sprintf("Getting RoleTemplate %s/%s", ref.Namespace, ref.Name))
+	rt, err := s.k8s.GetRoleTemplate(ctx, ref.Name, ref.Namespace)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	rendered, err := rt.Render(projName, ar.Spec.Application.Name, ar.Spec.Application.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("error rendering role template %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+	return rendered, nil
+}
+
+// RevokeAccessRequest will flag the given AccessRequest to be revoked. The
+// controller is responsible for removing the access and updating the status.
+func (s *DefaultService) RevokeAccessRequest(ctx context.Context, ar *api.AccessRequest) (*api.AccessRequest, error) {
+	s.logger.Debug(fmt.Sprintf("Revoking AccessRequest %s/%s", ar.GetNamespace(), ar.GetName()), "username", ar.Spec.Subject.Username)
+	result, err := s.updateAccessRequest(ctx, ar, func(current *api.AccessRequest) {
+		current.Spec.Revoke = true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error revoking access request: %w", err)
+	}
+	return result, nil
+}
+
+// CancelAccessRequest will flag the given AccessRequest to be cancelled. The
+// controller is responsible for invoking the plugin and concluding it.
+func (s *DefaultService) CancelAccessRequest(ctx context.Context, ar *api.AccessRequest) (*api.AccessRequest, error) {
+	s.logger.Debug(fmt.Sprintf("Cancelling AccessRequest %s/%s", ar.GetNamespace(), ar.GetName()), "username", ar.Spec.Subject.Username)
+	result, err := s.updateAccessRequest(ctx, ar, func(current *api.AccessRequest) {
+		current.Spec.Cancel = true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error cancelling access request: %w", err)
+	}
+	return result, nil
+}
+
+// isCancelling returns true if the given AccessRequest was cancelled by the
+// subject and is waiting for the controller to conclude it. Requests granted
+// before the cancellation was processed are still considered active.
+func isCancelling(ar *api.AccessRequest) bool {
+	return ar.Spec.Cancel && ar.Status.RequestState != api.GrantedStatus
+}
+
+// ExtendAccessRequest will append a new extension with the given duration to
+// the AccessRequest spec after validating it against the limits defined in the
+// referenced AccessBinding. The controller is responsible for invoking the
+// plugin and moving the expiration time forward.
+func (s *DefaultService) ExtendAccessRequest(ctx context.Context, ar *api.AccessRequest, duration time.Duration) (*api.AccessRequest, error) {
+	s.logger.Debug(fmt.Sprintf("Extending AccessRequest %s/%s", ar.GetNamespace(), ar.GetName()), "username", ar.Spec.Subject.Username, "duration", duration.String())
+	ref := ar.Spec.AccessBindingRef
+	if ref == nil {
+		return nil, NewValidationError("access request was not created from an AccessBinding and cannot be extended")
+	}
+	if ar.Spec.BreakGlass {
+		return nil, NewValidationError("break-glass access cannot be extended")
+	}
+	binding, err := s.k8s.GetAccessBinding(ctx, ref.Name, ref.Namespace)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, NewValidationError(fmt.Sprintf("AccessBinding %s/%s not found", ref.Namespace, ref.Name))
+		}
+		return nil, fmt.Errorf("error getting access binding: %w", err)
+	}
+	err = binding.ValidateExtension(ar, duration)
+	if err != nil {
+		return nil, NewValidationError(fmt.Sprintf("invalid extension: %s", err))
+	}
+
+	result, err := s.updateAccessRequest(ctx, ar, func(current *api.AccessRequest) {
+		current.Spec.Extensions = append(current.Spec.Extensions, api.AccessExtension{
+			Duration: metav1.Duration{Duration: duration},
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error extending access request: %w", err)
+	}
+	return result, nil
+}
+
+// ListSubjectAccessRequests will list and paginate the access requests of the
+// given username in all applications.
+func (s *DefaultService) ListSubjectAccessRequests(ctx context.Context, namespace, username string, opts *ListOptions) (*AccessRequestPage, error) {
+	if opts == nil {
+		opts = &ListOptions{}
+	}
+	s.logger.Debug("Listing subject AccessRequests", "namespace", namespace, "username", username, "status", opts.Status)
+	accessRequests, err := s.k8s.ListSubjectAccessRequests(ctx, namespace, username, opts.Status)
+	if err != nil {
+		return nil, fmt.Errorf("error listing subject access requests from k8s: %w", err)
+	}
+
+	result := []*api.AccessRequest{}
+	for _, ar := range accessRequests.Items {
+		result = append(result, &ar)
+	}
+	// pages are built on the creation order as the status of the requests
+	// can change between page fetches. Only the items of the page are sorted
+	// by importance.
+	slices.SortFunc(result, auditAccessRequestSort)
+	page, err := paginate(result, opts)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(page.Items, subjectAccessRequestSort)
+	return page, nil
+}
+
+// SearchAccessRequests will search the access requests matching the given
+// filter. Used by admins and auditors to review all access requests.
+func (s *DefaultService) SearchAccessRequests(ctx context.Context, namespace string, filter *AccessRequestFilter, opts *ListOptions) (*AccessRequestPage, error) {
+	if filter == nil {
+		filter = &AccessRequestFilter{}
+	}
+	s.logger.Debug("Searching AccessRequests", "namespace", namespace, "filter", filter)
+	accessRequests, err := s.k8s.SearchAccessRequests(ctx, namespace, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error searching access requests from k8s: %w", err)
+	}
+
+	result := []*api.AccessRequest{}
+	for _, ar := range accessRequests.Items {
+		if filter.matchesTimeRange(&ar) {
+			result = append(result, &ar)
+		}
+	}
+	slices.SortFunc(result, auditAccessRequestSort)
+	if opts == nil {
+		return &AccessRequestPage{Items: result}, nil
+	}
+	return paginate(result, opts)
+}
+
+// SearchArchivedAccessRequests will search the archived access requests
+// matching the given filter. The archive isn't indexed so all access requests
+// in the namespace are read and filtered in memory.
+func (s *DefaultService) SearchArchivedAccessRequests(ctx context.Context, namespace string, filter *AccessRequestFilter, opts *ListOptions) (*AccessRequestPage, error) {
+	if s.archive == nil {
+		return nil, ErrArchiveNotConfigured
+	}
+	if filter == nil {
+		filter = &AccessRequestFilter{}
+	}
+	s.logger.Debug("Searching archived AccessRequests", "namespace", namespace, "filter", filter)
+	archived, err := s.archive.List(ctx, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("error listing archived access requests: %w", err)
+	}
+
+	result := []*api.AccessRequest{}
+	for _, ar := range archived {
+		if filter.matches(ar) {
+			result = append(result, ar)
+		}
+	}
+	slices.SortFunc(result, auditAccessRequestSort)
+	if opts == nil {
+		return &AccessRequestPage{Items: result}, nil
+	}
+	return paginate(result, opts)
+}
+
+// WatchSubjectAccessRequests will filter the access request events received
+// from k8s to only notify about status transitions.
+func (s *DefaultService) WatchSubjectAccessRequests(ctx context.Context, namespace, username string) (<-chan *api.AccessRequest, error) {
+	events, err := s.k8s.WatchSubjectAccessRequests(ctx, namespace, username)
+	if err != nil {
+		return nil, fmt.Errorf("error watching access requests from k8s: %w", err)
+	}
+
+	transitions := make(chan *api.AccessRequest)
+	go func() {
+		defer close(transitions)
+		lastStatus := map[string]api.Status{}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ar, ok := <-events:
+				if !ok {
+					return
+				}
+				status, found := lastStatus[ar.GetName()]
+				if found && status == ar.Status.RequestState {
+					continue
+				}
+				lastStatus[ar.GetName()] = ar.Status.RequestState
+				select {
+				case transitions <- ar:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return transitions, nil
+}
+
+// ListPendingApprovals will list the access requests that can be approved by
+// the key user.
+func (s *DefaultService) ListPendingApprovals(ctx context.Context, key *AccessRequestKey, groups []string) ([]*api.AccessRequest, error) {
+	logKeys := []interface{}{
+		"namespace", key.Namespace, "app", key.ApplicationName, "username", key.Username, "appNamespace", key.ApplicationNamespace,
+	}
+	s.logger.Debug("Listing pending approvals", logKeys...)
+	accessRequests, err := s.k8s.ListApplicationAccessRequests(ctx, key.Namespace, key.ApplicationName, key.ApplicationNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("error listing application access requests from k8s: %w", err)
+	}
+
+	result := []*api.AccessRequest{}
+	for _, ar := range accessRequests.Items {
+		if !isPendingApproval(&ar, key.Username, groups) {
+			continue
+		}
+		result = append(result, &ar)
+	}
+	slices.SortStableFunc(result, defaultAccessRequestSort)
+	return result, nil
+}
+
+// isPendingApproval returns true if the given ar is waiting for a decision
+// from the given username.
+func isPendingApproval(ar *api.AccessRequest, username string, groups []string) bool {
+	return ar.RequiresApproval() &&
+		!ar.IsConcluded() &&
+		ar.Status.RequestState != api.GrantedStatus &&
+		ar.Spec.Subject.Username != username &&
+		ar.GetApprovalDecision(username) == nil &&
+		ar.Spec.Approval.IsApprover(groups)
+}
+
+// AddApprovalDecision will append the given decision in the AccessRequest
+// approvals list.
+func (s *DefaultService) AddApprovalDecision(ctx context.Context, ar *api.AccessRequest, decision *api.ApprovalDecision) (*api.AccessRequest, error) {
+	s.logger.Debug(fmt.Sprintf("Adding approval decision to AccessRequest %s/%s", ar.GetNamespace(), ar.GetName()), "approver", decision.Username, "decision", decision.Decision)
+	result, err := s.updateAccessRequest(ctx, ar, func(current *api.AccessRequest) {
+		current.Spec.Approvals = append(current.Spec.Approvals, *decision)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error adding approval decision: %w", err)
+	}
+	return result, nil
+}
+
+// updateAccessRequest will apply the given mutate function in a copy of the
+// given AccessRequest and update it. The update is retried with the latest
+// AccessRequest state in case of conflicts.
+func (s *DefaultService) updateAccessRequest(ctx context.Context, ar *api.AccessRequest, mutate func(*api.AccessRequest)) (*api.AccessRequest, error) {
+	var result *api.AccessRequest
+	current := ar.DeepCopy()
+	firstAttempt := true
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if !firstAttempt {
+			// re-fetch the AccessRequest to make sure the latest state is used
+			latest, err := s.k8s.GetAccessRequest(ctx, ar.GetName(), ar.GetNamespace())
+			if err != nil {
+				return err
+			}
+			current = latest
+		}
+		firstAttempt = false
+		mutate(current)
+		updated, err := s.k8s.UpdateAccessRequest(ctx, current)
+		if err != nil {
+			return err
+		}
+		result = updated
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func getAccessRequestPrefix(username, roleName string) string {
 	// If username is an email, we don't care about the email domain
 	username, _, _ = strings.Cut(username, "@")
@@ -407,3 +971,24 @@ func defaultAccessRequestSort(a, b *api.AccessRequest) int {
 	}
 	return 0
 }
+
+// subjectAccessRequestSort sorts access requests with defaultAccessRequestSort
+// and then by namespace and name so the order is the same across calls.
+func subjectAccessRequestSort(a, b *api.AccessRequest) int {
+	return cmp.Or(
+		defaultAccessRequestSort(a, b),
+		strings.Compare(a.GetNamespace(), b.GetNamespace()),
+		strings.Compare(a.GetName(), b.GetName()),
+	)
+}
+
+// auditAccessRequestSort sorts access requests by creation date with newer
+// requests first. Requests created at the same time are sorted by namespace
+// and name.
+func auditAccessRequestSort(a, b *api.AccessRequest) int {
+	return cmp.Or(
+		b.GetCreationTimestamp().Compare(a.GetCreationTimestamp().Time),
+		strings.Compare(a.GetNamespace(), b.GetNamespace()),
+		strings.Compare(a.GetName(), b.GetName()),
+	)
+}
@@ -10,6 +10,7 @@ import (
 
 	argocd "github.com/argoproj-labs/argocd-ephemeral-access/api/argoproj/v1alpha1"
 	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
+	"github.com/argoproj-labs/argocd-ephemeral-access/internal/archive"
 	"github.com/argoproj-labs/argocd-ephemeral-access/internal/backend"
 	"github.com/argoproj-labs/argocd-ephemeral-access/test/mocks"
 	"github.com/argoproj-labs/argocd-ephemeral-access/test/testdata"
@@ -22,6 +23,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/yaml"
 )
 
@@ -87,7 +90,7 @@ func TestServiceCreateAccessRequest(t *testing.T) {
 			})
 
 		// When
-		result, err := f.svc.CreateAccessRequest(context.Background(), key, ab)
+		result, err := f.svc.CreateAccessRequest(context.Background(), key, ab, nil)
 
 		// Then
 		assert.NoError(t, err)
@@ -102,21 +105,1030 @@ func TestServiceCreateAccessRequest(t *testing.T) {
 		assert.Equal(t, ab.Spec.Ordinal, result.Spec.Role.Ordinal)
 		assert.Equal(t, ab.Spec.RoleTemplateRef.Name, result.Spec.Role.TemplateRef.Name)
 		assert.Equal(t, AccessRequestDuration, result.Spec.Duration.Duration)
+		assert.Equal(t, ab.GetName(), result.Spec.AccessBindingRef.Name)
+		assert.Equal(t, ab.GetNamespace(), result.Spec.AccessBindingRef.Namespace)
+	})
+	t.Run("will create access request with the requested duration", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		key := &backend.AccessRequestKey{
+			Namespace:            "some-namespace",
+			ApplicationName:      "some-app",
+			ApplicationNamespace: "app-ns",
+			Username:             "some-user",
+		}
+		ab := newDefaultAccessBinding()
+		ab.Spec.Duration = &api.DurationPolicy{
+			Max: &metav1.Duration{Duration: time.Hour},
+		}
+		f.persister.EXPECT().CreateAccessRequest(mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, ar *api.AccessRequest) (*api.AccessRequest, error) {
+				return ar, nil
+			})
+		opts := &backend.AccessRequestOptions{Duration: 30 * time.Minute}
+
+		// When
+		result, err := f.svc.CreateAccessRequest(context.Background(), key, ab, opts)
+
+		// Then
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Equal(t, 30*time.Minute, result.Spec.Duration.Duration)
+	})
+	t.Run("will use the access binding default duration", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		key := &backend.AccessRequestKey{
+			Namespace:            "some-namespace",
+			ApplicationName:      "some-app",
+			ApplicationNamespace: "app-ns",
+			Username:             "some-user",
+		}
+		ab := newDefaultAccessBinding()
+		ab.Spec.Duration = &api.DurationPolicy{
+			Default: &metav1.Duration{Duration: 2 * time.Hour},
+		}
+		f.persister.EXPECT().CreateAccessRequest(mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, ar *api.AccessRequest) (*api.AccessRequest, error) {
+				return ar, nil
+			})
+
+		// When
+		result, err := f.svc.CreateAccessRequest(context.Background(), key, ab, &backend.AccessRequestOptions{})
+
+		// Then
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Equal(t, 2*time.Hour, result.Spec.Duration.Duration)
+	})
+	t.Run("will return validation error if duration is out of range", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		key := &backend.AccessRequestKey{
+			Namespace:            "some-namespace",
+			ApplicationName:      "some-app",
+			ApplicationNamespace: "app-ns",
+			Username:             "some-user",
+		}
+		ab := newDefaultAccessBinding()
+		ab.Spec.Duration = &api.DurationPolicy{
+			Max: &metav1.Duration{Duration: time.Hour},
+		}
+		opts := &backend.AccessRequestOptions{Duration: 2 * time.Hour}
+
+		// When
+		result, err := f.svc.CreateAccessRequest(context.Background(), key, ab, opts)
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		var validationErr *backend.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Contains(t, err.Error(), "longer than the maximum allowed")
+	})
+	t.Run("will return internal error if the access binding default duration is invalid", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		key := &backend.AccessRequestKey{
+			Namespace:            "some-namespace",
+			ApplicationName:      "some-app",
+			ApplicationNamespace: "app-ns",
+			Username:             "some-user",
+		}
+		ab := newDefaultAccessBinding()
+		ab.Spec.Duration = &api.DurationPolicy{
+			Default: &metav1.Duration{Duration: 2 * time.Hour},
+			Max:     &metav1.Duration{Duration: time.Hour},
+		}
+
+		// When
+		result, err := f.svc.CreateAccessRequest(context.Background(), key, ab, &backend.AccessRequestOptions{})
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		var validationErr *backend.ValidationError
+		assert.NotErrorAs(t, err, &validationErr, "binding misconfiguration must not be reported as a user error")
+		assert.ErrorIs(t, err, api.ErrInvalidDefaultDuration)
+	})
+	t.Run("will create access request with justification and ticket reference", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		key := &backend.AccessRequestKey{
+			Namespace:            "some-namespace",
+			ApplicationName:      "some-app",
+			ApplicationNamespace: "app-ns",
+			Username:             "some-user",
+		}
+		ab := newDefaultAccessBinding()
+		ab.Spec.Justification = &api.JustificationPolicy{
+			Required:          true,
+			TicketRefRequired: true,
+			TicketRefPattern:  `OPS-\d+`,
+		}
+		f.persister.EXPECT().CreateAccessRequest(mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, ar *api.AccessRequest) (*api.AccessRequest, error) {
+				return ar, nil
+			})
+		opts := &backend.AccessRequestOptions{
+			Justification: "some reason",
+			TicketRef:     "OPS-123",
+		}
+
+		// When
+		result, err := f.svc.CreateAccessRequest(context.Background(), key, ab, opts)
+
+		// Then
+		assert.NoError(t, err)
+		require.NotNil(t, result)
+		assert.Equal(t, "some reason", result.Spec.Justification)
+		assert.Equal(t, "OPS-123", result.Spec.TicketRef)
+	})
+	t.Run("will copy the approval policy from the access binding", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		key := &backend.AccessRequestKey{
+			Namespace:            "some-namespace",
+			ApplicationName:      "some-app",
+			ApplicationNamespace: "app-ns",
+			Username:             "some-user",
+		}
+		ab := newDefaultAccessBinding()
+		ab.Spec.Approval = &api.ApprovalPolicy{
+			Approvers:         []string{"approvers"},
+			RequiredApprovals: 2,
+		}
+		f.persister.EXPECT().CreateAccessRequest(mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, ar *api.AccessRequest) (*api.AccessRequest, error) {
+				return ar, nil
+			})
+
+		// When
+		result, err := f.svc.CreateAccessRequest(context.Background(), key, ab, nil)
+
+		// Then
+		assert.NoError(t, err)
+		require.NotNil(t, result)
+		assert.Equal(t, ab.Spec.Approval, result.Spec.Approval)
+		assert.NotSame(t, ab.Spec.Approval, result.Spec.Approval)
+	})
+	t.Run("will create access request with the requested start time", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		key := &backend.AccessRequestKey{
+			Namespace:            "some-namespace",
+			ApplicationName:      "some-app",
+			ApplicationNamespace: "app-ns",
+			Username:             "some-user",
+		}
+		ab := newDefaultAccessBinding()
+		f.persister.EXPECT().CreateAccessRequest(mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, ar *api.AccessRequest) (*api.AccessRequest, error) {
+				return ar, nil
+			})
+		startsAt := time.Now().Add(2 * time.Hour).Truncate(time.Second)
+		opts := &backend.AccessRequestOptions{StartsAt: &startsAt}
+
+		// When
+		result, err := f.svc.CreateAccessRequest(context.Background(), key, ab, opts)
+
+		// Then
+		assert.NoError(t, err)
+		require.NotNil(t, result)
+		require.NotNil(t, result.Spec.StartsAt)
+		assert.True(t, startsAt.Equal(result.Spec.StartsAt.Time))
+	})
+	t.Run("will return validation error if start time is in the past", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		key := &backend.AccessRequestKey{
+			Namespace:            "some-namespace",
+			ApplicationName:      "some-app",
+			ApplicationNamespace: "app-ns",
+			Username:             "some-user",
+		}
+		ab := newDefaultAccessBinding()
+		startsAt := time.Now().Add(-time.Minute)
+		opts := &backend.AccessRequestOptions{StartsAt: &startsAt}
+
+		// When
+		result, err := f.svc.CreateAccessRequest(context.Background(), key, ab, opts)
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		var validationErr *backend.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Contains(t, err.Error(), "invalid start time")
+	})
+	t.Run("will create break-glass access request without approval", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		key := &backend.AccessRequestKey{
+			Namespace:            "some-namespace",
+			ApplicationName:      "some-app",
+			ApplicationNamespace: "app-ns",
+			Username:             "some-user",
+		}
+		ab := newDefaultAccessBinding()
+		ab.Spec.Approval = &api.ApprovalPolicy{
+			Approvers:         []string{"approvers"},
+			RequiredApprovals: 1,
+		}
+		ab.Spec.Duration = &api.DurationPolicy{Default: &metav1.Duration{Duration: 4 * time.Hour}}
+		ab.Spec.BreakGlass = &api.BreakGlassPolicy{MaxDuration: metav1.Duration{Duration: time.Hour}}
+		f.persister.EXPECT().CreateAccessRequest(mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, ar *api.AccessRequest) (*api.AccessRequest, error) {
+				return ar, nil
+			})
+		opts := &backend.AccessRequestOptions{BreakGlass: true, Justification: "production is down"}
+
+		// When
+		result, err := f.svc.CreateAccessRequest(context.Background(), key, ab, opts)
+
+		// Then
+		assert.NoError(t, err)
+		require.NotNil(t, result)
+		assert.True(t, result.Spec.BreakGlass)
+		assert.Nil(t, result.Spec.Approval, "break-glass access must not require approval")
+		assert.Equal(t, "true", result.GetAnnotations()[api.BreakGlassAnnotation])
+		assert.Equal(t, time.Hour, result.Spec.Duration.Duration, "default duration must be capped to the break-glass maximum")
+	})
+	t.Run("will return validation error if break-glass request is invalid", func(t *testing.T) {
+		policy := &api.BreakGlassPolicy{MaxDuration: metav1.Duration{Duration: time.Hour}}
+		startsAt := time.Now().Add(time.Hour)
+		tests := []struct {
+			name          string
+			policy        *api.BreakGlassPolicy
+			opts          *backend.AccessRequestOptions
+			errorContains string
+		}{
+			{
+				name:          "binding doesn't allow break-glass",
+				opts:          &backend.AccessRequestOptions{BreakGlass: true, Justification: "incident"},
+				errorContains: "break-glass access is not allowed",
+			},
+			{
+				name:          "duration exceeds the maximum",
+				policy:        policy,
+				opts:          &backend.AccessRequestOptions{BreakGlass: true, Justification: "incident", Duration: 2 * time.Hour},
+				errorContains: "longer than the maximum allowed for break-glass access",
+			},
+			{
+				name:          "justification is missing",
+				policy:        policy,
+				opts:          &backend.AccessRequestOptions{BreakGlass: true},
+				errorContains: "justification is required",
+			},
+			{
+				name:          "start time is provided",
+				policy:        policy,
+				opts:          &backend.AccessRequestOptions{BreakGlass: true, Justification: "incident", StartsAt: &startsAt},
+				errorContains: "break-glass access can't be scheduled",
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// Given
+				f := serviceSetup(t)
+				key := &backend.AccessRequestKey{
+					Namespace:            "some-namespace",
+					ApplicationName:      "some-app",
+					ApplicationNamespace: "app-ns",
+					Username:             "some-user",
+				}
+				ab := newDefaultAccessBinding()
+				ab.Spec.BreakGlass = tt.policy
+
+				// When
+				result, err := f.svc.CreateAccessRequest(context.Background(), key, ab, tt.opts)
+
+				// Then
+				assert.Nil(t, result)
+				var validationErr *backend.ValidationError
+				assert.ErrorAs(t, err, &validationErr)
+				assert.ErrorContains(t, err, "invalid break-glass request")
+				assert.ErrorContains(t, err, tt.errorContains)
+			})
+		}
+	})
+	t.Run("will return validation error if required justification is missing", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		key := &backend.AccessRequestKey{
+			Namespace:            "some-namespace",
+			ApplicationName:      "some-app",
+			ApplicationNamespace: "app-ns",
+			Username:             "some-user",
+		}
+		ab := newDefaultAccessBinding()
+		ab.Spec.Justification = &api.JustificationPolicy{Required: true}
+
+		// When
+		result, err := f.svc.CreateAccessRequest(context.Background(), key, ab, &backend.AccessRequestOptions{})
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		var validationErr *backend.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Contains(t, err.Error(), "justification is required")
+	})
+	t.Run("will return error if k8s request fails", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		key := &backend.AccessRequestKey{
+			Namespace:            "some-namespace",
+			ApplicationName:      "some-app",
+			ApplicationNamespace: "app-ns",
+			Username:             "some-user",
+		}
+		ab := newDefaultAccessBinding()
+		f.persister.EXPECT().CreateAccessRequest(mock.Anything, mock.Anything).Return(nil, fmt.Errorf("some internal error"))
+
+		// When
+		result, err := f.svc.CreateAccessRequest(context.Background(), key, ab, nil)
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "some internal error")
+	})
+}
+
+func TestServiceRevokeAccessRequest(t *testing.T) {
+	t.Run("will flag the access request to be revoked", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		ar := utils.NewAccessRequestGranted()
+		f.persister.EXPECT().UpdateAccessRequest(mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, ar *api.AccessRequest) (*api.AccessRequest, error) {
+				return ar, nil
+			})
+
+		// When
+		result, err := f.svc.RevokeAccessRequest(context.Background(), ar)
+
+		// Then
+		assert.NoError(t, err)
+		require.NotNil(t, result)
+		assert.True(t, result.Spec.Revoke)
+		assert.False(t, ar.Spec.Revoke, "given access request must not be modified")
+	})
+	t.Run("will retry with the latest state on conflict", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		ar := utils.NewAccessRequestGranted()
+		conflictErr := errors.NewConflict(schema.GroupResource{}, ar.GetName(), fmt.Errorf("conflict"))
+		f.persister.EXPECT().UpdateAccessRequest(mock.Anything, mock.Anything).
+			Return(nil, fmt.Errorf("update error: %w", conflictErr)).Once()
+		f.persister.EXPECT().GetAccessRequest(mock.Anything, ar.GetName(), ar.GetNamespace()).Return(ar.DeepCopy(), nil).Once()
+		f.persister.EXPECT().UpdateAccessRequest(mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, ar *api.AccessRequest) (*api.AccessRequest, error) {
+				return ar, nil
+			}).Once()
+
+		// When
+		result, err := f.svc.RevokeAccessRequest(context.Background(), ar)
+
+		// Then
+		assert.NoError(t, err)
+		require.NotNil(t, result)
+		assert.True(t, result.Spec.Revoke)
+	})
+	t.Run("will return error if update fails", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		ar := utils.NewAccessRequestGranted()
+		f.persister.EXPECT().UpdateAccessRequest(mock.Anything, mock.Anything).Return(nil, fmt.Errorf("some internal error"))
+
+		// When
+		result, err := f.svc.RevokeAccessRequest(context.Background(), ar)
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "some internal error")
+	})
+}
+
+func TestServiceCancelAccessRequest(t *testing.T) {
+	t.Run("will flag the access request to be cancelled", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		ar := utils.NewAccessRequestRequested()
+		f.persister.EXPECT().UpdateAccessRequest(mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, ar *api.AccessRequest) (*api.AccessRequest, error) {
+				return ar, nil
+			})
+
+		// When
+		result, err := f.svc.CancelAccessRequest(context.Background(), ar)
+
+		// Then
+		assert.NoError(t, err)
+		require.NotNil(t, result)
+		assert.True(t, result.Spec.Cancel)
+		assert.False(t, ar.Spec.Cancel, "given access request must not be modified")
+	})
+	t.Run("will return error if update fails", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		ar := utils.NewAccessRequestRequested()
+		f.persister.EXPECT().UpdateAccessRequest(mock.Anything, mock.Anything).Return(nil, fmt.Errorf("some internal error"))
+
+		// When
+		result, err := f.svc.CancelAccessRequest(context.Background(), ar)
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "some internal error")
+	})
+}
+
+func TestServiceExtendAccessRequest(t *testing.T) {
+	newExtensibleAccess := func() (*api.AccessRequest, *api.AccessBinding) {
+		ab := newDefaultAccessBinding()
+		ab.Spec.Extension = &api.ExtensionPolicy{
+			MaxDuration:      metav1.Duration{Duration: time.Hour},
+			MaxTotalDuration: metav1.Duration{Duration: 3 * time.Hour},
+		}
+		ar := utils.NewAccessRequestGranted()
+		ar.Spec.Duration = metav1.Duration{Duration: time.Hour}
+		ar.Spec.AccessBindingRef = &api.AccessBindingReference{
+			Name:      ab.GetName(),
+			Namespace: ab.GetNamespace(),
+		}
+		return ar, ab
+	}
+	t.Run("will append the extension to the access request", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		ar, ab := newExtensibleAccess()
+		f.persister.EXPECT().GetAccessBinding(mock.Anything, ab.GetName(), ab.GetNamespace()).Return(ab, nil)
+		f.persister.EXPECT().UpdateAccessRequest(mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, ar *api.AccessRequest) (*api.AccessRequest, error) {
+				return ar, nil
+			})
+
+		// When
+		result, err := f.svc.ExtendAccessRequest(context.Background(), ar, 30*time.Minute)
+
+		// Then
+		assert.NoError(t, err)
+		require.NotNil(t, result)
+		require.Len(t, result.Spec.Extensions, 1)
+		assert.Equal(t, 30*time.Minute, result.Spec.Extensions[0].Duration.Duration)
+		assert.Empty(t, ar.Spec.Extensions, "given access request must not be modified")
+	})
+	t.Run("will return validation error if access request has no binding reference", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		ar, _ := newExtensibleAccess()
+		ar.Spec.AccessBindingRef = nil
+
+		// When
+		result, err := f.svc.ExtendAccessRequest(context.Background(), ar, 30*time.Minute)
+
+		// Then
+		var validationErr *backend.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Nil(t, result)
+	})
+	t.Run("will return validation error if access request is break-glass", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		ar, _ := newExtensibleAccess()
+		ar.Spec.BreakGlass = true
+
+		// When
+		result, err := f.svc.ExtendAccessRequest(context.Background(), ar, 30*time.Minute)
+
+		// Then
+		var validationErr *backend.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Contains(t, err.Error(), "break-glass access cannot be extended")
+		assert.Nil(t, result)
+	})
+	t.Run("will return validation error if binding does not allow extensions", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		ar, ab := newExtensibleAccess()
+		ab.Spec.Extension = nil
+		f.persister.EXPECT().GetAccessBinding(mock.Anything, ab.GetName(), ab.GetNamespace()).Return(ab, nil)
+
+		// When
+		result, err := f.svc.ExtendAccessRequest(context.Background(), ar, 30*time.Minute)
+
+		// Then
+		var validationErr *backend.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Contains(t, err.Error(), "extensions are not allowed")
+		assert.Nil(t, result)
+	})
+	t.Run("will return validation error if total duration exceeds the maximum", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		ar, ab := newExtensibleAccess()
+		ar.Spec.Extensions = []api.AccessExtension{
+			{Duration: metav1.Duration{Duration: time.Hour}},
+			{Duration: metav1.Duration{Duration: time.Hour}},
+		}
+		ar.Status.Extensions = []api.AccessExtensionStatus{
+			{Duration: metav1.Duration{Duration: time.Hour}, RequestState: api.GrantedStatus},
+			{Duration: metav1.Duration{Duration: time.Hour}, RequestState: api.GrantedStatus},
+		}
+		f.persister.EXPECT().GetAccessBinding(mock.Anything, ab.GetName(), ab.GetNamespace()).Return(ab, nil)
+
+		// When
+		result, err := f.svc.ExtendAccessRequest(context.Background(), ar, 30*time.Minute)
+
+		// Then
+		var validationErr *backend.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Contains(t, err.Error(), "would exceed the maximum allowed")
+		assert.Nil(t, result)
+	})
+	t.Run("will return error if binding retrieval fails", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		ar, ab := newExtensibleAccess()
+		f.persister.EXPECT().GetAccessBinding(mock.Anything, ab.GetName(), ab.GetNamespace()).Return(nil, fmt.Errorf("some internal error"))
+
+		// When
+		result, err := f.svc.ExtendAccessRequest(context.Background(), ar, 30*time.Minute)
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "some internal error")
+	})
+}
+
+func TestServiceListPendingApprovals(t *testing.T) {
+	key := &backend.AccessRequestKey{
+		Namespace:            "some-namespace",
+		ApplicationName:      "some-app",
+		ApplicationNamespace: "app-ns",
+		Username:             "some-approver",
+	}
+	newApprovalRequest := func(name string, mutations ...utils.AccessRequestMutation) api.AccessRequest {
+		ar := utils.NewAccessRequestRequested(append(mutations, utils.WithName(name))...)
+		ar.Spec.Approval = &api.ApprovalPolicy{Approvers: []string{"approvers"}}
+		return *ar
+	}
+	t.Run("will only return access requests waiting for the user decision", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		pending := newApprovalRequest("pending")
+		noApproval := newApprovalRequest("no-approval")
+		noApproval.Spec.Approval = nil
+		otherGroup := newApprovalRequest("other-group")
+		otherGroup.Spec.Approval.Approvers = []string{"other-approvers"}
+		ownRequest := newApprovalRequest("own-request")
+		ownRequest.Spec.Subject.Username = key.Username
+		decided := newApprovalRequest("decided")
+		decided.Spec.Approvals = []api.ApprovalDecision{{Username: key.Username, Decision: api.ApprovedDecision}}
+		granted := newApprovalRequest("granted", utils.ToGrantedState())
+		denied := newApprovalRequest("denied", utils.ToDeniedState())
+		list := &api.AccessRequestList{
+			Items: []api.AccessRequest{pending, noApproval, otherGroup, ownRequest, decided, granted, denied},
+		}
+		f.persister.EXPECT().ListApplicationAccessRequests(mock.Anything, key.Namespace, key.ApplicationName, key.ApplicationNamespace).Return(list, nil)
+
+		// When
+		result, err := f.svc.ListPendingApprovals(context.Background(), key, []string{"group1", "approvers"})
+
+		// Then
+		assert.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, "pending", result[0].GetName())
+	})
+	t.Run("will return error if k8s request fails", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		f.persister.EXPECT().ListApplicationAccessRequests(mock.Anything, key.Namespace, key.ApplicationName, key.ApplicationNamespace).
+			Return(nil, fmt.Errorf("some internal error"))
+
+		// When
+		result, err := f.svc.ListPendingApprovals(context.Background(), key, []string{"approvers"})
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "some internal error")
+	})
+}
+
+func TestServiceListSubjectAccessRequests(t *testing.T) {
+	newList := func(names ...string) *api.AccessRequestList {
+		list := &api.AccessRequestList{}
+		for _, name := range names {
+			list.Items = append(list.Items, *utils.NewAccessRequestGranted(utils.WithName(name)))
+		}
+		return list
+	}
+	t.Run("will paginate the user access requests", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		f.persister.EXPECT().ListSubjectAccessRequests(mock.Anything, "some-namespace", "some-user", api.GrantedStatus).
+			Return(newList("ar-1", "ar-2", "ar-3"), nil)
+		opts := &backend.ListOptions{Status: api.GrantedStatus, Limit: 2}
+
+		// When
+		first, err := f.svc.ListSubjectAccessRequests(context.Background(), "some-namespace", "some-user", opts)
+		require.NoError(t, err)
+		opts.Continue = first.Continue
+		second, err := f.svc.ListSubjectAccessRequests(context.Background(), "some-namespace", "some-user", opts)
+		require.NoError(t, err)
+
+		// Then
+		require.Len(t, first.Items, 2)
+		assert.NotEmpty(t, first.Continue)
+		require.Len(t, second.Items, 1)
+		assert.Empty(t, second.Continue)
+		names := []string{first.Items[0].GetName(), first.Items[1].GetName(), second.Items[0].GetName()}
+		assert.ElementsMatch(t, []string{"ar-1", "ar-2", "ar-3"}, names)
+	})
+	t.Run("will not skip access requests changing state between pages", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		newAccessRequest := func(name string, created time.Time, status api.Status) api.AccessRequest {
+			ar := utils.NewAccessRequestGranted(utils.WithName(name))
+			ar.SetCreationTimestamp(metav1.NewTime(created))
+			ar.Status.RequestState = status
+			return *ar
+		}
+		now := time.Now()
+		firstList := &api.AccessRequestList{
+			Items: []api.AccessRequest{
+				newAccessRequest("ar-1", now, api.ExpiredStatus),
+				newAccessRequest("ar-2", now.Add(-time.Hour), api.GrantedStatus),
+				newAccessRequest("ar-3", now.Add(-2*time.Hour), api.RequestedStatus),
+			},
+		}
+		secondList := &api.AccessRequestList{
+			Items: []api.AccessRequest{
+				newAccessRequest("ar-1", now, api.ExpiredStatus),
+				newAccessRequest("ar-2", now.Add(-time.Hour), api.GrantedStatus),
+				newAccessRequest("ar-3", now.Add(-2*time.Hour), api.GrantedStatus),
+			},
+		}
+		f.persister.EXPECT().ListSubjectAccessRequests(mock.Anything, "some-namespace", "some-user", api.Status("")).
+			Return(firstList, nil).Once()
+		f.persister.EXPECT().ListSubjectAccessRequests(mock.Anything, "some-namespace", "some-user", api.Status("")).
+			Return(secondList, nil).Once()
+		opts := &backend.ListOptions{Limit: 2}
+
+		// When
+		first, err := f.svc.ListSubjectAccessRequests(context.Background(), "some-namespace", "some-user", opts)
+		require.NoError(t, err)
+		opts.Continue = first.Continue
+		second, err := f.svc.ListSubjectAccessRequests(context.Background(), "some-namespace", "some-user", opts)
+		require.NoError(t, err)
+
+		// Then
+		require.Len(t, first.Items, 2)
+		assert.Equal(t, "ar-2", first.Items[0].GetName(), "page items must be sorted by importance")
+		assert.Equal(t, "ar-1", first.Items[1].GetName())
+		require.Len(t, second.Items, 1)
+		assert.Equal(t, "ar-3", second.Items[0].GetName())
+		assert.Empty(t, second.Continue)
+	})
+	t.Run("will return all access requests when no options are provided", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		f.persister.EXPECT().ListSubjectAccessRequests(mock.Anything, "some-namespace", "some-user", api.Status("")).
+			Return(newList("ar-1", "ar-2"), nil)
+
+		// When
+		result, err := f.svc.ListSubjectAccessRequests(context.Background(), "some-namespace", "some-user", nil)
+
+		// Then
+		assert.NoError(t, err)
+		assert.Len(t, result.Items, 2)
+		assert.Empty(t, result.Continue)
+	})
+	t.Run("will return validation error on invalid continue token", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		f.persister.EXPECT().ListSubjectAccessRequests(mock.Anything, "some-namespace", "some-user", api.Status("")).
+			Return(newList("ar-1"), nil)
+		opts := &backend.ListOptions{Continue: "not-a-token"}
+
+		// When
+		result, err := f.svc.ListSubjectAccessRequests(context.Background(), "some-namespace", "some-user", opts)
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		var validationErr *backend.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
 	})
 	t.Run("will return error if k8s request fails", func(t *testing.T) {
 		// Given
 		f := serviceSetup(t)
-		key := &backend.AccessRequestKey{
-			Namespace:            "some-namespace",
-			ApplicationName:      "some-app",
-			ApplicationNamespace: "app-ns",
-			Username:             "some-user",
+		f.persister.EXPECT().ListSubjectAccessRequests(mock.Anything, "some-namespace", "some-user", api.Status("")).
+			Return(nil, fmt.Errorf("some internal error"))
+
+		// When
+		result, err := f.svc.ListSubjectAccessRequests(context.Background(), "some-namespace", "some-user", nil)
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "some internal error")
+	})
+}
+
+func TestServiceSearchAccessRequests(t *testing.T) {
+	newAccessRequest := func(name string, created time.Time) api.AccessRequest {
+		ar := utils.NewAccessRequestGranted(utils.WithName(name))
+		ar.SetCreationTimestamp(metav1.NewTime(created))
+		return *ar
+	}
+	now := time.Now().Truncate(time.Second)
+	t.Run("will return access requests in the time range sorted by creation date", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		list := &api.AccessRequestList{
+			Items: []api.AccessRequest{
+				newAccessRequest("too-old", now.Add(-3*time.Hour)),
+				newAccessRequest("older", now.Add(-2*time.Hour)),
+				newAccessRequest("newer", now.Add(-1*time.Hour)),
+				newAccessRequest("too-new", now),
+			},
 		}
-		ab := newDefaultAccessBinding()
-		f.persister.EXPECT().CreateAccessRequest(mock.Anything, mock.Anything).Return(nil, fmt.Errorf("some internal error"))
+		after := now.Add(-2 * time.Hour)
+		before := now
+		filter := &backend.AccessRequestFilter{Role: "some-role", CreatedAfter: &after, CreatedBefore: &before}
+		f.persister.EXPECT().SearchAccessRequests(mock.Anything, "some-namespace", filter).Return(list, nil)
+
+		// When
+		result, err := f.svc.SearchAccessRequests(context.Background(), "some-namespace", filter, nil)
+
+		// Then
+		assert.NoError(t, err)
+		require.Len(t, result.Items, 2)
+		assert.Equal(t, "newer", result.Items[0].GetName())
+		assert.Equal(t, "older", result.Items[1].GetName())
+		assert.Empty(t, result.Continue)
+	})
+	t.Run("will paginate the result", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		list := &api.AccessRequestList{
+			Items: []api.AccessRequest{
+				newAccessRequest("ar-b", now),
+				newAccessRequest("ar-a", now),
+				newAccessRequest("ar-c", now.Add(-time.Hour)),
+			},
+		}
+		f.persister.EXPECT().SearchAccessRequests(mock.Anything, "some-namespace", mock.Anything).Return(list, nil)
+		opts := &backend.ListOptions{Limit: 2}
+
+		// When
+		first, err := f.svc.SearchAccessRequests(context.Background(), "some-namespace", nil, opts)
+		require.NoError(t, err)
+		opts.Continue = first.Continue
+		second, err := f.svc.SearchAccessRequests(context.Background(), "some-namespace", nil, opts)
+		require.NoError(t, err)
+
+		// Then
+		require.Len(t, first.Items, 2)
+		assert.Equal(t, "ar-a", first.Items[0].GetName())
+		assert.Equal(t, "ar-b", first.Items[1].GetName())
+		require.Len(t, second.Items, 1)
+		assert.Equal(t, "ar-c", second.Items[0].GetName())
+		assert.Empty(t, second.Continue)
+	})
+	t.Run("will resume after the last item when access requests change between pages", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		firstList := &api.AccessRequestList{
+			Items: []api.AccessRequest{
+				newAccessRequest("ar-a", now),
+				newAccessRequest("ar-b", now.Add(-time.Hour)),
+				newAccessRequest("ar-c", now.Add(-2*time.Hour)),
+			},
+		}
+		secondList := &api.AccessRequestList{
+			Items: []api.AccessRequest{
+				newAccessRequest("ar-newer", now.Add(2*time.Hour)),
+				newAccessRequest("ar-new", now.Add(time.Hour)),
+				newAccessRequest("ar-a", now),
+				newAccessRequest("ar-c", now.Add(-2*time.Hour)),
+			},
+		}
+		f.persister.EXPECT().SearchAccessRequests(mock.Anything, "some-namespace", mock.Anything).Return(firstList, nil).Once()
+		f.persister.EXPECT().SearchAccessRequests(mock.Anything, "some-namespace", mock.Anything).Return(secondList, nil).Once()
+		opts := &backend.ListOptions{Limit: 2}
+
+		// When
+		first, err := f.svc.SearchAccessRequests(context.Background(), "some-namespace", nil, opts)
+		require.NoError(t, err)
+		opts.Continue = first.Continue
+		second, err := f.svc.SearchAccessRequests(context.Background(), "some-namespace", nil, opts)
+		require.NoError(t, err)
+
+		// Then
+		require.Len(t, first.Items, 2)
+		assert.Equal(t, "ar-a", first.Items[0].GetName())
+		assert.Equal(t, "ar-b", first.Items[1].GetName())
+		require.Len(t, second.Items, 1)
+		assert.Equal(t, "ar-c", second.Items[0].GetName())
+		assert.Empty(t, second.Continue)
+	})
+	t.Run("will return error if k8s request fails", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		f.persister.EXPECT().SearchAccessRequests(mock.Anything, "some-namespace", mock.Anything).
+			Return(nil, fmt.Errorf("some internal error"))
+
+		// When
+		result, err := f.svc.SearchAccessRequests(context.Background(), "some-namespace", nil, nil)
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "some internal error")
+	})
+}
+
+func TestServiceSearchArchivedAccessRequests(t *testing.T) {
+	newArchive := func(t *testing.T, ars ...*api.AccessRequest) archive.Archive {
+		t.Helper()
+		a, err := archive.NewFileArchive(t.TempDir())
+		require.NoError(t, err)
+		for _, ar := range ars {
+			require.NoError(t, a.Archive(context.Background(), ar))
+		}
+		return a
+	}
+	newAccessRequest := func(name, namespace, username string, created time.Time) *api.AccessRequest {
+		ar := utils.NewAccessRequestGranted(utils.WithName(name))
+		ar.SetNamespace(namespace)
+		ar.SetUID(types.UID(name))
+		ar.Spec.Subject.Username = username
+		ar.SetCreationTimestamp(metav1.NewTime(created))
+		return ar
+	}
+	now := time.Now().Truncate(time.Second)
+	newService := func(t *testing.T, a archive.Reader) backend.Service {
+		t.Helper()
+		f := serviceSetup(t)
+		return backend.NewDefaultService(f.persister, f.logger, ControllerNamespace, AccessRequestDuration, backend.WithArchive(a))
+	}
+	t.Run("will return archived access requests matching the filter", func(t *testing.T) {
+		// Given
+		svc := newService(t, newArchive(t,
+			newAccessRequest("older", "some-namespace", "some-user", now.Add(-2*time.Hour)),
+			newAccessRequest("newer", "some-namespace", "some-user", now.Add(-1*time.Hour)),
+			newAccessRequest("other-user", "some-namespace", "other-user", now),
+			newAccessRequest("other-namespace", "other-namespace", "some-user", now),
+		))
+		filter := &backend.AccessRequestFilter{Username: "some-user", Status: api.GrantedStatus}
+
+		// When
+		result, err := svc.SearchArchivedAccessRequests(context.Background(), "some-namespace", filter, nil)
+
+		// Then
+		require.NoError(t, err)
+		require.Len(t, result.Items, 2)
+		assert.Equal(t, "newer", result.Items[0].GetName())
+		assert.Equal(t, "older", result.Items[1].GetName())
+	})
+	t.Run("will paginate the result", func(t *testing.T) {
+		// Given
+		svc := newService(t, newArchive(t,
+			newAccessRequest("ar-a", "some-namespace", "some-user", now),
+			newAccessRequest("ar-b", "some-namespace", "some-user", now.Add(-time.Hour)),
+		))
+		opts := &backend.ListOptions{Limit: 1}
+
+		// When
+		result, err := svc.SearchArchivedAccessRequests(context.Background(), "some-namespace", nil, opts)
+
+		// Then
+		require.NoError(t, err)
+		require.Len(t, result.Items, 1)
+		assert.Equal(t, "ar-a", result.Items[0].GetName())
+		assert.NotEmpty(t, result.Continue)
+	})
+	t.Run("will return error if the archive is not configured", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+
+		// When
+		result, err := f.svc.SearchArchivedAccessRequests(context.Background(), "some-namespace", nil, nil)
+
+		// Then
+		assert.ErrorIs(t, err, backend.ErrArchiveNotConfigured)
+		assert.Nil(t, result)
+	})
+}
+
+func TestServiceWatchSubjectAccessRequests(t *testing.T) {
+	t.Run("will only notify status transitions", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		events := make(chan *api.AccessRequest, 4)
+		events <- utils.NewAccessRequestRequested(utils.WithName("ar-1"))
+		events <- utils.NewAccessRequestRequested(utils.WithName("ar-1"))
+		events <- utils.NewAccessRequestGranted(utils.WithName("ar-1"))
+		events <- utils.NewAccessRequestRequested(utils.WithName("ar-2"))
+		f.persister.EXPECT().WatchSubjectAccessRequests(mock.Anything, "some-namespace", "some-user").
+			Return((<-chan *api.AccessRequest)(events), nil)
+
+		// When
+		transitions, err := f.svc.WatchSubjectAccessRequests(ctx, "some-namespace", "some-user")
+		require.NoError(t, err)
+		received := []string{}
+		for range 3 {
+			ar := <-transitions
+			received = append(received, fmt.Sprintf("%s:%s", ar.GetName(), ar.Status.RequestState))
+		}
+		cancel()
+
+		// Then
+		expected := []string{"ar-1:requested", "ar-1:granted", "ar-2:requested"}
+		assert.Equal(t, expected, received)
+		assert.Eventually(t, func() bool {
+			_, ok := <-transitions
+			return !ok
+		}, time.Second, 10*time.Millisecond)
+	})
+	t.Run("will close the transitions channel when the events channel is closed", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		events := make(chan *api.AccessRequest)
+		f.persister.EXPECT().WatchSubjectAccessRequests(mock.Anything, "some-namespace", "some-user").
+			Return((<-chan *api.AccessRequest)(events), nil)
+		transitions, err := f.svc.WatchSubjectAccessRequests(context.Background(), "some-namespace", "some-user")
+		require.NoError(t, err)
+
+		// When
+		close(events)
+
+		// Then
+		select {
+		case ar, ok := <-transitions:
+			assert.False(t, ok)
+			assert.Nil(t, ar)
+		case <-time.After(time.Second):
+			t.Fatal("transitions channel not closed")
+		}
+	})
+	t.Run("will return error if k8s watch fails", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		f.persister.EXPECT().WatchSubjectAccessRequests(mock.Anything, "some-namespace", "some-user").
+			Return(nil, fmt.Errorf("some internal error"))
+
+		// When
+		transitions, err := f.svc.WatchSubjectAccessRequests(context.Background(), "some-namespace", "some-user")
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, transitions)
+		assert.Contains(t, err.Error(), "some internal error")
+	})
+}
+
+func TestServiceAddApprovalDecision(t *testing.T) {
+	t.Run("will append the decision to the access request", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		ar := utils.NewAccessRequestRequested()
+		ar.Spec.Approval = &api.ApprovalPolicy{Approvers: []string{"approvers"}}
+		decision := &api.ApprovalDecision{Username: "some-approver", Decision: api.ApprovedDecision}
+		f.persister.EXPECT().UpdateAccessRequest(mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, ar *api.AccessRequest) (*api.AccessRequest, error) {
+				return ar, nil
+			})
+
+		// When
+		result, err := f.svc.AddApprovalDecision(context.Background(), ar, decision)
+
+		// Then
+		assert.NoError(t, err)
+		require.NotNil(t, result)
+		require.Len(t, result.Spec.Approvals, 1)
+		assert.Equal(t, *decision, result.Spec.Approvals[0])
+		assert.Empty(t, ar.Spec.Approvals, "given access request must not be modified")
+	})
+	t.Run("will return error if update fails", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		ar := utils.NewAccessRequestRequested()
+		decision := &api.ApprovalDecision{Username: "some-approver", Decision: api.RejectedDecision}
+		f.persister.EXPECT().UpdateAccessRequest(mock.Anything, mock.Anything).Return(nil, fmt.Errorf("some internal error"))
 
 		// When
-		result, err := f.svc.CreateAccessRequest(context.Background(), key, ab)
+		result, err := f.svc.AddApprovalDecision(context.Background(), ar, decision)
 
 		// Then
 		assert.Error(t, err)
@@ -125,6 +1137,97 @@ func TestServiceCreateAccessRequest(t *testing.T) {
 	})
 }
 
+func TestServiceGetRenderedRoleTemplate(t *testing.T) {
+	t.Run("will render the role template for the access request", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		ar := utils.NewAccessRequestGranted()
+		rt := &api.RoleTemplate{
+			Spec: api.RoleTemplateSpec{
+				Name:        "some-role",
+				Description: "Access to {{.application}} in {{.project}}",
+			},
+		}
+		f.persister.EXPECT().GetRoleTemplate(mock.Anything, ar.Spec.Role.TemplateRef.Name, ar.Spec.Role.TemplateRef.Namespace).Return(rt, nil)
+
+		// When
+		result, err := f.svc.GetRenderedRoleTemplate(context.Background(), ar, "some-project")
+
+		// Then
+		assert.NoError(t, err)
+		require.NotNil(t, result)
+		assert.Equal(t, fmt.Sprintf("Access to %s in some-project", ar.Spec.Application.Name), result.Spec.Description)
+	})
+	t.Run("will return nil if role template is not found", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		ar := utils.NewAccessRequestGranted()
+		notFoundErr := errors.NewNotFound(schema.GroupResource{}, ar.Spec.Role.TemplateRef.Name)
+		f.persister.EXPECT().GetRoleTemplate(mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("wrapped: %w", notFoundErr))
+
+		// When
+		result, err := f.svc.GetRenderedRoleTemplate(context.Background(), ar, "some-project")
+
+		// Then
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("will return error if k8s request fails", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		ar := utils.NewAccessRequestGranted()
+		f.persister.EXPECT().GetRoleTemplate(mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("some internal error"))
+
+		// When
+		result, err := f.svc.GetRenderedRoleTemplate(context.Background(), ar, "some-project")
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestServiceGetAccessRequest(t *testing.T) {
+	t.Run("will return the access request", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		ar := utils.NewAccessRequestGranted()
+		f.persister.EXPECT().GetAccessRequest(mock.Anything, ar.GetName(), ar.GetNamespace()).Return(ar, nil)
+
+		// When
+		result, err := f.svc.GetAccessRequest(context.Background(), ar.GetName(), ar.GetNamespace())
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, ar, result)
+	})
+	t.Run("will return nil if access request is not found", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		notFoundErr := errors.NewNotFound(schema.GroupResource{}, "some-ar")
+		f.persister.EXPECT().GetAccessRequest(mock.Anything, "some-ar", "some-ns").Return(nil, fmt.Errorf("get error: %w", notFoundErr))
+
+		// When
+		result, err := f.svc.GetAccessRequest(context.Background(), "some-ar", "some-ns")
+
+		// Then
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("will return error if k8s request fails", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		f.persister.EXPECT().GetAccessRequest(mock.Anything, "some-ar", "some-ns").Return(nil, fmt.Errorf("some internal error"))
+
+		// When
+		result, err := f.svc.GetAccessRequest(context.Background(), "some-ar", "some-ns")
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
 func TestServiceListAccessRequest(t *testing.T) {
 	t.Run("will return access request successfully", func(t *testing.T) {
 		// Given
@@ -265,6 +1368,30 @@ func TestServiceGetAccessRequestByRole(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Nil(t, ar)
 	})
+	t.Run("will ignore access requests being cancelled", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		key := &backend.AccessRequestKey{
+			Namespace:            "some-namespace",
+			ApplicationName:      "some-app",
+			ApplicationNamespace: "app-ns",
+			Username:             "some-user",
+		}
+		roleName := "some-role"
+		cancellingAR := newAccessRequest(key, roleName)
+		cancellingAR.Status.RequestState = api.RequestedStatus
+		cancellingAR.Spec.Cancel = true
+		cancelledAR := newAccessRequest(key, roleName)
+		cancelledAR.Status.RequestState = api.CancelledStatus
+		f.persister.EXPECT().ListAccessRequests(mock.Anything, key).Return(&api.AccessRequestList{Items: []api.AccessRequest{*cancellingAR, *cancelledAR}}, nil)
+
+		// When
+		result, err := f.svc.GetAccessRequestByRole(context.Background(), key, roleName)
+
+		// Then
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+	})
 	t.Run("will return nil if active access request has different role name", func(t *testing.T) {
 		// Given
 		f := serviceSetup(t)
@@ -438,6 +1565,99 @@ func TestServiceGetAccessBindingsForGroups(t *testing.T) {
 	})
 }
 
+func TestServiceExplainAccessBindings(t *testing.T) {
+	roleName := "some-role"
+	namespace := "some-namespace"
+	t.Run("will explain every binding for the role", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		app := &unstructured.Unstructured{Object: map[string]any{"metadata": map[string]any{"name": "some-app"}}}
+		project := &unstructured.Unstructured{Object: map[string]any{"metadata": map[string]any{"name": "some-project"}}}
+		falseCondition := newAccessBinding(namespace, roleName, "group1")
+		falseCondition.SetName("false-condition")
+		falseCondition.Spec.If = ptr.To(`app.metadata.name == "other-app"`)
+		invalidCondition := newAccessBinding(namespace, roleName, "group1")
+		invalidCondition.SetName("invalid-condition")
+		invalidCondition.Spec.If = ptr.To("1 + 1")
+		noMatch := newAccessBinding(namespace, roleName, "other-group")
+		noMatch.SetName("no-match")
+		granting := newAccessBinding(namespace, roleName, "{{ .project.metadata.name }}-admins")
+		granting.SetName("granting")
+		granting.Spec.If = ptr.To(`app.metadata.name == "some-app"`)
+		alsoGranting := newAccessBinding(ControllerNamespace, roleName, "group1")
+		alsoGranting.SetName("also-granting")
+		f.persister.EXPECT().ListAccessBindings(mock.Anything, roleName, namespace).
+			Return(&api.AccessBindingList{Items: []api.AccessBinding{*falseCondition, *invalidCondition, *noMatch, *granting}}, nil)
+		f.persister.EXPECT().ListAccessBindings(mock.Anything, roleName, ControllerNamespace).
+			Return(&api.AccessBindingList{Items: []api.AccessBinding{*alsoGranting}}, nil)
+		groups := []string{"group1", "some-project-admins"}
+
+		// When
+		result, err := f.svc.ExplainAccessBindings(context.Background(), roleName, namespace, groups, app, project)
+
+		// Then
+		assert.NoError(t, err)
+		require.Len(t, result, 5)
+
+		assert.Equal(t, "false-condition", result[0].Binding.GetName())
+		assert.Equal(t, backend.ConditionFalse, result[0].Condition)
+		assert.Equal(t, []string{"group1"}, result[0].Subjects)
+		assert.Equal(t, []string{"group1"}, result[0].MatchedGroups)
+		assert.False(t, result[0].Granting)
+
+		assert.Equal(t, backend.ConditionError, result[1].Condition)
+		assert.Contains(t, result[1].ConditionError, "non-boolean")
+		assert.False(t, result[1].Granting)
+
+		assert.Equal(t, backend.ConditionNotDefined, result[2].Condition)
+		assert.Empty(t, result[2].MatchedGroups)
+		assert.False(t, result[2].Granting)
+
+		assert.Equal(t, backend.ConditionTrue, result[3].Condition)
+		assert.Equal(t, []string{"some-project-admins"}, result[3].Subjects)
+		assert.Equal(t, []string{"some-project-admins"}, result[3].MatchedGroups)
+		assert.True(t, result[3].Granting)
+
+		assert.Equal(t, "also-granting", result[4].Binding.GetName())
+		assert.Equal(t, []string{"group1"}, result[4].MatchedGroups)
+		assert.False(t, result[4].Granting, "only the first granting binding must be flagged")
+	})
+	t.Run("will report subject rendering errors", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		app := &unstructured.Unstructured{}
+		project := &unstructured.Unstructured{}
+		ab := newAccessBinding(namespace, roleName, "{{")
+		f.persister.EXPECT().ListAccessBindings(mock.Anything, roleName, namespace).
+			Return(&api.AccessBindingList{Items: []api.AccessBinding{*ab}}, nil)
+		f.persister.EXPECT().ListAccessBindings(mock.Anything, roleName, ControllerNamespace).
+			Return(&api.AccessBindingList{}, nil)
+
+		// When
+		result, err := f.svc.ExplainAccessBindings(context.Background(), roleName, namespace, []string{"group1"}, app, project)
+
+		// Then
+		assert.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Contains(t, result[0].SubjectsError, "error parsing AccessBinding subjects")
+		assert.False(t, result[0].Granting)
+	})
+	t.Run("will return error if k8s request fails", func(t *testing.T) {
+		// Given
+		f := serviceSetup(t)
+		f.persister.EXPECT().ListAccessBindings(mock.Anything, roleName, namespace).
+			Return(nil, fmt.Errorf("some internal error"))
+
+		// When
+		result, err := f.svc.ExplainAccessBindings(context.Background(), roleName, namespace, []string{"group1"}, &unstructured.Unstructured{}, &unstructured.Unstructured{})
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "some internal error")
+	})
+}
+
 func TestServiceGetGrantingAccessBinding(t *testing.T) {
 	t.Run("will return binding when granting in target namespace", func(t *testing.T) {
 		// Given
@@ -39,6 +39,8 @@ import (
 
 	argocd "github.com/argoproj-labs/argocd-ephemeral-access/api/argoproj/v1alpha1"
 	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
+	"github.com/argoproj-labs/argocd-ephemeral-access/internal/archive"
+	"github.com/argoproj-labs/argocd-ephemeral-access/internal/controller/audit"
 	"github.com/argoproj-labs/argocd-ephemeral-access/internal/controller/config"
 	"github.com/argoproj-labs/argocd-ephemeral-access/internal/controller/metrics"
 	"github.com/argoproj-labs/argocd-ephemeral-access/pkg/log"
@@ -50,6 +52,9 @@ type AccessRequestReconciler struct {
 	Scheme  *runtime.Scheme
 	Service *Service
 	Config  config.ControllerConfigurer
+	// Archiver stores the AccessRequests before they are deleted once the
+	// TTL is exceeded. AccessRequests are not archived if nil.
+	Archiver archive.Archiver
 }
 
 const (
@@ -70,8 +75,10 @@ const (
 // +kubebuilder:rbac:groups=ephemeral-access.argoproj-labs.io,resources=roletemplates,verbs=get;list;watch
 // +kubebuilder:rbac:groups=ephemeral-access.argoproj-labs.io,resources=roletemplates/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=ephemeral-access.argoproj-labs.io,resources=roletemplates/finalizers,verbs=update
+// +kubebuilder:rbac:groups=ephemeral-access.argoproj-labs.io,resources=accessbindings,verbs=get;list;watch
 // +kubebuilder:rbac:groups=argoproj.io,resources=appprojects,verbs=get;list;watch;update;patch
-// +kubebuilder:rbac:groups=argoproj.io,resources=applications,verbs=get;list;watch
+// +kubebuilder:rbac:groups=argoproj.io,resources=applications,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile is the main function that will be invoked on every change in
 // AccessRequests desired state. It will:
@@ -177,11 +184,15 @@ func (r *AccessRequestReconciler) HandleConflict(ctx context.Context, ar *api.Ac
 		accessRequestConflictError := &AccessRequestConflictError{}
 		if errors.As(err, &accessRequestConflictError) {
 			logger.Error(err, "AccessRequest conflict error")
-			ar.UpdateStatusHistory(api.InvalidStatus, err.Error())
+			details := err.Error()
+			ar.UpdateStatusHistory(api.InvalidStatus, details)
 			err = r.Status().Update(ctx, ar)
 			if err != nil {
 				return fmt.Errorf("error updating status to invalid: %w", err)
 			}
+			r.Service.RecordStatusEvent(ctx, ar, api.InvalidStatus, details)
+			r.Service.Notify(ctx, ar, api.InvalidStatus, details)
+			r.Service.Audit(ctx, ar, audit.ActionInvalid, details)
 			metrics.IncrementAccessRequestCounter(api.InvalidStatus)
 			return nil
 		}
@@ -222,15 +233,21 @@ func (r *AccessRequestReconciler) ValidateConflict(ctx context.Context, ar *api.
 			arResp.GetNamespace() == ar.GetNamespace() {
 			continue
 		}
+		// skip if the request was cancelled by the subject as it will be
+		// concluded without being granted
+		if arResp.Spec.Cancel && arResp.Status.RequestState != api.GrantedStatus {
+			continue
+		}
 		// skip if the request is for different role template
 		if arResp.Spec.Role.TemplateRef.Name != ar.Spec.Role.TemplateRef.Name ||
 			arResp.Spec.Role.TemplateRef.Namespace != ar.Spec.Role.TemplateRef.Namespace {
 			continue
 		}
-		// if the existing request is pending or granted, then the new request is
+		// if the existing request is pending, scheduled or granted, then the new request is
 		// a duplicate and must be rejected
 		if arResp.Status.RequestState == api.GrantedStatus ||
-			arResp.Status.RequestState == api.RequestedStatus {
+			arResp.Status.RequestState == api.RequestedStatus ||
+			arResp.Status.RequestState == api.ScheduledStatus {
 			return NewAccessRequestConflictError(fmt.Sprintf("found existing AccessRequest (%s/%s) in %s state", arResp.GetNamespace(), arResp.GetName(), string(arResp.Status.RequestState)))
 		}
 		// if the existing request reconciliation isn't initialized yet, then we
@@ -251,9 +268,23 @@ func buildResult(status api.Status, ar *api.AccessRequest, config config.Control
 	case api.RequestedStatus:
 		result.Requeue = true
 		result.RequeueAfter = config.ControllerRequeueInterval()
+	case api.ScheduledStatus:
+		result.Requeue = true
+		result.RequeueAfter = time.Until(ar.Spec.StartsAt.Time)
 	case api.GrantedStatus:
 		result.Requeue = true
 		result.RequeueAfter = time.Until(ar.Status.ExpiresAt.Time)
+		// pending extensions must be reevaluated periodically until the
+		// plugin makes a decision
+		if ar.GetPendingExtension() != nil && result.RequeueAfter > config.ControllerRequeueInterval() {
+			result.RequeueAfter = config.ControllerRequeueInterval()
+		}
+		// the expiring notification must be sent before the access expires
+		if due := ar.ExpiryWarningDue(); due != nil {
+			if warnIn := time.Until(*due); warnIn > 0 && warnIn < result.RequeueAfter {
+				result.RequeueAfter = warnIn
+			}
+		}
 	default:
 		if ar.IsConcluded() && hasTTLConfig(config) {
 			if ttl := getTTLTime(ar, config); ttl != nil {
@@ -319,11 +350,20 @@ func (r *AccessRequestReconciler) handleTTL(ctx context.Context, ar *api.AccessR
 	ttlExceeded := time.Now().After(*ttl)
 
 	if ttlExceeded {
+		// The AccessRequest is only deleted once archived so it can't be
+		// lost if the archive is unavailable.
+		if r.Archiver != nil {
+			err := r.Archiver.Archive(ctx, ar)
+			if err != nil {
+				return ttlExceeded, fmt.Errorf("error archiving AccessRequest: %w", err)
+			}
+		}
 		// If TTL is exceeded, set the resource to be deleted.
 		err := r.Delete(ctx, ar)
 		if err != nil {
 			return ttlExceeded, fmt.Errorf("error adding deletion timestamp: %w", err)
 		}
+		r.Service.Audit(ctx, ar, audit.ActionDeleted, fmt.Sprintf("AccessRequest deleted after the TTL of %s", r.Config.ControllerAccessRequestTTL()))
 	}
 	return ttlExceeded, nil
 }
@@ -428,6 +468,9 @@ func (r *AccessRequestReconciler) handleRequestTimeout(ctx context.Context, ar *
 		if err != nil {
 			return timedout, fmt.Errorf("error updating status to timeout: %w", err)
 		}
+		r.Service.RecordStatusEvent(ctx, ar, api.TimeoutStatus, "AccessRequest timed out")
+		r.Service.Notify(ctx, ar, api.TimeoutStatus, "AccessRequest timed out")
+		r.Service.Audit(ctx, ar, audit.ActionTimeout, "AccessRequest timed out")
 	}
 	return timedout, nil
 }
@@ -460,9 +503,11 @@ func (r *AccessRequestReconciler) handleFinalizer(ctx context.Context, ar *api.A
 
 	// The object is being deleted
 	if controllerutil.ContainsFinalizer(ar, AccessRequestFinalizerName) {
-		// if the access request is not expired yet then
-		// execute the cleanup procedure before removing the finalizer
-		if ar.Status.RequestState != api.ExpiredStatus {
+		// if the access request is not expired, revoked or cancelled yet
+		// then execute the cleanup procedure before removing the finalizer
+		if ar.Status.RequestState != api.ExpiredStatus &&
+			ar.Status.RequestState != api.RevokedStatus &&
+			ar.Status.RequestState != api.CancelledStatus {
 			// this is a best effort to update policies that eventually changed
 			// in the project. Errors are ignored as it is more important to
 			// remove the user from the role.
@@ -17,6 +17,7 @@ limitations under the License.
 package controller
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
@@ -272,6 +273,11 @@ var _ = Describe("AccessRequest Controller", func() {
 					return apierrors.IsNotFound(err) || ar.GetDeletionTimestamp() != nil
 				}).WithTimeout(timeout + 10*time.Second).WithPolling(interval).Should(BeTrue())
 			})
+			It("will validate if the AccessRequest is archived before deletion", func() {
+				archived, err := accessRequestArchive.List(ctx, namespace)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(archived).To(ContainElement(HaveField("ObjectMeta.Name", f.accessrequests[0].GetName())))
+			})
 		})
 		When("protected fields values change after applied", func() {
 			const (
@@ -398,8 +404,8 @@ var _ = Describe("AccessRequest Controller", func() {
 				"p, {{.role}}, logs, get, {{.project}}/{{.namespace}}/{{.application}}, allow",
 			}
 
-			accessRequesterMock.EXPECT().GrantAccess(mock.Anything, mock.Anything).
-				RunAndReturn(func(ar *api.AccessRequest, a *argocd.Application) (*plugin.GrantResponse, error) {
+			accessRequesterMock.EXPECT().GrantAccess(mock.Anything, mock.Anything, mock.Anything).
+				RunAndReturn(func(_ context.Context, ar *api.AccessRequest, a *argocd.Application) (*plugin.GrantResponse, error) {
 					pluginResponse := &plugin.GrantResponse{
 						Status: plugin.GrantStatusGranted,
 					}
@@ -0,0 +1,193 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
+	"github.com/argoproj-labs/argocd-ephemeral-access/pkg/log"
+)
+
+const (
+	// AccessScheduleLabel is the label added to the AccessRequests created by
+	// an AccessSchedule. The value is the AccessSchedule name.
+	AccessScheduleLabel = "ephemeral-access.argoproj-labs.io/schedule"
+)
+
+// AccessScheduleReconciler reconciles an AccessSchedule object
+type AccessScheduleReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=ephemeral-access.argoproj-labs.io,resources=accessschedules,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ephemeral-access.argoproj-labs.io,resources=accessschedules/status,verbs=get;update;patch
+
+// Reconcile will be invoked on every change in AccessSchedules and every time
+// a new window is due. It will:
+//  1. Parse the configured cron schedule
+//  2. Create one AccessRequest for each subject if a window is active
+//  3. Update the schedule status and requeue at the next window start
+//
+// The generated AccessRequests follow the same lifecycle as the ones created
+// by the backend and are owned by the AccessSchedule.
+func (r *AccessScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	as := &api.AccessSchedule{}
+	if err := r.Get(ctx, req.NamespacedName, as); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Debug("Object deleted")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Error retrieving AccessSchedule from k8s")
+		return ctrl.Result{}, err
+	}
+	logger = logger.WithValues("schedule", as.Spec.Schedule, "timeZone", as.Spec.TimeZone)
+	ctx = log.IntoContext(ctx, logger)
+	logger.Info("Reconciliation started")
+
+	if as.Spec.Suspend {
+		logger.Info("AccessSchedule suspended: skipping...")
+		return ctrl.Result{}, nil
+	}
+
+	schedule, err := as.ParseSchedule()
+	if err != nil {
+		// retrying won't help until the spec is fixed
+		logger.Error(err, "Invalid AccessSchedule")
+		return ctrl.Result{}, nil
+	}
+
+	now := time.Now()
+	status := as.Status.DeepCopy()
+	if windowStart := as.ActiveWindow(schedule, now); windowStart != nil {
+		err = r.createAccessRequests(ctx, as, *windowStart)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("error creating scheduled AccessRequests: %w", err)
+		}
+		status.LastScheduleTime = &metav1.Time{Time: *windowStart}
+	}
+
+	next := schedule.Next(now)
+	status.NextScheduleTime = nil
+	if !next.IsZero() {
+		status.NextScheduleTime = &metav1.Time{Time: next}
+	}
+	as.Status = *status
+	err = r.Status().Update(ctx, as)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error updating AccessSchedule status: %w", err)
+	}
+
+	result := ctrl.Result{}
+	if !next.IsZero() {
+		result.RequeueAfter = time.Until(next)
+	}
+	logger.Info("Reconciliation concluded", "next", next, "result", result)
+	return result, nil
+}
+
+// createAccessRequests will create the AccessRequests for all subjects in the
+// window starting at the given time. AccessRequests have deterministic names
+// so they are only created once per window.
+func (r *AccessScheduleReconciler) createAccessRequests(ctx context.Context, as *api.AccessSchedule, windowStart time.Time) error {
+	logger := log.FromContext(ctx)
+	binding := &api.AccessBinding{}
+	key := client.ObjectKey{
+		Name:      as.Spec.AccessBindingRef.Name,
+		Namespace: as.Spec.AccessBindingRef.Namespace,
+	}
+	err := r.Get(ctx, key, binding)
+	if err != nil {
+		return fmt.Errorf("error retrieving AccessBinding %s/%s: %w", key.Namespace, key.Name, err)
+	}
+
+	for _, subject := range as.Spec.Subjects {
+		ar := newScheduledAccessRequest(as, binding, subject, windowStart)
+		err := controllerutil.SetControllerReference(as, ar, r.Scheme)
+		if err != nil {
+			return fmt.Errorf("error setting AccessRequest owner: %w", err)
+		}
+		err = r.Create(ctx, ar)
+		if err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				continue
+			}
+			return fmt.Errorf("error creating AccessRequest %s for %s: %w", ar.GetName(), subject.Username, err)
+		}
+		logger.Info("Scheduled AccessRequest created", "accessrequest", ar.GetName(), "subject", subject.Username)
+	}
+	return nil
+}
+
+// newScheduledAccessRequest returns the AccessRequest granting the access
+// to the given subject in the window starting at the given time. The start
+// time is set in the AccessRequest so the access expires at the end of the
+// window regardless of when it is granted.
+func newScheduledAccessRequest(as *api.AccessSchedule, binding *api.AccessBinding, subject api.ScheduleSubject, windowStart time.Time) *api.AccessRequest {
+	justification := as.Spec.Justification
+	if justification == "" {
+		justification = fmt.Sprintf("Scheduled access from AccessSchedule %s", as.GetName())
+	}
+	userId := subject.UserId
+	return &api.AccessRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      scheduledAccessRequestName(as.GetName(), subject.Username, windowStart),
+			Namespace: as.GetNamespace(),
+			Labels: map[string]string{
+				AccessScheduleLabel: as.GetName(),
+			},
+		},
+		Spec: api.AccessRequestSpec{
+			Duration: as.Spec.Duration,
+			Role: api.TargetRole{
+				TemplateRef: api.TargetRoleTemplate{
+					Name:      binding.Spec.RoleTemplateRef.Name,
+					Namespace: binding.GetNamespace(),
+				},
+				Ordinal:      binding.Spec.Ordinal,
+				FriendlyName: binding.Spec.FriendlyName,
+			},
+			Application: as.Spec.Application,
+			Subject: api.Subject{
+				Username: subject.Username,
+				UserId:   &userId,
+			},
+			AccessBindingRef: &api.AccessBindingReference{
+				Name:      binding.GetName(),
+				Namespace: binding.GetNamespace(),
+			},
+			Justification: justification,
+			StartsAt:      &metav1.Time{Time: windowStart},
+		},
+	}
+}
+
+// scheduledAccessRequestName returns a deterministic name for the
+// AccessRequest created for the given subject in the given window. Usernames
+// are hashed as they may contain characters not allowed in object names.
+func scheduledAccessRequestName(scheduleName, username string, windowStart time.Time) string {
+	h := fnv.New32a()
+	h.Write([]byte(username))
+	return fmt.Sprintf("%s-%08x-%d", scheduleName, h.Sum32(), windowStart.Unix())
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *AccessScheduleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&api.AccessSchedule{},
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
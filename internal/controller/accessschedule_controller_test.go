@@ -0,0 +1,192 @@
+package controller_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
+	"github.com/argoproj-labs/argocd-ephemeral-access/internal/controller"
+)
+
+func TestAccessScheduleReconciler(t *testing.T) {
+	newBinding := func() *api.AccessBinding {
+		return &api.AccessBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some-binding",
+				Namespace: "ephemeral",
+			},
+			Spec: api.AccessBindingSpec{
+				RoleTemplateRef: api.RoleTemplateReference{Name: "some-role"},
+				Subjects:        []string{"some-group"},
+				Ordinal:         1,
+				FriendlyName:    ptr.To("Some Role"),
+			},
+		}
+	}
+	newSchedule := func(schedule string, duration time.Duration) *api.AccessSchedule {
+		return &api.AccessSchedule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some-schedule",
+				Namespace: "ephemeral",
+				UID:       types.UID("some-uid"),
+			},
+			Spec: api.AccessScheduleSpec{
+				Schedule: schedule,
+				Duration: metav1.Duration{Duration: duration},
+				Application: api.TargetApplication{
+					Name:      "some-app",
+					Namespace: "argocd",
+				},
+				AccessBindingRef: api.AccessBindingReference{
+					Name:      "some-binding",
+					Namespace: "ephemeral",
+				},
+				Subjects: []api.ScheduleSubject{
+					{Username: "user1@example.com"},
+					{Username: "user2@example.com", UserId: "user2-id"},
+				},
+			},
+		}
+	}
+	setup := func(t *testing.T, objs ...client.Object) (*controller.AccessScheduleReconciler, client.Client) {
+		t.Helper()
+		scheme := runtime.NewScheme()
+		require.NoError(t, api.AddToScheme(scheme))
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(objs...).
+			WithStatusSubresource(&api.AccessSchedule{}).
+			Build()
+		return &controller.AccessScheduleReconciler{Client: k8sClient, Scheme: scheme}, k8sClient
+	}
+	reconcile := func(r *controller.AccessScheduleReconciler) (ctrl.Result, error) {
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "some-schedule", Namespace: "ephemeral"}}
+		return r.Reconcile(context.Background(), req)
+	}
+	listAccessRequests := func(t *testing.T, k8sClient client.Client) []api.AccessRequest {
+		t.Helper()
+		arList := &api.AccessRequestList{}
+		require.NoError(t, k8sClient.List(context.Background(), arList, client.InNamespace("ephemeral")))
+		return arList.Items
+	}
+
+	t.Run("will create AccessRequests for all subjects during an active window", func(t *testing.T) {
+		// Given
+		windowStart := time.Now().UTC().Truncate(time.Hour)
+		r, k8sClient := setup(t, newSchedule("0 * * * *", time.Hour), newBinding())
+
+		// When
+		result, err := reconcile(r)
+
+		// Then
+		require.NoError(t, err)
+		assert.Greater(t, result.RequeueAfter, time.Duration(0))
+		assert.LessOrEqual(t, result.RequeueAfter, time.Hour)
+		items := listAccessRequests(t, k8sClient)
+		require.Len(t, items, 2)
+		for _, ar := range items {
+			assert.Equal(t, "some-schedule", ar.GetLabels()[controller.AccessScheduleLabel])
+			require.Len(t, ar.GetOwnerReferences(), 1)
+			assert.Equal(t, "some-schedule", ar.GetOwnerReferences()[0].Name)
+			assert.Equal(t, time.Hour, ar.Spec.Duration.Duration)
+			assert.True(t, windowStart.Equal(ar.Spec.StartsAt.Time))
+			assert.Equal(t, "some-role", ar.Spec.Role.TemplateRef.Name)
+			assert.Equal(t, "ephemeral", ar.Spec.Role.TemplateRef.Namespace)
+			assert.Equal(t, "some-binding", ar.Spec.AccessBindingRef.Name)
+			assert.Equal(t, "some-app", ar.Spec.Application.Name)
+			assert.Equal(t, "Scheduled access from AccessSchedule some-schedule", ar.Spec.Justification)
+			assert.Nil(t, ar.Spec.Approval)
+			assert.Contains(t, ar.GetName(), "some-schedule-")
+			assert.Contains(t, ar.GetName(), fmt.Sprintf("-%d", windowStart.Unix()))
+		}
+		usernames := []string{items[0].Spec.Subject.Username, items[1].Spec.Subject.Username}
+		assert.ElementsMatch(t, []string{"user1@example.com", "user2@example.com"}, usernames)
+
+		as := &api.AccessSchedule{}
+		require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Name: "some-schedule", Namespace: "ephemeral"}, as))
+		require.NotNil(t, as.Status.LastScheduleTime)
+		assert.True(t, windowStart.Equal(as.Status.LastScheduleTime.Time))
+		require.NotNil(t, as.Status.NextScheduleTime)
+		assert.True(t, windowStart.Add(time.Hour).Equal(as.Status.NextScheduleTime.Time))
+	})
+	t.Run("will only create AccessRequests once per window", func(t *testing.T) {
+		// Given
+		r, k8sClient := setup(t, newSchedule("0 * * * *", time.Hour), newBinding())
+
+		// When
+		_, err := reconcile(r)
+		require.NoError(t, err)
+		_, err = reconcile(r)
+
+		// Then
+		require.NoError(t, err)
+		assert.Len(t, listAccessRequests(t, k8sClient), 2)
+	})
+	t.Run("will not create AccessRequests outside of the window", func(t *testing.T) {
+		// Given
+		nextHour := (time.Now().UTC().Hour() + 2) % 24
+		r, k8sClient := setup(t, newSchedule(fmt.Sprintf("0 %d * * *", nextHour), time.Hour), newBinding())
+
+		// When
+		result, err := reconcile(r)
+
+		// Then
+		require.NoError(t, err)
+		assert.Greater(t, result.RequeueAfter, time.Hour)
+		assert.Empty(t, listAccessRequests(t, k8sClient))
+		as := &api.AccessSchedule{}
+		require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Name: "some-schedule", Namespace: "ephemeral"}, as))
+		assert.Nil(t, as.Status.LastScheduleTime)
+		assert.NotNil(t, as.Status.NextScheduleTime)
+	})
+	t.Run("will not create AccessRequests if the schedule is suspended", func(t *testing.T) {
+		// Given
+		as := newSchedule("0 * * * *", time.Hour)
+		as.Spec.Suspend = true
+		r, k8sClient := setup(t, as, newBinding())
+
+		// When
+		result, err := reconcile(r)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+		assert.Empty(t, listAccessRequests(t, k8sClient))
+	})
+	t.Run("will not requeue if the schedule is invalid", func(t *testing.T) {
+		// Given
+		r, k8sClient := setup(t, newSchedule("every hour", time.Hour), newBinding())
+
+		// When
+		result, err := reconcile(r)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+		assert.Empty(t, listAccessRequests(t, k8sClient))
+	})
+	t.Run("will return error if the access binding is not found", func(t *testing.T) {
+		// Given
+		r, k8sClient := setup(t, newSchedule("0 * * * *", time.Hour))
+
+		// When
+		_, err := reconcile(r)
+
+		// Then
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "error retrieving AccessBinding ephemeral/some-binding")
+		assert.Empty(t, listAccessRequests(t, k8sClient))
+	})
+}
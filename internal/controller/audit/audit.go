@@ -0,0 +1,278 @@
+// Package audit implements an append-only, hash-chained audit log of the
+// decisions made about AccessRequests. Every record is written as a JSON line
+// containing the hash of the previous record, so removing or changing any
+// record breaks the chain and can be detected with Verify.
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
+)
+
+// StdoutPath is the path used to write the audit log to the standard output.
+const StdoutPath = "stdout"
+
+// Action defines the audited decisions.
+type Action string
+
+const (
+	ActionCreated        Action = "created"
+	ActionPluginResponse Action = "plugin-response"
+	ActionApproved       Action = "approved"
+	ActionRejected       Action = "rejected"
+	ActionRequested      Action = "requested"
+	ActionScheduled      Action = "scheduled"
+	ActionGranted        Action = "granted"
+	ActionDenied         Action = "denied"
+	ActionRevoked        Action = "revoked"
+	ActionCancelled      Action = "cancelled"
+	ActionExpired        Action = "expired"
+	ActionInvalid        Action = "invalid"
+	ActionTimeout        Action = "timeout"
+	// ActionDeleted is recorded when the AccessRequest is deleted after the
+	// configured TTL
+	ActionDeleted Action = "deleted"
+)
+
+// ActionFromStatus returns the action recorded when an AccessRequest
+// transitions to the given status.
+func ActionFromStatus(status api.Status) Action {
+	if status == api.InitiatedStatus {
+		return ActionCreated
+	}
+	return Action(status)
+}
+
+// Record defines an audit log entry.
+type Record struct {
+	// Sequence is the position of the record in the chain starting from 1
+	Sequence uint64 `json:"seq"`
+	// Time is when the decision was made
+	Time time.Time `json:"time"`
+	// Action is the audited decision
+	Action Action `json:"action"`
+	// AccessRequest identifies the AccessRequest in the format
+	// <namespace>/<name>
+	AccessRequest string `json:"accessRequest"`
+	// UID is the AccessRequest UID
+	UID string `json:"uid,omitempty"`
+	// Username is the AccessRequest subject
+	Username string `json:"username"`
+	// Application is the target Application in the format
+	// <namespace>/<name>
+	Application string `json:"application"`
+	// Project is the target AppProject
+	Project string `json:"project,omitempty"`
+	// Role is the requested RoleTemplate
+	Role string `json:"role"`
+	// Status is the AccessRequest status once the decision was made
+	Status api.Status `json:"status,omitempty"`
+	// Details contains the decision details (e.g. the plugin message)
+	Details string `json:"details,omitempty"`
+	// Justification provided by the subject
+	Justification string `json:"justification,omitempty"`
+	// TicketRef provided by the subject
+	TicketRef string `json:"ticketRef,omitempty"`
+	// BreakGlass is true for emergency access
+	BreakGlass bool `json:"breakGlass,omitempty"`
+	// PrevHash is the hash of the previous record. Empty for the first
+	// record of a chain.
+	PrevHash string `json:"prevHash"`
+	// Hash is the SHA-256 of this record JSON without the hash field
+	Hash string `json:"hash,omitempty"`
+}
+
+// computeHash returns the hex encoded SHA-256 of the record JSON without the
+// hash field.
+func (r Record) computeHash() (string, error) {
+	r.Hash = ""
+	data, err := json.Marshal(r)
+	if err != nil {
+		return "", fmt.Errorf("error marshaling audit record: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Logger writes the audit records to a sink. It is safe for concurrent use.
+type Logger struct {
+	mu       sync.Mutex
+	w        io.Writer
+	closer   io.Closer
+	sequence uint64
+	lastHash string
+}
+
+// NewLogger returns a Logger starting a new chain in the given writer.
+func NewLogger(w io.Writer) *Logger {
+	return &Logger{w: w}
+}
+
+// NewLoggerFromPath returns a Logger writing to the file in the given path or
+// to the standard output if path is StdoutPath. Existing files are appended
+// and the chain continues from their last record.
+func NewLoggerFromPath(path string) (*Logger, error) {
+	if path == StdoutPath {
+		return NewLogger(os.Stdout), nil
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("error opening audit log %s: %w", path, err)
+	}
+	last, err := lastRecord(f)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("error reading audit log %s: %w", path, err)
+	}
+	l := &Logger{w: f, closer: f}
+	if last != nil {
+		l.sequence = last.Sequence
+		l.lastHash = last.Hash
+	}
+	return l, nil
+}
+
+// lastRecord returns the last record in the given reader or nil if empty.
+func lastRecord(r io.Reader) (*Record, error) {
+	var last []byte
+	scanner := newScanner(r)
+	for scanner.Scan() {
+		if line := bytes.TrimSpace(scanner.Bytes()); len(line) > 0 {
+			last = append(last[:0], line...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if last == nil {
+		return nil, nil
+	}
+	record := &Record{}
+	if err := json.Unmarshal(last, record); err != nil {
+		return nil, fmt.Errorf("invalid last record: %w", err)
+	}
+	return record, nil
+}
+
+// Log appends a record with the given action about the given AccessRequest
+// to the chain.
+func (l *Logger) Log(action Action, ar *api.AccessRequest, details string) error {
+	record := Record{
+		Time:          time.Now().UTC(),
+		Action:        action,
+		AccessRequest: fmt.Sprintf("%s/%s", ar.GetNamespace(), ar.GetName()),
+		UID:           string(ar.GetUID()),
+		Username:      ar.Spec.Subject.Username,
+		Application:   fmt.Sprintf("%s/%s", ar.Spec.Application.Namespace, ar.Spec.Application.Name),
+		Project:       ar.Status.TargetProject,
+		Role:          ar.Spec.Role.TemplateRef.Name,
+		Status:        ar.Status.RequestState,
+		Details:       details,
+		Justification: ar.Spec.Justification,
+		TicketRef:     ar.Spec.TicketRef,
+		BreakGlass:    ar.Spec.BreakGlass,
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	record.Sequence = l.sequence + 1
+	record.PrevHash = l.lastHash
+	hash, err := record.computeHash()
+	if err != nil {
+		return err
+	}
+	record.Hash = hash
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("error marshaling audit record: %w", err)
+	}
+	_, err = l.w.Write(append(data, '\n'))
+	if err != nil {
+		return fmt.Errorf("error writing audit record: %w", err)
+	}
+	l.sequence = record.Sequence
+	l.lastHash = record.Hash
+	return nil
+}
+
+// Close will close the underlying file if any.
+func (l *Logger) Close() error {
+	if l.closer == nil {
+		return nil
+	}
+	return l.closer.Close()
+}
+
+// VerifyResult contains the summary of a verified audit log.
+type VerifyResult struct {
+	// Records is the number of verified records
+	Records int
+	// Chains is the number of chains found. A new chain is started every
+	// time the controller restarts while writing to the standard output.
+	Chains int
+	// LastHash is the hash of the last record
+	LastHash string
+}
+
+// Verify reads the audit records from r and validates the hash chain. Returns
+// an error identifying the first line that was modified, removed or added
+// out of order.
+func Verify(r io.Reader) (*VerifyResult, error) {
+	result := &VerifyResult{}
+	var prev *Record
+	scanner := newScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		record := &Record{}
+		if err := json.Unmarshal(line, record); err != nil {
+			return result, fmt.Errorf("line %d: invalid record: %w", lineNumber, err)
+		}
+		hash, err := record.computeHash()
+		if err != nil {
+			return result, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		if hash != record.Hash {
+			return result, fmt.Errorf("line %d: hash mismatch: record was modified", lineNumber)
+		}
+		switch {
+		case record.Sequence == 1 && record.PrevHash == "":
+			result.Chains++
+		case prev == nil:
+			return result, fmt.Errorf("line %d: chain doesn't start at the first record", lineNumber)
+		case record.Sequence != prev.Sequence+1:
+			return result, fmt.Errorf("line %d: expected sequence %d but found %d", lineNumber, prev.Sequence+1, record.Sequence)
+		case record.PrevHash != prev.Hash:
+			return result, fmt.Errorf("line %d: previous hash mismatch: chain is broken", lineNumber)
+		}
+		prev = record
+		result.Records++
+		result.LastHash = record.Hash
+	}
+	if err := scanner.Err(); err != nil {
+		return result, fmt.Errorf("error reading audit log: %w", err)
+	}
+	if result.Records == 0 {
+		return result, errors.New("audit log is empty")
+	}
+	return result, nil
+}
+
+func newScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	return scanner
+}
@@ -0,0 +1,181 @@
+package audit_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
+	"github.com/argoproj-labs/argocd-ephemeral-access/internal/controller/audit"
+	"github.com/argoproj-labs/argocd-ephemeral-access/test/utils"
+)
+
+func newAccessRequest() *api.AccessRequest {
+	ar := utils.NewAccessRequest("some-ar", "ephemeral", "some-app", "argocd", "some-role", "ephemeral", "user-id", "some-user")
+	ar.Spec.Justification = "incident"
+	ar.Status.TargetProject = "some-project"
+	return ar
+}
+
+// writeChain returns an audit log with the given number of records
+func writeChain(t *testing.T, records int) []string {
+	t.Helper()
+	var buf bytes.Buffer
+	logger := audit.NewLogger(&buf)
+	ar := newAccessRequest()
+	for i := 0; i < records; i++ {
+		require.NoError(t, logger.Log(audit.ActionGranted, ar, "some details"))
+	}
+	return strings.Split(strings.TrimSpace(buf.String()), "\n")
+}
+
+func verify(lines []string) (*audit.VerifyResult, error) {
+	return audit.Verify(strings.NewReader(strings.Join(lines, "\n") + "\n"))
+}
+
+func TestLogger(t *testing.T) {
+	t.Run("will write hash-chained records", func(t *testing.T) {
+		// Given
+		var buf bytes.Buffer
+		logger := audit.NewLogger(&buf)
+		ar := newAccessRequest()
+		ar.Status.RequestState = api.GrantedStatus
+
+		// When
+		require.NoError(t, logger.Log(audit.ActionCreated, ar, ""))
+		require.NoError(t, logger.Log(audit.ActionGranted, ar, "approved by plugin"))
+
+		// Then
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 2)
+		first, second := &audit.Record{}, &audit.Record{}
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), first))
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), second))
+		assert.Equal(t, uint64(1), first.Sequence)
+		assert.Empty(t, first.PrevHash)
+		assert.Equal(t, uint64(2), second.Sequence)
+		assert.Equal(t, first.Hash, second.PrevHash)
+		assert.Equal(t, audit.ActionGranted, second.Action)
+		assert.Equal(t, "ephemeral/some-ar", second.AccessRequest)
+		assert.Equal(t, "argocd/some-app", second.Application)
+		assert.Equal(t, "some-project", second.Project)
+		assert.Equal(t, "some-user", second.Username)
+		assert.Equal(t, "some-role", second.Role)
+		assert.Equal(t, api.GrantedStatus, second.Status)
+		assert.Equal(t, "approved by plugin", second.Details)
+		assert.Equal(t, "incident", second.Justification)
+	})
+	t.Run("will continue the chain of an existing file", func(t *testing.T) {
+		// Given
+		path := filepath.Join(t.TempDir(), "audit.jsonl")
+		logger, err := audit.NewLoggerFromPath(path)
+		require.NoError(t, err)
+		require.NoError(t, logger.Log(audit.ActionCreated, newAccessRequest(), ""))
+		require.NoError(t, logger.Close())
+
+		// When
+		logger, err = audit.NewLoggerFromPath(path)
+		require.NoError(t, err)
+		require.NoError(t, logger.Log(audit.ActionGranted, newAccessRequest(), ""))
+		require.NoError(t, logger.Close())
+
+		// Then
+		f, err := os.Open(path)
+		require.NoError(t, err)
+		defer f.Close()
+		result, err := audit.Verify(f)
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Records)
+		assert.Equal(t, 1, result.Chains)
+	})
+	t.Run("will return error if the existing file is corrupted", func(t *testing.T) {
+		// Given
+		path := filepath.Join(t.TempDir(), "audit.jsonl")
+		require.NoError(t, os.WriteFile(path, []byte("not json\n"), 0o600))
+
+		// When
+		_, err := audit.NewLoggerFromPath(path)
+
+		// Then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid last record")
+	})
+}
+
+func TestVerify(t *testing.T) {
+	t.Run("will verify a valid chain", func(t *testing.T) {
+		// Given
+		lines := writeChain(t, 3)
+
+		// When
+		result, err := verify(lines)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Records)
+		assert.Equal(t, 1, result.Chains)
+		assert.NotEmpty(t, result.LastHash)
+	})
+	t.Run("will accept chains restarted by the controller", func(t *testing.T) {
+		// Given
+		lines := append(writeChain(t, 2), writeChain(t, 2)...)
+
+		// When
+		result, err := verify(lines)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, 4, result.Records)
+		assert.Equal(t, 2, result.Chains)
+	})
+	t.Run("will detect modified records", func(t *testing.T) {
+		// Given
+		lines := writeChain(t, 3)
+		lines[1] = strings.Replace(lines[1], `"action":"granted"`, `"action":"denied"`, 1)
+
+		// When
+		result, err := verify(lines)
+
+		// Then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "line 2: hash mismatch")
+		assert.Equal(t, 1, result.Records)
+	})
+	t.Run("will detect removed records", func(t *testing.T) {
+		// Given
+		lines := writeChain(t, 3)
+		lines = append(lines[:1], lines[2:]...)
+
+		// When
+		_, err := verify(lines)
+
+		// Then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "line 2: expected sequence 2 but found 3")
+	})
+	t.Run("will detect truncated chains", func(t *testing.T) {
+		// Given
+		lines := writeChain(t, 3)
+
+		// When
+		_, err := verify(lines[1:])
+
+		// Then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "line 1: chain doesn't start at the first record")
+	})
+	t.Run("will return error if empty", func(t *testing.T) {
+		// When
+		_, err := audit.Verify(strings.NewReader(""))
+
+		// Then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "audit log is empty")
+	})
+}
@@ -17,6 +17,9 @@ type Configurer interface {
 	MetricsConfigurer
 	ControllerConfigurer
 	PluginConfigurer
+	NotificationConfigurer
+	AuditConfigurer
+	ArchiveConfigurer
 }
 
 // LogConfigurer defines the accessor methods for log configurations.
@@ -28,6 +31,44 @@ type LogConfigurer interface {
 // PluginConfigurer defines the accessor methods for the plugin configurations.
 type PluginConfigurer interface {
 	PluginPath() string
+	PluginTimeout() time.Duration
+	PluginURL() string
+	PluginToken() string
+	PluginTLSCertPath() string
+	PluginTLSKeyPath() string
+	PluginTLSCAPath() string
+	PluginMaxRetries() int
+	PluginChain() []string
+	PluginHealthCheckInterval() time.Duration
+	PluginRestartMaxBackoff() time.Duration
+}
+
+// AuditConfigurer defines the accessor methods for the audit log
+// configurations.
+type AuditConfigurer interface {
+	AuditLogPath() string
+}
+
+// ArchiveConfigurer defines the accessor methods for the archive
+// configurations.
+type ArchiveConfigurer interface {
+	ArchiveType() string
+	ArchiveNamespace() string
+	ArchivePath() string
+}
+
+// NotificationConfigurer defines the accessor methods for the global
+// notification configurations.
+type NotificationConfigurer interface {
+	NotificationWebhookURL() string
+	NotificationWebhookSecret() string
+	NotificationWebhookTemplate() string
+	NotificationEvents() []string
+	NotificationExpiryWarning() time.Duration
+	NotificationMaxRetries() int
+	NotificationArgoCDAnnotations() bool
+	NotificationSecretName() string
+	NotificationSecretNamespace() string
 }
 
 // MetricsConfigurer defines the accessor methods for metrics configurations.
@@ -98,6 +139,121 @@ func (c *Config) PluginPath() string {
 	return c.Plugin.Path
 }
 
+// PluginTimeout acessor method
+func (c *Config) PluginTimeout() time.Duration {
+	return c.Plugin.Timeout
+}
+
+// PluginURL acessor method
+func (c *Config) PluginURL() string {
+	return c.Plugin.URL
+}
+
+// PluginToken acessor method
+func (c *Config) PluginToken() string {
+	return c.Plugin.Token
+}
+
+// PluginTLSCertPath acessor method
+func (c *Config) PluginTLSCertPath() string {
+	return c.Plugin.TLSCertPath
+}
+
+// PluginTLSKeyPath acessor method
+func (c *Config) PluginTLSKeyPath() string {
+	return c.Plugin.TLSKeyPath
+}
+
+// PluginTLSCAPath acessor method
+func (c *Config) PluginTLSCAPath() string {
+	return c.Plugin.TLSCAPath
+}
+
+// PluginMaxRetries acessor method
+func (c *Config) PluginMaxRetries() int {
+	return c.Plugin.MaxRetries
+}
+
+// PluginChain acessor method
+func (c *Config) PluginChain() []string {
+	return c.Plugin.Chain
+}
+
+// PluginHealthCheckInterval acessor method
+func (c *Config) PluginHealthCheckInterval() time.Duration {
+	return c.Plugin.HealthCheckInterval
+}
+
+// PluginRestartMaxBackoff acessor method
+func (c *Config) PluginRestartMaxBackoff() time.Duration {
+	return c.Plugin.RestartMaxBackoff
+}
+
+// NotificationWebhookURL acessor method
+func (c *Config) NotificationWebhookURL() string {
+	return c.Notification.WebhookURL
+}
+
+// NotificationWebhookSecret acessor method
+func (c *Config) NotificationWebhookSecret() string {
+	return c.Notification.WebhookSecret
+}
+
+// NotificationWebhookTemplate acessor method
+func (c *Config) NotificationWebhookTemplate() string {
+	return c.Notification.WebhookTemplate
+}
+
+// NotificationEvents acessor method
+func (c *Config) NotificationEvents() []string {
+	return c.Notification.Events
+}
+
+// NotificationExpiryWarning acessor method
+func (c *Config) NotificationExpiryWarning() time.Duration {
+	return c.Notification.ExpiryWarning
+}
+
+// NotificationMaxRetries acessor method
+func (c *Config) NotificationMaxRetries() int {
+	return c.Notification.MaxRetries
+}
+
+// NotificationSecretName acessor method
+func (c *Config) NotificationSecretName() string {
+	return c.Notification.SecretName
+}
+
+// NotificationSecretNamespace acessor method
+func (c *Config) NotificationSecretNamespace() string {
+	return c.Notification.SecretNamespace
+}
+
+// AuditLogPath acessor method
+func (c *Config) AuditLogPath() string {
+	return c.Audit.Path
+}
+
+// ArchiveType acessor method
+func (c *Config) ArchiveType() string {
+	return c.Archive.Type
+}
+
+// ArchiveNamespace acessor method
+func (c *Config) ArchiveNamespace() string {
+	return c.Archive.Namespace
+}
+
+// ArchivePath acessor method
+func (c *Config) ArchivePath() string {
+	return c.Archive.Path
+}
+
+// NotificationArgoCDAnnotations acessor method
+func (c *Config) NotificationArgoCDAnnotations() bool {
+	return c.Notification.ArgoCDAnnotations
+}
+
 // ControllerAccessRequestTTL returns the time-to-live (TTL) duration for access
 // requests as configured in the Controller settings.
 func (c *Config) ControllerAccessRequestTTL() time.Duration {
@@ -119,12 +275,121 @@ type Config struct {
 	// Controller defines the controller configurations
 	Controller ControllerConfig `env:", prefix=EPHEMERAL_CONTROLLER_"`
 	Plugin     PluginConfig     `env:", prefix=EPHEMERAL_PLUGIN_"`
+	// Notification defines the global notification configurations
+	Notification NotificationConfig `env:", prefix=EPHEMERAL_NOTIFICATION_"`
+	// Audit defines the audit log configurations
+	Audit AuditConfig `env:", prefix=EPHEMERAL_AUDIT_"`
+	// Archive defines where AccessRequests are archived before deletion
+	Archive ArchiveConfig `env:", prefix=EPHEMERAL_ARCHIVE_"`
+}
+
+// ArchiveConfig defines where AccessRequests are archived before they are
+// deleted once the TTL is exceeded.
+type ArchiveConfig struct {
+	// Type is the archive storage.
+	// Possible values: configmap, file
+	// AccessRequests are not archived if not provided.
+	Type string `env:"TYPE"`
+	// Namespace is where the ConfigMaps are created when using the
+	// configmap archive.
+	Namespace string `env:"NAMESPACE"`
+	// Path is the directory where the files are written when using the
+	// file archive.
+	Path string `env:"PATH"`
+}
+
+// AuditConfig defines the audit log configurations
+type AuditConfig struct {
+	// Path is the file where the hash-chained audit log is appended. Use
+	// "stdout" to write the records to the standard output. The audit log
+	// is disabled if not provided.
+	Path string `env:"PATH"`
+}
+
+// NotificationConfig defines the webhook notified about the lifecycle of all
+// AccessRequests. Additional webhooks can be configured per AccessBinding.
+type NotificationConfig struct {
+	// WebhookURL is the URL receiving the notifications. Global
+	// notifications are disabled if not provided.
+	WebhookURL string `env:"WEBHOOK_URL"`
+	// WebhookSecret is the key used to sign the notification payloads with
+	// HMAC-SHA256. Payloads are not signed if not provided.
+	WebhookSecret string `env:"WEBHOOK_SECRET"`
+	// WebhookTemplate is the Go template used to render the JSON payload
+	// sent to the webhook. A default payload is sent if not provided.
+	WebhookTemplate string `env:"WEBHOOK_TEMPLATE"`
+	// Events is the comma separated list of events notified to the webhook.
+	// All events are notified if not provided.
+	Events []string `env:"EVENTS"`
+	// ExpiryWarning defines how long before the access expires the
+	// "expiring" notification is sent. Set to 0 to disable.
+	// Default: 15 minutes
+	ExpiryWarning time.Duration `env:"EXPIRY_WARNING, default=15m"`
+	// MaxRetries is the number of times a failed notification is retried
+	// before giving up.
+	// Default: 3
+	MaxRetries int `env:"MAX_RETRIES, default=3"`
+	// ArgoCDAnnotations enables writing the notifications as annotations in
+	// the Argo CD Applications so they can be consumed by Argo CD
+	// Notifications triggers.
+	// Default: false
+	ArgoCDAnnotations bool `env:"ARGOCD_ANNOTATIONS, default=false"`
+	// SecretName is the Secret holding the keys used to sign the payloads
+	// of the webhooks configured in the AccessBindings. It is the only
+	// Secret the controller is allowed to read.
+	// Default: controller-notification-secret
+	SecretName string `env:"SECRET_NAME, default=controller-notification-secret"`
+	// SecretNamespace is the namespace of the Secret defined by SecretName.
+	// It must be the controller namespace.
+	SecretNamespace string `env:"SECRET_NAMESPACE"`
 }
 
 // PluginConfig defines the plugin configuration
 type PluginConfig struct {
 	// Path must be the full path to the binary implementing the plugin interface
 	Path string `env:"PATH"`
+	// Timeout is the maximum duration of each call to the plugin. Calls
+	// exceeding it fail and are retried in the next reconciliation. Setting
+	// it to 0 disables the timeout.
+	// Valid time units are "ms", "s", "m", "h".
+	// Default: 30 seconds
+	Timeout time.Duration `env:"TIMEOUT, default=30s"`
+	// URL is the base URL of a remote AccessRequester service. The
+	// decisions are requested with HTTP calls to the service instead of
+	// launching a plugin binary. Can't be used with Path.
+	URL string `env:"URL"`
+	// Token is the bearer token sent to the remote AccessRequester service.
+	Token string `env:"TOKEN"`
+	// TLSCertPath is the path of the client certificate used to authenticate
+	// with the remote AccessRequester service using mTLS.
+	TLSCertPath string `env:"TLS_CERT_PATH"`
+	// TLSKeyPath is the path of the client certificate key.
+	TLSKeyPath string `env:"TLS_KEY_PATH"`
+	// TLSCAPath is the path of the CA bundle used to verify the remote
+	// AccessRequester service certificate. The system CAs are used if not
+	// provided.
+	TLSCAPath string `env:"TLS_CA_PATH"`
+	// MaxRetries is the number of times a failed call to the remote
+	// AccessRequester service is retried within the plugin timeout.
+	// Default: 3
+	MaxRetries int `env:"MAX_RETRIES, default=3"`
+	// Chain is a comma separated list of additional plugins in the format
+	// name=location, where location is the plugin binary path or the base URL
+	// of a remote AccessRequester service. All plugins must grant the access
+	// unless AccessBindings or RoleTemplates select specific plugins by name.
+	// The plugin configured with Path or URL is named "default".
+	Chain []string `env:"CHAIN"`
+	// HealthCheckInterval defines how often the plugin processes are pinged.
+	// Plugins not responding are relaunched. Not used by remote plugins.
+	// Valid time units are "ms", "s", "m", "h".
+	// Default: 10 seconds
+	HealthCheckInterval time.Duration `env:"HEALTH_CHECK_INTERVAL, default=10s"`
+	// RestartMaxBackoff is the maximum interval between failed attempts to
+	// relaunch a plugin process. The interval starts at one second and is
+	// doubled after each failed attempt.
+	// Valid time units are "ms", "s", "m", "h".
+	// Default: 5 minutes
+	RestartMaxBackoff time.Duration `env:"RESTART_MAX_BACKOFF, default=5m"`
 }
 
 // MetricsConfig defines the metrics configurations
@@ -176,7 +441,7 @@ type LogConfig struct {
 // String prints the config state
 func (c *Config) String() string {
 	return fmt.Sprintf(
-		"Metrics: [ Address: %s Secure: %t ] Log [ Level: %s Format: %s ] Controller [ EnableLeaderElection: %t HealthProbeAddress: %s EnableHTTP2: %t RequeueInterval: %s ] Plugin [ Path : %s ]",
+		"Metrics: [ Address: %s Secure: %t ] Log [ Level: %s Format: %s ] Controller [ EnableLeaderElection: %t HealthProbeAddress: %s EnableHTTP2: %t RequeueInterval: %s ] Plugin [ Path : %s URL: %s Chain: %s Timeout: %s ] Audit [ Path: %s ] Archive [ Type: %s Path: %s ]",
 		c.Metrics.Address,
 		c.Metrics.Secure,
 		c.Log.Level,
@@ -186,6 +451,12 @@ func (c *Config) String() string {
 		c.Controller.EnableHTTP2,
 		c.Controller.RequeueInterval,
 		c.Plugin.Path,
+		c.Plugin.URL,
+		c.Plugin.Chain,
+		c.Plugin.Timeout,
+		c.Audit.Path,
+		c.Archive.Type,
+		c.Archive.Path,
 	)
 }
 
@@ -25,8 +25,21 @@ func TestConfiguration(t *testing.T) {
 		assert.False(t, config.ControllerEnableHTTP2())
 		assert.Equal(t, time.Minute*3, config.ControllerRequeueInterval())
 		assert.Empty(t, config.PluginPath())
+		assert.Equal(t, time.Second*30, config.PluginTimeout())
+		assert.Empty(t, config.PluginURL())
+		assert.Equal(t, 3, config.PluginMaxRetries())
+		assert.Empty(t, config.PluginChain())
+		assert.Equal(t, time.Second*10, config.PluginHealthCheckInterval())
+		assert.Equal(t, time.Minute*5, config.PluginRestartMaxBackoff())
 		assert.Equal(t, time.Hour*4, config.ControllerRequestTimeout())
 		assert.Equal(t, time.Nanosecond*0, config.ControllerAccessRequestTTL())
+		assert.Empty(t, config.NotificationWebhookURL())
+		assert.Empty(t, config.NotificationEvents())
+		assert.Equal(t, time.Minute*15, config.NotificationExpiryWarning())
+		assert.Equal(t, 3, config.NotificationMaxRetries())
+		assert.False(t, config.NotificationArgoCDAnnotations())
+		assert.Empty(t, config.AuditLogPath())
+		assert.Empty(t, config.ArchiveType())
 	})
 	t.Run("will validate if env vars are set properly", func(t *testing.T) {
 		// Given
@@ -42,6 +55,26 @@ func TestConfiguration(t *testing.T) {
 		t.Setenv("EPHEMERAL_CONTROLLER_REQUEST_TIMEOUT", "1h")
 		t.Setenv("EPHEMERAL_CONTROLLER_ACCESS_REQUEST_TTL", "10h")
 		t.Setenv("EPHEMERAL_PLUGIN_PATH", "/usr/local/bin/plugin")
+		t.Setenv("EPHEMERAL_PLUGIN_TIMEOUT", "2m")
+		t.Setenv("EPHEMERAL_PLUGIN_URL", "https://approvals.example.com/ephemeral-access")
+		t.Setenv("EPHEMERAL_PLUGIN_TOKEN", "some-token")
+		t.Setenv("EPHEMERAL_PLUGIN_TLS_CERT_PATH", "/etc/plugin-tls/tls.crt")
+		t.Setenv("EPHEMERAL_PLUGIN_TLS_KEY_PATH", "/etc/plugin-tls/tls.key")
+		t.Setenv("EPHEMERAL_PLUGIN_TLS_CA_PATH", "/etc/plugin-tls/ca.crt")
+		t.Setenv("EPHEMERAL_PLUGIN_MAX_RETRIES", "5")
+		t.Setenv("EPHEMERAL_PLUGIN_CHAIN", "freeze=/plugins/freeze,servicenow=https://approvals.example.com")
+		t.Setenv("EPHEMERAL_PLUGIN_HEALTH_CHECK_INTERVAL", "30s")
+		t.Setenv("EPHEMERAL_PLUGIN_RESTART_MAX_BACKOFF", "1m")
+		t.Setenv("EPHEMERAL_NOTIFICATION_WEBHOOK_URL", "https://hooks.example.com/access")
+		t.Setenv("EPHEMERAL_NOTIFICATION_WEBHOOK_SECRET", "some-secret")
+		t.Setenv("EPHEMERAL_NOTIFICATION_EVENTS", "granted,expiring")
+		t.Setenv("EPHEMERAL_NOTIFICATION_EXPIRY_WARNING", "5m")
+		t.Setenv("EPHEMERAL_NOTIFICATION_MAX_RETRIES", "5")
+		t.Setenv("EPHEMERAL_NOTIFICATION_ARGOCD_ANNOTATIONS", "true")
+		t.Setenv("EPHEMERAL_AUDIT_PATH", "/var/log/ephemeral-access/audit.jsonl")
+		t.Setenv("EPHEMERAL_ARCHIVE_TYPE", "configmap")
+		t.Setenv("EPHEMERAL_ARCHIVE_NAMESPACE", "argocd-ephemeral-access")
+		t.Setenv("EPHEMERAL_ARCHIVE_PATH", "/var/lib/ephemeral-access/archive")
 
 		// When
 		config, err := config.ReadEnvConfigs()
@@ -58,7 +91,27 @@ func TestConfiguration(t *testing.T) {
 		assert.True(t, config.ControllerEnableHTTP2())
 		assert.Equal(t, time.Second, config.ControllerRequeueInterval())
 		assert.Equal(t, "/usr/local/bin/plugin", config.PluginPath())
+		assert.Equal(t, time.Minute*2, config.PluginTimeout())
+		assert.Equal(t, "https://approvals.example.com/ephemeral-access", config.PluginURL())
+		assert.Equal(t, "some-token", config.PluginToken())
+		assert.Equal(t, "/etc/plugin-tls/tls.crt", config.PluginTLSCertPath())
+		assert.Equal(t, "/etc/plugin-tls/tls.key", config.PluginTLSKeyPath())
+		assert.Equal(t, "/etc/plugin-tls/ca.crt", config.PluginTLSCAPath())
+		assert.Equal(t, 5, config.PluginMaxRetries())
+		assert.Equal(t, []string{"freeze=/plugins/freeze", "servicenow=https://approvals.example.com"}, config.PluginChain())
+		assert.Equal(t, time.Second*30, config.PluginHealthCheckInterval())
+		assert.Equal(t, time.Minute, config.PluginRestartMaxBackoff())
 		assert.Equal(t, time.Hour*1, config.ControllerRequestTimeout())
 		assert.Equal(t, time.Hour*10, config.ControllerAccessRequestTTL())
+		assert.Equal(t, "https://hooks.example.com/access", config.NotificationWebhookURL())
+		assert.Equal(t, "some-secret", config.NotificationWebhookSecret())
+		assert.Equal(t, []string{"granted", "expiring"}, config.NotificationEvents())
+		assert.Equal(t, time.Minute*5, config.NotificationExpiryWarning())
+		assert.Equal(t, 5, config.NotificationMaxRetries())
+		assert.True(t, config.NotificationArgoCDAnnotations())
+		assert.Equal(t, "/var/log/ephemeral-access/audit.jsonl", config.AuditLogPath())
+		assert.Equal(t, "configmap", config.ArchiveType())
+		assert.Equal(t, "argocd-ephemeral-access", config.ArchiveNamespace())
+		assert.Equal(t, "/var/lib/ephemeral-access/archive", config.ArchivePath())
 	})
 }
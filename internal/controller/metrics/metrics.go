@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"time"
 
@@ -61,6 +62,22 @@ var (
 		},
 		[]string{"operation", "result"},
 	)
+
+	breakGlassAccessTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "break_glass_access_total",
+			Help: "Total number of break-glass accesses granted by role",
+		},
+		[]string{"role_namespace", "role_name"},
+	)
+
+	pluginUp = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "plugin_up",
+			Help: "Whether the AccessRequester plugin process is healthy (1) or not (0)",
+		},
+		[]string{"plugin"},
+	)
 )
 
 func newAccessRequestCollector(ctx context.Context, reader client.Reader) prometheus.Collector {
@@ -78,6 +95,8 @@ func Register(ctx context.Context, reader client.Reader) {
 	register.Do(func() {
 		metrics.Registry.MustRegister(accessRequestStatusTotal)
 		metrics.Registry.MustRegister(pluginOperationsTotal)
+		metrics.Registry.MustRegister(breakGlassAccessTotal)
+		metrics.Registry.MustRegister(pluginUp)
 		metrics.Registry.MustRegister(newAccessRequestCollector(ctx, reader))
 	})
 }
@@ -147,7 +166,23 @@ func IncrementAccessRequestCounter(status api.Status) {
 	accessRequestStatusTotal.WithLabelValues(string(status)).Inc()
 }
 
-// RecordPluginOperationResult records the result of a plugin operation
+// IncrementBreakGlassCounter increments the counter of break-glass accesses
+// granted for the role of the given AccessRequest
+func IncrementBreakGlassCounter(ar *api.AccessRequest) {
+	breakGlassAccessTotal.WithLabelValues(ar.Spec.Role.TemplateRef.Namespace, ar.Spec.Role.TemplateRef.Name).Inc()
+}
+
+// SetPluginUp sets the health of the plugin with the given name
+func SetPluginUp(name string, up bool) {
+	value := 0.0
+	if up {
+		value = 1
+	}
+	pluginUp.WithLabelValues(name).Set(value)
+}
+
+// RecordPluginOperationResult records the result of a plugin operation. Errors
+// caused by plugin calls exceeding their deadline are recorded as timeout.
 func RecordPluginOperationResult(operation string, result interface{}) {
 	var resultString string
 	switch r := result.(type) {
@@ -157,6 +192,9 @@ func RecordPluginOperationResult(operation string, result interface{}) {
 		resultString = string(r)
 	case error:
 		resultString = "error"
+		if errors.Is(r, plugin.ErrTimeout) {
+			resultString = "timeout"
+		}
 	default:
 		resultString = "unknown"
 	}
@@ -3,6 +3,7 @@ package metrics
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -48,6 +49,46 @@ func TestIncrementAccessRequestCounter(t *testing.T) {
 	}
 }
 
+func TestSetPluginUp(t *testing.T) {
+	pluginUp.Reset()
+
+	expected := `
+	# HELP plugin_up Whether the AccessRequester plugin process is healthy (1) or not (0)
+	# TYPE plugin_up gauge
+	plugin_up{plugin="default"} 1
+	plugin_up{plugin="freeze"} 0
+	`
+
+	SetPluginUp("default", true)
+	SetPluginUp("freeze", true)
+	SetPluginUp("freeze", false)
+
+	if err := testutil.CollectAndCompare(pluginUp, strings.NewReader(expected), "plugin_up"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestIncrementBreakGlassCounter(t *testing.T) {
+	breakGlassAccessTotal.Reset()
+
+	expected := `
+	# HELP break_glass_access_total Total number of break-glass accesses granted by role
+	# TYPE break_glass_access_total counter
+	break_glass_access_total{role_name="role1",role_namespace="roleNs"} 2
+	break_glass_access_total{role_name="role2",role_namespace="roleNs"} 1
+	`
+	ar1 := utils.NewAccessRequest("ar1", "ns", "app", "appNs", "role1", "roleNs", "user1", "")
+	ar2 := utils.NewAccessRequest("ar2", "ns", "app", "appNs", "role2", "roleNs", "user2", "")
+
+	IncrementBreakGlassCounter(ar1)
+	IncrementBreakGlassCounter(ar1)
+	IncrementBreakGlassCounter(ar2)
+
+	if err := testutil.CollectAndCompare(breakGlassAccessTotal, strings.NewReader(expected), "break_glass_access_total"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
 func TestUpdateAccessRequests(t *testing.T) {
 	readerMock := mocks.MockReader{}
 
@@ -148,6 +189,12 @@ func TestRecordPluginOperationResult(t *testing.T) {
 			result:         errors.New("test error"),
 			expectedResult: "error",
 		},
+		{
+			name:           "timeout result",
+			operation:      "revoke_access",
+			result:         fmt.Errorf("error invoking plugin: %w", plugin.ErrTimeout),
+			expectedResult: "timeout",
+		},
 		{
 			name:           "unknown result",
 			operation:      "grant_access",
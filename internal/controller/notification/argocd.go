@@ -0,0 +1,68 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argocd "github.com/argoproj-labs/argocd-ephemeral-access/api/argoproj/v1alpha1"
+	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
+)
+
+// ArgoCDSink writes the notifications as annotations in the Argo CD
+// Application targeted by the AccessRequest. This allows Argo CD
+// Notifications triggers to notify the Application subscribers.
+type ArgoCDSink struct {
+	writer client.Writer
+}
+
+// NewArgoCDSink returns an ArgoCDSink patching the Applications with the
+// given writer.
+func NewArgoCDSink(writer client.Writer) *ArgoCDSink {
+	return &ArgoCDSink{writer: writer}
+}
+
+// Accepts returns true for all events as filtering is done by the Argo CD
+// Notifications triggers.
+func (s *ArgoCDSink) Accepts(api.NotificationEvent) bool {
+	return true
+}
+
+// Send will replace the access annotations in the Application referenced by
+// the notified AccessRequest.
+func (s *ArgoCDSink) Send(ctx context.Context, n *Notification) error {
+	ar := n.AccessRequest
+	var expiresAt *string
+	if ar.Status.ExpiresAt != nil && (n.Event == api.NotificationGranted || n.Event == api.NotificationExpiring) {
+		value := ar.Status.ExpiresAt.UTC().Format(time.RFC3339)
+		expiresAt = &value
+	}
+	annotations := map[string]*string{
+		api.AccessEventAnnotation:     ptr.To(string(n.Event)),
+		api.AccessEventIDAnnotation:   ptr.To(fmt.Sprintf("%s/%s/%s/%d", ar.GetNamespace(), ar.GetName(), n.Event, n.Time.UnixNano())),
+		api.AccessRequestAnnotation:   ptr.To(fmt.Sprintf("%s/%s", ar.GetNamespace(), ar.GetName())),
+		api.AccessUserAnnotation:      ptr.To(ar.Spec.Subject.Username),
+		api.AccessRoleAnnotation:      ptr.To(ar.Spec.Role.TemplateRef.Name),
+		api.AccessExpiresAtAnnotation: expiresAt,
+		api.AccessMessageAnnotation:   ptr.To(n.Message),
+	}
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{"annotations": annotations},
+	})
+	if err != nil {
+		return fmt.Errorf("error marshaling application patch: %w", err)
+	}
+	app := &argocd.Application{}
+	app.SetName(ar.Spec.Application.Name)
+	app.SetNamespace(ar.Spec.Application.Namespace)
+	err = s.writer.Patch(ctx, app, client.RawPatch(types.MergePatchType, patch))
+	if err != nil {
+		return fmt.Errorf("error patching application %s/%s: %w", app.GetNamespace(), app.GetName(), err)
+	}
+	return nil
+}
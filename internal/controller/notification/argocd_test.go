@@ -0,0 +1,90 @@
+package notification_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	argocd "github.com/argoproj-labs/argocd-ephemeral-access/api/argoproj/v1alpha1"
+	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
+	"github.com/argoproj-labs/argocd-ephemeral-access/internal/controller/notification"
+)
+
+func TestArgoCDSink(t *testing.T) {
+	setup := func(t *testing.T) client.Client {
+		t.Helper()
+		scheme := runtime.NewScheme()
+		require.NoError(t, argocd.AddToScheme(scheme))
+		app := &argocd.Application{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "some-app",
+				Namespace:   "argocd",
+				Annotations: map[string]string{"notifications.argoproj.io/subscribe.on-access-granted.slack": "some-channel"},
+			},
+		}
+		return fake.NewClientBuilder().WithScheme(scheme).WithObjects(app).Build()
+	}
+	getAnnotations := func(t *testing.T, k8sClient client.Client) map[string]string {
+		t.Helper()
+		app := &argocd.Application{}
+		require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Name: "some-app", Namespace: "argocd"}, app))
+		return app.GetAnnotations()
+	}
+	t.Run("will annotate the application", func(t *testing.T) {
+		// Given
+		k8sClient := setup(t)
+		sink := notification.NewArgoCDSink(k8sClient)
+
+		// When
+		err := sink.Send(context.Background(), newNotification(api.NotificationGranted))
+
+		// Then
+		require.NoError(t, err)
+		annotations := getAnnotations(t, k8sClient)
+		assert.Equal(t, "some-channel", annotations["notifications.argoproj.io/subscribe.on-access-granted.slack"])
+		assert.Equal(t, "granted", annotations[api.AccessEventAnnotation])
+		assert.Contains(t, annotations[api.AccessEventIDAnnotation], "ephemeral/some-ar/granted/")
+		assert.Equal(t, "ephemeral/some-ar", annotations[api.AccessRequestAnnotation])
+		assert.Equal(t, "some-user", annotations[api.AccessUserAnnotation])
+		assert.Equal(t, "some-role", annotations[api.AccessRoleAnnotation])
+		assert.Equal(t, "2024-01-01T10:00:00Z", annotations[api.AccessExpiresAtAnnotation])
+		assert.Equal(t, "some message", annotations[api.AccessMessageAnnotation])
+	})
+	t.Run("will remove the expiration once the access is concluded", func(t *testing.T) {
+		// Given
+		k8sClient := setup(t)
+		sink := notification.NewArgoCDSink(k8sClient)
+		require.NoError(t, sink.Send(context.Background(), newNotification(api.NotificationGranted)))
+		granted := getAnnotations(t, k8sClient)
+
+		// When
+		err := sink.Send(context.Background(), newNotification(api.NotificationExpired))
+
+		// Then
+		require.NoError(t, err)
+		annotations := getAnnotations(t, k8sClient)
+		assert.Equal(t, "expired", annotations[api.AccessEventAnnotation])
+		assert.NotEqual(t, granted[api.AccessEventIDAnnotation], annotations[api.AccessEventIDAnnotation])
+		assert.NotContains(t, annotations, api.AccessExpiresAtAnnotation)
+	})
+	t.Run("will return error if the application doesn't exist", func(t *testing.T) {
+		// Given
+		k8sClient := setup(t)
+		sink := notification.NewArgoCDSink(k8sClient)
+		n := newNotification(api.NotificationGranted)
+		n.AccessRequest.Spec.Application.Name = "missing-app"
+
+		// When
+		err := sink.Send(context.Background(), n)
+
+		// Then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "error patching application argocd/missing-app")
+	})
+}
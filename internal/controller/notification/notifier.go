@@ -0,0 +1,204 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
+	"github.com/argoproj-labs/argocd-ephemeral-access/internal/controller/config"
+)
+
+// Notification defines the data sent to the sinks. It is also the data
+// available in the webhook templates.
+type Notification struct {
+	// Event is the notified lifecycle event
+	Event api.NotificationEvent
+	// Message contains the details about the event (e.g. the plugin message)
+	Message string
+	// Time is when the event happened
+	Time time.Time
+	// AccessRequest is the AccessRequest associated with the event
+	AccessRequest *api.AccessRequest
+}
+
+// Sink defines a destination of notifications.
+type Sink interface {
+	// Accepts returns true if the given event must be sent to this sink.
+	Accepts(event api.NotificationEvent) bool
+	// Send delivers the given notification. Implementations are responsible
+	// for retrying failed deliveries.
+	Send(ctx context.Context, n *Notification) error
+}
+
+// Notifier sends notifications about the AccessRequests lifecycle to the
+// sinks configured globally and in the AccessBindings.
+type Notifier struct {
+	reader          client.Reader
+	secretName      string
+	secretNamespace string
+	sinks           []Sink
+	expiryWarning   time.Duration
+	maxRetries      int
+}
+
+// NewNotifier returns a Notifier configured with the global webhook defined
+// in the given cfg. The reader is used to retrieve the notification Secret
+// holding the keys referenced by the AccessBinding webhooks.
+func NewNotifier(reader client.Reader, cfg config.NotificationConfigurer) (*Notifier, error) {
+	n := &Notifier{
+		reader:          reader,
+		secretName:      cfg.NotificationSecretName(),
+		secretNamespace: cfg.NotificationSecretNamespace(),
+		expiryWarning:   cfg.NotificationExpiryWarning(),
+		maxRetries:      cfg.NotificationMaxRetries(),
+	}
+	if cfg.NotificationWebhookURL() == "" {
+		return n, nil
+	}
+	events := []api.NotificationEvent{}
+	for _, event := range cfg.NotificationEvents() {
+		events = append(events, api.NotificationEvent(event))
+	}
+	opts := []WebhookOption{WithEvents(events...), WithMaxRetries(n.maxRetries)}
+	if cfg.NotificationWebhookSecret() != "" {
+		opts = append(opts, WithSecret([]byte(cfg.NotificationWebhookSecret())))
+	}
+	sink, err := NewWebhookSink(cfg.NotificationWebhookURL(), cfg.NotificationWebhookTemplate(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating global webhook: %w", err)
+	}
+	n.sinks = append(n.sinks, sink)
+	return n, nil
+}
+
+// AddSink will register the given sink to receive the notifications of all
+// AccessRequests.
+func (n *Notifier) AddSink(sink Sink) {
+	n.sinks = append(n.sinks, sink)
+}
+
+// Enabled returns true if notifications are configured for AccessRequests
+// created from the given binding. The binding can be nil.
+func (n *Notifier) Enabled(binding *api.AccessBinding) bool {
+	if len(n.sinks) > 0 {
+		return true
+	}
+	return binding != nil && binding.Spec.Notifications != nil && len(binding.Spec.Notifications.Webhooks) > 0
+}
+
+// ExpiryWarning returns how long before the access expires the "expiring"
+// event must be notified for AccessRequests created from the given binding.
+// Returns nil if notifications are not enabled or the warning is disabled.
+func (n *Notifier) ExpiryWarning(binding *api.AccessBinding) *metav1.Duration {
+	if !n.Enabled(binding) {
+		return nil
+	}
+	warning := n.expiryWarning
+	if binding != nil && binding.Spec.Notifications != nil && binding.Spec.Notifications.ExpiryWarning != nil {
+		warning = binding.Spec.Notifications.ExpiryWarning.Duration
+	}
+	if warning <= 0 {
+		return nil
+	}
+	return &metav1.Duration{Duration: warning}
+}
+
+// Notify will send the given event about the ar to all sinks accepting it.
+// The binding is the AccessBinding used to create the ar and can be nil. All
+// sinks are invoked even if some of them fail. Returns the errors of all
+// failed sinks.
+func (n *Notifier) Notify(ctx context.Context, event api.NotificationEvent, ar *api.AccessRequest, binding *api.AccessBinding, message string) error {
+	sinks, err := n.bindingSinks(ctx, binding)
+	errs := []error{err}
+	sinks = append(sinks, n.sinks...)
+	notification := &Notification{
+		Event:         event,
+		Message:       message,
+		Time:          time.Now().UTC(),
+		AccessRequest: ar,
+	}
+	for _, sink := range sinks {
+		if !sink.Accepts(event) {
+			continue
+		}
+		errs = append(errs, sink.Send(ctx, notification))
+	}
+	return errors.Join(errs...)
+}
+
+// bindingSinks returns the sinks defined in the given binding. Webhooks that
+// can't be configured are skipped and reported in the returned error.
+func (n *Notifier) bindingSinks(ctx context.Context, binding *api.AccessBinding) ([]Sink, error) {
+	if binding == nil || binding.Spec.Notifications == nil {
+		return nil, nil
+	}
+	sinks := []Sink{}
+	errs := []error{}
+	for _, webhook := range binding.Spec.Notifications.Webhooks {
+		opts := []WebhookOption{WithEvents(webhook.Events...), WithMaxRetries(n.maxRetries)}
+		if webhook.SecretKey != "" {
+			secret, err := n.getSecretKey(ctx, webhook.SecretKey)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("error configuring webhook %s: %w", webhook.URL, err))
+				continue
+			}
+			opts = append(opts, WithSecret(secret))
+		}
+		sink, err := NewWebhookSink(webhook.URL, webhook.Template, opts...)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error configuring webhook %s: %w", webhook.URL, err))
+			continue
+		}
+		sinks = append(sinks, sink)
+	}
+	return sinks, errors.Join(errs...)
+}
+
+// getSecretKey returns the value of the given key in the notification Secret.
+// Only this Secret can be read so AccessBindings can't make the controller
+// sign payloads with arbitrary Secrets.
+func (n *Notifier) getSecretKey(ctx context.Context, key string) ([]byte, error) {
+	secret := &corev1.Secret{}
+	err := n.reader.Get(ctx, client.ObjectKey{Namespace: n.secretNamespace, Name: n.secretName}, secret)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving secret %s/%s: %w", n.secretNamespace, n.secretName, err)
+	}
+	value, ok := secret.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("key %s not found in secret %s/%s", key, n.secretNamespace, n.secretName)
+	}
+	return value, nil
+}
+
+// EventFromStatus returns the event notified when an AccessRequest
+// transitions to the given status. Returns false if the status isn't
+// notified.
+func EventFromStatus(status api.Status) (api.NotificationEvent, bool) {
+	switch status {
+	case api.RequestedStatus:
+		return api.NotificationRequested, true
+	case api.ScheduledStatus:
+		return api.NotificationScheduled, true
+	case api.GrantedStatus:
+		return api.NotificationGranted, true
+	case api.DeniedStatus:
+		return api.NotificationDenied, true
+	case api.ExpiredStatus:
+		return api.NotificationExpired, true
+	case api.RevokedStatus:
+		return api.NotificationRevoked, true
+	case api.CancelledStatus:
+		return api.NotificationCancelled, true
+	case api.InvalidStatus:
+		return api.NotificationInvalid, true
+	case api.TimeoutStatus:
+		return api.NotificationTimeout, true
+	}
+	return "", false
+}
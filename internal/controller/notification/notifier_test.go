@@ -0,0 +1,132 @@
+package notification_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
+	"github.com/argoproj-labs/argocd-ephemeral-access/internal/controller/notification"
+	"github.com/argoproj-labs/argocd-ephemeral-access/test/mocks"
+)
+
+func TestNotifier(t *testing.T) {
+	newConfig := func(t *testing.T, url string) *mocks.MockConfigurer {
+		t.Helper()
+		cfg := mocks.NewMockConfigurer(t)
+		cfg.EXPECT().NotificationExpiryWarning().Return(15 * time.Minute)
+		cfg.EXPECT().NotificationMaxRetries().Return(0)
+		cfg.EXPECT().NotificationSecretName().Return("controller-notification-secret")
+		cfg.EXPECT().NotificationSecretNamespace().Return("controller-ns")
+		cfg.EXPECT().NotificationWebhookURL().Return(url)
+		if url != "" {
+			cfg.EXPECT().NotificationEvents().Return([]string{"granted"})
+			cfg.EXPECT().NotificationWebhookSecret().Return("global-secret")
+			cfg.EXPECT().NotificationWebhookTemplate().Return("")
+		}
+		return cfg
+	}
+	newReader := func(t *testing.T, objs ...client.Object) client.Reader {
+		t.Helper()
+		scheme := runtime.NewScheme()
+		require.NoError(t, corev1.AddToScheme(scheme))
+		return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	}
+	newBinding := func(webhooks ...api.WebhookNotification) *api.AccessBinding {
+		return &api.AccessBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "some-binding", Namespace: "ephemeral"},
+			Spec: api.AccessBindingSpec{
+				Notifications: &api.NotificationPolicy{Webhooks: webhooks},
+			},
+		}
+	}
+	ar := newNotification(api.NotificationGranted).AccessRequest
+
+	t.Run("will notify the global and binding webhooks", func(t *testing.T) {
+		// Given
+		global, globalRequests := newServer(t)
+		binding, bindingRequests := newServer(t)
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "controller-notification-secret", Namespace: "controller-ns"},
+			Data:       map[string][]byte{"binding.key": []byte("binding-secret")},
+		}
+		notifier, err := notification.NewNotifier(newReader(t, secret), newConfig(t, global.URL))
+		require.NoError(t, err)
+		ab := newBinding(api.WebhookNotification{
+			URL:       binding.URL,
+			SecretKey: "binding.key",
+		})
+
+		// When
+		err = notifier.Notify(context.Background(), api.NotificationGranted, ar, ab, "some message")
+
+		// Then
+		require.NoError(t, err)
+		req := <-globalRequests
+		assert.Equal(t, "sha256="+notification.Sign([]byte("global-secret"), req.body), req.header.Get(notification.SignatureHeader))
+		req = <-bindingRequests
+		assert.Equal(t, "sha256="+notification.Sign([]byte("binding-secret"), req.body), req.header.Get(notification.SignatureHeader))
+	})
+	t.Run("will only notify the webhooks accepting the event", func(t *testing.T) {
+		// Given
+		global, globalRequests := newServer(t)
+		binding, bindingRequests := newServer(t)
+		notifier, err := notification.NewNotifier(newReader(t), newConfig(t, global.URL))
+		require.NoError(t, err)
+		ab := newBinding(api.WebhookNotification{
+			URL:    binding.URL,
+			Events: []api.NotificationEvent{api.NotificationDenied},
+		})
+
+		// When
+		err = notifier.Notify(context.Background(), api.NotificationDenied, ar, ab, "")
+
+		// Then
+		require.NoError(t, err)
+		assert.Empty(t, globalRequests)
+		assert.Len(t, bindingRequests, 1)
+	})
+	t.Run("will notify the remaining webhooks if one fails", func(t *testing.T) {
+		// Given
+		failing, _ := newServer(t, http.StatusBadRequest)
+		binding, bindingRequests := newServer(t)
+		notifier, err := notification.NewNotifier(newReader(t), newConfig(t, ""))
+		require.NoError(t, err)
+		ab := newBinding(
+			api.WebhookNotification{URL: failing.URL},
+			api.WebhookNotification{URL: binding.URL, SecretKey: "missing.key"},
+			api.WebhookNotification{URL: binding.URL},
+		)
+
+		// When
+		err = notifier.Notify(context.Background(), api.NotificationGranted, ar, ab, "")
+
+		// Then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "status 400")
+		assert.Contains(t, err.Error(), "error retrieving secret controller-ns/controller-notification-secret")
+		assert.Len(t, bindingRequests, 1)
+	})
+	t.Run("will return the expiry warning", func(t *testing.T) {
+		// Given
+		notifier, err := notification.NewNotifier(newReader(t), newConfig(t, ""))
+		require.NoError(t, err)
+		ab := newBinding(api.WebhookNotification{URL: "http://localhost"})
+		overridden := newBinding(api.WebhookNotification{URL: "http://localhost"})
+		overridden.Spec.Notifications.ExpiryWarning = &metav1.Duration{Duration: 5 * time.Minute}
+
+		// Then
+		assert.Nil(t, notifier.ExpiryWarning(nil), "must be nil if no webhook is configured")
+		assert.Equal(t, 15*time.Minute, notifier.ExpiryWarning(ab).Duration)
+		assert.Equal(t, 5*time.Minute, notifier.ExpiryWarning(overridden).Duration)
+	})
+}
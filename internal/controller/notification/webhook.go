@@ -0,0 +1,187 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"text/template"
+	"time"
+
+	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
+	"github.com/argoproj-labs/argocd-ephemeral-access/internal/httpretry"
+)
+
+const (
+	// EventHeader is the HTTP header containing the notified event
+	EventHeader = "X-Ephemeral-Access-Event"
+	// SignatureHeader is the HTTP header containing the HMAC-SHA256
+	// signature of the payload in the format "sha256=<hex digest>"
+	SignatureHeader = "X-Ephemeral-Access-Signature"
+
+	defaultBackoff = time.Second
+)
+
+// DefaultWebhookTemplate is the template used to render the payload sent to
+// webhooks that don't define a custom template.
+const DefaultWebhookTemplate = `{
+  "event": {{ json .Event }},
+  "message": {{ json .Message }},
+  "time": {{ json .Time }},
+  "accessRequest": {
+    "name": {{ json .AccessRequest.Name }},
+    "namespace": {{ json .AccessRequest.Namespace }},
+    "username": {{ json .AccessRequest.Spec.Subject.Username }},
+    "role": {{ json .AccessRequest.Spec.Role.TemplateRef.Name }},
+    "application": {{ json .AccessRequest.Spec.Application.Name }},
+    "applicationNamespace": {{ json .AccessRequest.Spec.Application.Namespace }},
+    "project": {{ json .AccessRequest.Status.TargetProject }},
+    "status": {{ json .AccessRequest.Status.RequestState }},
+    "justification": {{ json .AccessRequest.Spec.Justification }},
+    "expiresAt": {{ json .AccessRequest.Status.ExpiresAt }}
+  }
+}`
+
+// WebhookSink sends notifications as JSON payloads to an HTTP endpoint.
+type WebhookSink struct {
+	url        string
+	events     []api.NotificationEvent
+	template   *template.Template
+	secret     []byte
+	client     *http.Client
+	maxRetries int
+	backoff    time.Duration
+}
+
+// WebhookOption defines an optional configuration of the WebhookSink.
+type WebhookOption func(*WebhookSink)
+
+// WithEvents will only send the given events to the webhook. All events are
+// sent if not provided.
+func WithEvents(events ...api.NotificationEvent) WebhookOption {
+	return func(w *WebhookSink) {
+		w.events = events
+	}
+}
+
+// WithSecret configures the key used to sign the payloads.
+func WithSecret(secret []byte) WebhookOption {
+	return func(w *WebhookSink) {
+		w.secret = secret
+	}
+}
+
+// WithMaxRetries configures how many times a failed request is retried.
+func WithMaxRetries(maxRetries int) WebhookOption {
+	return func(w *WebhookSink) {
+		w.maxRetries = maxRetries
+	}
+}
+
+// WithBackoff configures the initial interval between retries. The interval
+// is doubled after each attempt.
+func WithBackoff(backoff time.Duration) WebhookOption {
+	return func(w *WebhookSink) {
+		w.backoff = backoff
+	}
+}
+
+// WithHTTPClient configures the client used to send the requests.
+func WithHTTPClient(client *http.Client) WebhookOption {
+	return func(w *WebhookSink) {
+		w.client = client
+	}
+}
+
+// NewWebhookSink returns a WebhookSink sending notifications to the given url
+// with the payload rendered by the given tmpl. The DefaultWebhookTemplate is
+// used if tmpl is empty. Returns an error if the template is invalid.
+func NewWebhookSink(url, tmpl string, opts ...WebhookOption) (*WebhookSink, error) {
+	if url == "" {
+		return nil, errors.New("webhook url cannot be empty")
+	}
+	if tmpl == "" {
+		tmpl = DefaultWebhookTemplate
+	}
+	t, err := template.New("payload").Funcs(template.FuncMap{"json": toJSON}).Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing webhook template: %w", err)
+	}
+	w := &WebhookSink{
+		url:      url,
+		template: t,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		backoff:  defaultBackoff,
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w, nil
+}
+
+// Accepts returns true if the given event must be sent to this webhook.
+func (w *WebhookSink) Accepts(event api.NotificationEvent) bool {
+	return len(w.events) == 0 || slices.Contains(w.events, event)
+}
+
+// Send will post the given notification to the webhook. Requests failing
+// with network errors, 429 or 5xx responses are retried with exponential
+// backoff. Returns the last error if all attempts fail.
+func (w *WebhookSink) Send(ctx context.Context, n *Notification) error {
+	payload, err := w.render(n)
+	if err != nil {
+		return err
+	}
+	header := http.Header{}
+	header.Set(EventHeader, string(n.Event))
+	if len(w.secret) > 0 {
+		header.Set(SignatureHeader, "sha256="+Sign(w.secret, payload))
+	}
+	err = httpretry.Do(ctx, w.maxRetries, w.backoff, func(ctx context.Context) error {
+		_, err := httpretry.Post(ctx, w.client, w.url, header, payload)
+		return err
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("notification cancelled: %w", ctx.Err())
+		}
+		return fmt.Errorf("error sending notification: %w", err)
+	}
+	return nil
+}
+
+// render will execute the webhook template with the given notification and
+// validate that the result is a valid JSON document.
+func (w *WebhookSink) render(n *Notification) ([]byte, error) {
+	var buf bytes.Buffer
+	err := w.template.Execute(&buf, n)
+	if err != nil {
+		return nil, fmt.Errorf("error rendering webhook template: %w", err)
+	}
+	if !json.Valid(buf.Bytes()) {
+		return nil, errors.New("rendered webhook payload is not a valid JSON document")
+	}
+	return buf.Bytes(), nil
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of the given payload. Receivers
+// can use it to validate the SignatureHeader.
+func Sign(secret, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func toJSON(v any) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
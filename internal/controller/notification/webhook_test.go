@@ -0,0 +1,198 @@
+package notification_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
+	"github.com/argoproj-labs/argocd-ephemeral-access/internal/controller/notification"
+	"github.com/argoproj-labs/argocd-ephemeral-access/test/utils"
+)
+
+func newNotification(event api.NotificationEvent) *notification.Notification {
+	ar := utils.NewAccessRequest("some-ar", "ephemeral", "some-app", "argocd", "some-role", "ephemeral", "user-id", "some-user")
+	ar.Spec.Justification = `needs "quotes" escaped`
+	ar.Status.TargetProject = "some-project"
+	ar.Status.RequestState = api.GrantedStatus
+	ar.Status.ExpiresAt = &metav1.Time{Time: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)}
+	return &notification.Notification{
+		Event:         event,
+		Message:       "some message",
+		Time:          time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+		AccessRequest: ar,
+	}
+}
+
+type request struct {
+	header http.Header
+	body   []byte
+}
+
+func newServer(t *testing.T, statusCodes ...int) (*httptest.Server, chan request) {
+	t.Helper()
+	requests := make(chan request, 10)
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests <- request{header: r.Header.Clone(), body: body}
+		call := int(calls.Add(1)) - 1
+		if call < len(statusCodes) {
+			w.WriteHeader(statusCodes[call])
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, requests
+}
+
+func TestWebhookSink(t *testing.T) {
+	t.Run("will send the default payload", func(t *testing.T) {
+		// Given
+		server, requests := newServer(t)
+		sink, err := notification.NewWebhookSink(server.URL, "")
+		require.NoError(t, err)
+
+		// When
+		err = sink.Send(context.Background(), newNotification(api.NotificationGranted))
+
+		// Then
+		require.NoError(t, err)
+		require.Len(t, requests, 1)
+		req := <-requests
+		assert.Equal(t, "application/json", req.header.Get("Content-Type"))
+		assert.Equal(t, "granted", req.header.Get(notification.EventHeader))
+		assert.Empty(t, req.header.Get(notification.SignatureHeader))
+		payload := map[string]any{}
+		require.NoError(t, json.Unmarshal(req.body, &payload))
+		assert.Equal(t, "granted", payload["event"])
+		assert.Equal(t, "some message", payload["message"])
+		assert.Equal(t, "2024-01-01T09:00:00Z", payload["time"])
+		accessRequest := payload["accessRequest"].(map[string]any)
+		assert.Equal(t, "some-ar", accessRequest["name"])
+		assert.Equal(t, "some-user", accessRequest["username"])
+		assert.Equal(t, "some-project", accessRequest["project"])
+		assert.Equal(t, `needs "quotes" escaped`, accessRequest["justification"])
+		assert.Equal(t, "2024-01-01T10:00:00Z", accessRequest["expiresAt"])
+	})
+	t.Run("will send the custom template", func(t *testing.T) {
+		// Given
+		server, requests := newServer(t)
+		tmpl := `{"text": {{ json (printf "%s was %s" .AccessRequest.Spec.Subject.Username .Event) }}}`
+		sink, err := notification.NewWebhookSink(server.URL, tmpl)
+		require.NoError(t, err)
+
+		// When
+		err = sink.Send(context.Background(), newNotification(api.NotificationGranted))
+
+		// Then
+		require.NoError(t, err)
+		req := <-requests
+		assert.JSONEq(t, `{"text": "some-user was granted"}`, string(req.body))
+	})
+	t.Run("will sign the payload", func(t *testing.T) {
+		// Given
+		server, requests := newServer(t)
+		secret := []byte("some-secret")
+		sink, err := notification.NewWebhookSink(server.URL, "", notification.WithSecret(secret))
+		require.NoError(t, err)
+
+		// When
+		err = sink.Send(context.Background(), newNotification(api.NotificationGranted))
+
+		// Then
+		require.NoError(t, err)
+		req := <-requests
+		assert.Equal(t, "sha256="+notification.Sign(secret, req.body), req.header.Get(notification.SignatureHeader))
+	})
+	t.Run("will retry server errors", func(t *testing.T) {
+		// Given
+		server, requests := newServer(t, http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK)
+		sink, err := notification.NewWebhookSink(server.URL, "",
+			notification.WithMaxRetries(3),
+			notification.WithBackoff(time.Millisecond))
+		require.NoError(t, err)
+
+		// When
+		err = sink.Send(context.Background(), newNotification(api.NotificationGranted))
+
+		// Then
+		assert.NoError(t, err)
+		assert.Len(t, requests, 3)
+	})
+	t.Run("will return error when retries are exhausted", func(t *testing.T) {
+		// Given
+		server, requests := newServer(t, http.StatusInternalServerError, http.StatusInternalServerError)
+		sink, err := notification.NewWebhookSink(server.URL, "",
+			notification.WithMaxRetries(1),
+			notification.WithBackoff(time.Millisecond))
+		require.NoError(t, err)
+
+		// When
+		err = sink.Send(context.Background(), newNotification(api.NotificationGranted))
+
+		// Then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "after 2 attempts")
+		assert.Contains(t, err.Error(), "status 500")
+		assert.Len(t, requests, 2)
+	})
+	t.Run("will not retry client errors", func(t *testing.T) {
+		// Given
+		server, requests := newServer(t, http.StatusBadRequest)
+		sink, err := notification.NewWebhookSink(server.URL, "",
+			notification.WithMaxRetries(3),
+			notification.WithBackoff(time.Millisecond))
+		require.NoError(t, err)
+
+		// When
+		err = sink.Send(context.Background(), newNotification(api.NotificationGranted))
+
+		// Then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "status 400")
+		assert.Len(t, requests, 1)
+	})
+	t.Run("will return error if the payload is not valid JSON", func(t *testing.T) {
+		// Given
+		server, requests := newServer(t)
+		sink, err := notification.NewWebhookSink(server.URL, `{"text": {{ .Message }}}`)
+		require.NoError(t, err)
+
+		// When
+		err = sink.Send(context.Background(), newNotification(api.NotificationGranted))
+
+		// Then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not a valid JSON document")
+		assert.Empty(t, requests)
+	})
+	t.Run("will return error if the template is invalid", func(t *testing.T) {
+		// When
+		_, err := notification.NewWebhookSink("http://localhost", `{{ .Message `)
+
+		// Then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "error parsing webhook template")
+	})
+	t.Run("will only accept the configured events", func(t *testing.T) {
+		// Given
+		sink, err := notification.NewWebhookSink("http://localhost", "",
+			notification.WithEvents(api.NotificationGranted, api.NotificationExpiring))
+		require.NoError(t, err)
+
+		// Then
+		assert.True(t, sink.Accepts(api.NotificationGranted))
+		assert.True(t, sink.Accepts(api.NotificationExpiring))
+		assert.False(t, sink.Accepts(api.NotificationDenied))
+	})
+}
@@ -0,0 +1,179 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
+	"github.com/argoproj-labs/argocd-ephemeral-access/pkg/log"
+)
+
+// RoleTemplateReconciler reconciles a RoleTemplate object. It only maintains
+// the RoleTemplate status. The AppProject roles are managed by the
+// AccessRequestReconciler.
+type RoleTemplateReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// Reconcile will be invoked on every change in RoleTemplates and in the
+// AccessRequests referencing them. It will:
+//  1. Validate that the RoleTemplate can be rendered
+//  2. Verify if the role of all granted AccessRequests is synced with the
+//     current RoleTemplate in the AppProject
+//  3. Update the RoleTemplate status and conditions
+//
+// It depends on the AccessRequest indexes created by the
+// AccessRequestReconciler.
+func (r *RoleTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	rt := &api.RoleTemplate{}
+	if err := r.Get(ctx, req.NamespacedName, rt); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Debug("Object deleted")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Error retrieving RoleTemplate from k8s")
+		return ctrl.Result{}, err
+	}
+	logger.Debug("Reconciliation started")
+
+	updated := rt.DeepCopy()
+	updated.Status.SyncHash = RoleTemplateHash(rt)
+	// the placeholders are only used to validate the templates
+	_, err := rt.Render("project", "application", "namespace")
+	if err != nil {
+		message := fmt.Sprintf("Invalid RoleTemplate: %s", err)
+		updated.Status.Synced = false
+		updated.Status.Message = message
+		updated.SetCondition(api.ConditionReady, metav1.ConditionFalse, "InvalidTemplate", message)
+		updated.SetCondition(api.ConditionProjectSynced, metav1.ConditionFalse, "InvalidTemplate", message)
+	} else {
+		total, outOfSync, err := r.countGrantedAccessRequests(ctx, rt)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("error verifying AccessRequests sync state: %w", err)
+		}
+		updated.SetCondition(api.ConditionReady, metav1.ConditionTrue, "Valid", "RoleTemplate is valid")
+		if outOfSync > 0 {
+			message := fmt.Sprintf("%d of %d granted AccessRequests pending sync", outOfSync, total)
+			updated.Status.Synced = false
+			updated.Status.Message = message
+			updated.SetCondition(api.ConditionProjectSynced, metav1.ConditionFalse, "SyncPending", message)
+		} else {
+			message := fmt.Sprintf("Role synced for %d granted AccessRequests", total)
+			updated.Status.Synced = true
+			updated.Status.Message = message
+			updated.SetCondition(api.ConditionProjectSynced, metav1.ConditionTrue, "Synced", message)
+		}
+	}
+
+	if equality.Semantic.DeepEqual(rt.Status, updated.Status) {
+		logger.Debug("No need to update RoleTemplate status")
+		return ctrl.Result{}, nil
+	}
+	err = r.Status().Update(ctx, updated)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error updating RoleTemplate status: %w", err)
+	}
+	logger.Debug("Reconciliation concluded", "synced", updated.Status.Synced, "message", updated.Status.Message)
+	return ctrl.Result{}, nil
+}
+
+// countGrantedAccessRequests returns the number of granted AccessRequests
+// referencing the given rt and how many of them have the AppProject role
+// out of sync with the current RoleTemplate.
+func (r *RoleTemplateReconciler) countGrantedAccessRequests(ctx context.Context, rt *api.RoleTemplate) (int, int, error) {
+	arList := &api.AccessRequestList{}
+	selector := fields.SelectorFromSet(
+		fields.Set{
+			roleTemplateNameField:      rt.GetName(),
+			roleTemplateNamespaceField: rt.GetNamespace(),
+		})
+	err := r.List(ctx, arList, &client.ListOptions{FieldSelector: selector})
+	if err != nil {
+		return 0, 0, fmt.Errorf("error listing AccessRequests: %w", err)
+	}
+	total, outOfSync := 0, 0
+	for _, ar := range arList.Items {
+		if ar.Status.RequestState != api.GrantedStatus {
+			continue
+		}
+		total++
+		rendered, err := rt.Render(ar.Status.TargetProject, ar.Spec.Application.Name, ar.Spec.Application.Namespace)
+		if err != nil || RoleTemplateHash(rendered) != ar.Status.RoleTemplateHash {
+			outOfSync++
+		}
+	}
+	return total, outOfSync, nil
+}
+
+// callReconcileForAccessRequest will build the reconcile request for the
+// RoleTemplate referenced by the given AccessRequest.
+func (r *RoleTemplateReconciler) callReconcileForAccessRequest(ctx context.Context, obj client.Object) []reconcile.Request {
+	ar, ok := obj.(*api.AccessRequest)
+	if !ok || ar.Spec.Role.TemplateRef.Name == "" {
+		return nil
+	}
+	return []reconcile.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Name:      ar.Spec.Role.TemplateRef.Name,
+				Namespace: ar.Spec.Role.TemplateRef.Namespace,
+			},
+		},
+	}
+}
+
+// AccessRequestSyncChangedPredicate defines the predicate used to filter the
+// AccessRequest events that can change the RoleTemplate sync state.
+func AccessRequestSyncChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldAR, ok := e.ObjectOld.(*api.AccessRequest)
+			if !ok {
+				return false
+			}
+			newAR, ok := e.ObjectNew.(*api.AccessRequest)
+			if !ok {
+				return false
+			}
+			return oldAR.Status.RequestState != newAR.Status.RequestState ||
+				oldAR.Status.RoleTemplateHash != newAR.Status.RoleTemplateHash
+		},
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return true
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RoleTemplateReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&api.RoleTemplate{},
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&api.AccessRequest{},
+			handler.EnqueueRequestsFromMapFunc(r.callReconcileForAccessRequest),
+			builder.WithPredicates(AccessRequestSyncChangedPredicate())).
+		Complete(r)
+}
@@ -0,0 +1,124 @@
+package controller_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
+	"github.com/argoproj-labs/argocd-ephemeral-access/internal/controller"
+	"github.com/argoproj-labs/argocd-ephemeral-access/test/utils"
+)
+
+func TestRoleTemplateReconciler(t *testing.T) {
+	newRoleTemplate := func(policies ...string) *api.RoleTemplate {
+		return &api.RoleTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some-role",
+				Namespace: "ephemeral",
+			},
+			Spec: api.RoleTemplateSpec{
+				Name:        "some-role",
+				Description: "write access to {{.application}}",
+				Policies:    policies,
+			},
+		}
+	}
+	newGrantedAccessRequest := func(name string, rt *api.RoleTemplate) *api.AccessRequest {
+		ar := utils.NewAccessRequest(name, "ephemeral", "some-app", "argocd", rt.GetName(), rt.GetNamespace(), "user-id", "some-user")
+		ar.Status.RequestState = api.GrantedStatus
+		ar.Status.TargetProject = "some-project"
+		rendered, err := rt.Render("some-project", "some-app", "argocd")
+		require.NoError(t, err)
+		ar.Status.RoleTemplateHash = controller.RoleTemplateHash(rendered)
+		return ar
+	}
+	setup := func(t *testing.T, objs ...client.Object) (*controller.RoleTemplateReconciler, client.Client) {
+		t.Helper()
+		scheme := runtime.NewScheme()
+		require.NoError(t, api.AddToScheme(scheme))
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(objs...).
+			WithStatusSubresource(&api.RoleTemplate{}).
+			WithIndex(&api.AccessRequest{}, ".spec.role.template.name", func(obj client.Object) []string {
+				return []string{obj.(*api.AccessRequest).Spec.Role.TemplateRef.Name}
+			}).
+			WithIndex(&api.AccessRequest{}, ".spec.role.template.namespace", func(obj client.Object) []string {
+				return []string{obj.(*api.AccessRequest).Spec.Role.TemplateRef.Namespace}
+			}).
+			Build()
+		return &controller.RoleTemplateReconciler{Client: k8sClient, Scheme: scheme}, k8sClient
+	}
+	reconcile := func(t *testing.T, r *controller.RoleTemplateReconciler, k8sClient client.Client) *api.RoleTemplate {
+		t.Helper()
+		key := types.NamespacedName{Name: "some-role", Namespace: "ephemeral"}
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+		require.NoError(t, err)
+		rt := &api.RoleTemplate{}
+		require.NoError(t, k8sClient.Get(context.Background(), key, rt))
+		return rt
+	}
+
+	t.Run("will set the template as synced when all granted requests are synced", func(t *testing.T) {
+		// Given
+		rt := newRoleTemplate("p, {{.role}}, applications, sync, {{.project}}/{{.application}}, allow")
+		r, k8sClient := setup(t, rt, newGrantedAccessRequest("ar1", rt), newGrantedAccessRequest("ar2", rt))
+
+		// When
+		result := reconcile(t, r, k8sClient)
+
+		// Then
+		assert.True(t, result.Status.Synced)
+		assert.Equal(t, "Role synced for 2 granted AccessRequests", result.Status.Message)
+		assert.Equal(t, controller.RoleTemplateHash(rt), result.Status.SyncHash)
+		ready := result.GetCondition(api.ConditionReady)
+		require.NotNil(t, ready)
+		assert.Equal(t, metav1.ConditionTrue, ready.Status)
+		projectSynced := result.GetCondition(api.ConditionProjectSynced)
+		require.NotNil(t, projectSynced)
+		assert.Equal(t, metav1.ConditionTrue, projectSynced.Status)
+		assert.Equal(t, "Synced", projectSynced.Reason)
+	})
+	t.Run("will report granted requests pending sync", func(t *testing.T) {
+		// Given
+		rt := newRoleTemplate("p, {{.role}}, applications, sync, {{.project}}/{{.application}}, allow")
+		outdated := newGrantedAccessRequest("ar2", newRoleTemplate("p, {{.role}}, applications, get, {{.project}}/{{.application}}, allow"))
+		r, k8sClient := setup(t, rt, newGrantedAccessRequest("ar1", rt), outdated)
+
+		// When
+		result := reconcile(t, r, k8sClient)
+
+		// Then
+		assert.False(t, result.Status.Synced)
+		assert.Equal(t, "1 of 2 granted AccessRequests pending sync", result.Status.Message)
+		projectSynced := result.GetCondition(api.ConditionProjectSynced)
+		require.NotNil(t, projectSynced)
+		assert.Equal(t, metav1.ConditionFalse, projectSynced.Status)
+		assert.Equal(t, "SyncPending", projectSynced.Reason)
+	})
+	t.Run("will set ready to false if the template is invalid", func(t *testing.T) {
+		// Given
+		rt := newRoleTemplate("p, {{.role, applications, sync, */*, allow")
+		r, k8sClient := setup(t, rt)
+
+		// When
+		result := reconcile(t, r, k8sClient)
+
+		// Then
+		assert.False(t, result.Status.Synced)
+		assert.Contains(t, result.Status.Message, "Invalid RoleTemplate")
+		ready := result.GetCondition(api.ConditionReady)
+		require.NotNil(t, ready)
+		assert.Equal(t, metav1.ConditionFalse, ready.Status)
+		assert.Equal(t, "InvalidTemplate", ready.Reason)
+	})
+}
@@ -6,17 +6,23 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strings"
+	"time"
 
 	"github.com/argoproj-labs/argocd-ephemeral-access/internal/controller/metrics"
 
 	argocd "github.com/argoproj-labs/argocd-ephemeral-access/api/argoproj/v1alpha1"
 	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
+	"github.com/argoproj-labs/argocd-ephemeral-access/internal/controller/audit"
 	"github.com/argoproj-labs/argocd-ephemeral-access/internal/controller/config"
+	"github.com/argoproj-labs/argocd-ephemeral-access/internal/controller/notification"
 	"github.com/argoproj-labs/argocd-ephemeral-access/pkg/log"
 	"github.com/argoproj-labs/argocd-ephemeral-access/pkg/plugin"
 	"github.com/cnf/structhash"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -25,6 +31,36 @@ const (
 	FieldOwnerEphemeralAccess = "ephemeral-access-controller"
 )
 
+const (
+	// EventReasonBreakGlassGranted is the reason of the Event published when
+	// a break-glass access is granted
+	EventReasonBreakGlassGranted = "BreakGlassGranted"
+	// EventReasonBreakGlassReviewed is the reason of the Event published with
+	// the plugin decision about a break-glass access already granted
+	EventReasonBreakGlassReviewed = "BreakGlassReviewed"
+	// EventReasonBreakGlassReviewFailed is the reason of the Event published
+	// when the plugin fails to review a break-glass access
+	EventReasonBreakGlassReviewFailed = "BreakGlassReviewFailed"
+	// EventReasonApplicationError is the reason of the Event published when
+	// the Application associated with an AccessRequest can't be retrieved
+	EventReasonApplicationError = "ApplicationError"
+)
+
+// statusEventReasons defines the reason of the Event published when an
+// AccessRequest transitions to each status.
+var statusEventReasons = map[api.Status]string{
+	api.InitiatedStatus: "AccessInitiated",
+	api.RequestedStatus: "AccessRequested",
+	api.ScheduledStatus: "AccessScheduled",
+	api.GrantedStatus:   "AccessGranted",
+	api.ExpiredStatus:   "AccessExpired",
+	api.DeniedStatus:    "AccessDenied",
+	api.InvalidStatus:   "AccessInvalid",
+	api.TimeoutStatus:   "AccessTimeout",
+	api.RevokedStatus:   "AccessRevoked",
+	api.CancelledStatus: "AccessCancelled",
+}
+
 type K8sClient interface {
 	// Patch patches the given obj in the Kubernetes cluster. obj must be a
 	// struct pointer so that obj can be updated with the content returned by the Server.
@@ -41,16 +77,170 @@ type K8sClient interface {
 }
 
 type Service struct {
-	k8sClient       K8sClient
-	Config          config.ControllerConfigurer
-	accessRequester plugin.AccessRequester
+	k8sClient     K8sClient
+	Config        config.ControllerConfigurer
+	plugins       []namedPlugin
+	pluginTimeout time.Duration
+	recorder      record.EventRecorder
+	notifier      *notification.Notifier
+	auditor       *audit.Logger
+}
+
+// DefaultPluginName is the name of the plugin provided to NewService. It can be
+// used by AccessBindings and RoleTemplates to select the plugin.
+const DefaultPluginName = "default"
+
+// namedPlugin is an AccessRequester plugin registered in the Service with the
+// name used by AccessBindings and RoleTemplates to select it.
+type namedPlugin struct {
+	name            string
+	accessRequester plugin.AccessRequesterV2
+}
+
+// ServiceOption defines an optional configuration of the Service.
+type ServiceOption func(*Service)
+
+// WithEventRecorder configures the recorder used to publish Kubernetes Events
+// about AccessRequests. Events are not published if not provided.
+func WithEventRecorder(recorder record.EventRecorder) ServiceOption {
+	return func(s *Service) {
+		s.recorder = recorder
+	}
+}
+
+// WithNotifier configures the notifier used to send the AccessRequests
+// lifecycle events to webhooks. Notifications are not sent if not provided.
+func WithNotifier(notifier *notification.Notifier) ServiceOption {
+	return func(s *Service) {
+		s.notifier = notifier
+	}
+}
+
+// WithAuditLogger configures the logger used to record the decisions about
+// AccessRequests in the audit log. Decisions are not audited if not provided.
+func WithAuditLogger(auditor *audit.Logger) ServiceOption {
+	return func(s *Service) {
+		s.auditor = auditor
+	}
+}
+
+// WithPlugin registers an additional AccessRequester plugin with the given
+// name. Unless AccessBindings or RoleTemplates select specific plugins, all
+// registered plugins must grant the access, in the order they are registered
+// after the plugin provided to NewService.
+func WithPlugin(name string, accessRequester plugin.AccessRequesterV2) ServiceOption {
+	return func(s *Service) {
+		s.plugins = append(s.plugins, namedPlugin{name: name, accessRequester: accessRequester})
+	}
+}
+
+// WithPluginTimeout configures the maximum duration of each plugin call. Plugin
+// calls are only bounded by the reconciliation context if not provided.
+func WithPluginTimeout(timeout time.Duration) ServiceOption {
+	return func(s *Service) {
+		s.pluginTimeout = timeout
+	}
+}
+
+func NewService(c K8sClient, cfg config.ControllerConfigurer, accessRequester plugin.AccessRequesterV2, opts ...ServiceOption) *Service {
+	s := &Service{
+		k8sClient: c,
+		Config:    cfg,
+	}
+	if accessRequester != nil {
+		s.plugins = append(s.plugins, namedPlugin{name: DefaultPluginName, accessRequester: accessRequester})
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// recordEvent publishes a Kubernetes Event associated with the given
+// AccessRequest if an EventRecorder is configured.
+func (s *Service) recordEvent(ar *api.AccessRequest, eventType, reason, messageFmt string, args ...any) {
+	if s.recorder == nil {
+		return
+	}
+	s.recorder.Eventf(ar, eventType, reason, messageFmt, args...)
+}
+
+// RecordStatusEvent publishes a Kubernetes Event explaining the transition of
+// the given AccessRequest to the given status. The status details (e.g. the
+// plugin message) are included in the Event message. A summary Event is also
+// published in the target AppProject once it is known. Noop if an
+// EventRecorder is not configured.
+func (s *Service) RecordStatusEvent(ctx context.Context, ar *api.AccessRequest, status api.Status, details string) {
+	if s.recorder == nil {
+		return
+	}
+	logger := log.FromContext(ctx)
+	eventType := corev1.EventTypeNormal
+	switch status {
+	case api.DeniedStatus, api.InvalidStatus, api.TimeoutStatus:
+		eventType = corev1.EventTypeWarning
+	}
+	reason, ok := statusEventReasons[status]
+	if !ok {
+		reason = "AccessUpdated"
+	}
+	message := fmt.Sprintf("Access %s for %s", status, ar.Spec.Subject.Username)
+	if details != "" {
+		message = fmt.Sprintf("%s: %s", message, details)
+	}
+	s.recorder.Event(ar, eventType, reason, message)
+
+	if ar.Status.TargetProject == "" {
+		return
+	}
+	project, err := s.getProject(ctx, ar.Status.TargetProject, ar.GetNamespace())
+	if err != nil {
+		logger.Debug(fmt.Sprintf("Skipping AppProject event: error retrieving AppProject: %s", err))
+		return
+	}
+	s.recorder.Eventf(project, eventType, reason, "AccessRequest %s/%s: %s role %s in application %s/%s for %s",
+		ar.GetNamespace(), ar.GetName(), status, ar.Spec.Role.TemplateRef.Name,
+		ar.Spec.Application.Namespace, ar.Spec.Application.Name, ar.Spec.Subject.Username)
+}
+
+// Notify sends the notification associated with the transition of the given
+// AccessRequest to the given status. Notifications are delivered in the
+// background so webhook retries don't block the reconciliation. Noop if a
+// notifier is not configured or the status isn't notified.
+func (s *Service) Notify(ctx context.Context, ar *api.AccessRequest, status api.Status, details string) {
+	event, ok := notification.EventFromStatus(status)
+	if !ok {
+		return
+	}
+	s.notify(ctx, ar, event, details)
+}
+
+func (s *Service) notify(ctx context.Context, ar *api.AccessRequest, event api.NotificationEvent, message string) {
+	if s.notifier == nil {
+		return
+	}
+	logger := log.FromContext(ctx)
+	binding := s.lookupAccessBinding(ctx, ar)
+	ar = ar.DeepCopy()
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		err := s.notifier.Notify(ctx, event, ar, binding, message)
+		if err != nil {
+			logger.Error(err, "Error sending notification", "event", event)
+		}
+	}()
 }
 
-func NewService(c K8sClient, cfg config.ControllerConfigurer, accessRequester plugin.AccessRequester) *Service {
-	return &Service{
-		k8sClient:       c,
-		Config:          cfg,
-		accessRequester: accessRequester,
+// Audit appends a record with the given action about the given AccessRequest
+// to the audit log. Failures are logged and don't interrupt the
+// reconciliation. Noop if an audit logger is not configured.
+func (s *Service) Audit(ctx context.Context, ar *api.AccessRequest, action audit.Action, details string) {
+	if s.auditor == nil {
+		return
+	}
+	err := s.auditor.Log(action, ar, details)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Error writing audit record", "action", action)
 	}
 }
 
@@ -136,11 +326,112 @@ func (s *Service) ValidateProject(ctx context.Context, app *argocd.Application,
 	return true, nil
 }
 
+// ValidateAccessBinding validates the given AccessRequest against the constraints
+// defined in the AccessBinding referenced by ar.Spec.AccessBindingRef. The
+// validation is skipped for AccessRequests without binding reference and for
+// the ones already granted, unless the binding was deleted. It updates the
+// AccessRequest status to invalid in the following cases:
+// - The referenced AccessBinding does not exist (granted access is removed).
+// - The requested duration is out of the range allowed by the AccessBinding.
+// - The justification or ticket reference doesn't meet the AccessBinding rules.
+// - Break-glass access is requested but not allowed by the AccessBinding.
+//
+// Returns the AccessBinding (nil if not referenced) and true if the AccessRequest
+// is valid. Returns an error if any status update or binding retrieval fails.
+func (s *Service) ValidateAccessBinding(ctx context.Context, ar *api.AccessRequest) (*api.AccessBinding, bool, error) {
+	if ar.Spec.AccessBindingRef == nil {
+		// break-glass constraints are defined in the binding
+		if ar.Spec.BreakGlass && ar.Status.RequestState != api.GrantedStatus {
+			msg := "Invalid break-glass request: an AccessBinding reference is required"
+			err := s.updateStatus(ctx, ar, api.InvalidStatus, msg, ar.Status.RoleTemplateHash)
+			if err != nil {
+				return nil, false, fmt.Errorf("error updating status to invalid when break-glass binding is missing: %w", err)
+			}
+			return nil, false, nil
+		}
+		return nil, true, nil
+	}
+	binding, err := s.getAccessBinding(ctx, ar)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			err := s.handleAccessBindingNotFound(ctx, ar)
+			if err != nil {
+				return nil, false, fmt.Errorf("error handling access binding not found: %w", err)
+			}
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("error getting AccessBinding: %w", err)
+	}
+
+	// the duration and justification are only relevant before the access is
+	// granted as the expiration time is defined at that moment
+	if ar.Status.RequestState == api.GrantedStatus {
+		return binding, true, nil
+	}
+	err = binding.ValidateDuration(ar.Spec.Duration.Duration)
+	if err != nil {
+		msg := fmt.Sprintf("Invalid duration: %s", err)
+		err := s.updateStatus(ctx, ar, api.InvalidStatus, msg, ar.Status.RoleTemplateHash)
+		if err != nil {
+			return nil, false, fmt.Errorf("error updating status to invalid when duration is out of range: %w", err)
+		}
+		return nil, false, nil
+	}
+	err = binding.ValidateJustification(ar.Spec.Justification, ar.Spec.TicketRef)
+	if err != nil {
+		msg := fmt.Sprintf("Invalid justification: %s", err)
+		err := s.updateStatus(ctx, ar, api.InvalidStatus, msg, ar.Status.RoleTemplateHash)
+		if err != nil {
+			return nil, false, fmt.Errorf("error updating status to invalid when justification is missing: %w", err)
+		}
+		return nil, false, nil
+	}
+	if ar.Spec.BreakGlass {
+		err = binding.ValidateBreakGlass(ar.Spec.Duration.Duration)
+		if err != nil {
+			msg := fmt.Sprintf("Invalid break-glass request: %s", err)
+			err := s.updateStatus(ctx, ar, api.InvalidStatus, msg, ar.Status.RoleTemplateHash)
+			if err != nil {
+				return nil, false, fmt.Errorf("error updating status to invalid when break-glass is not allowed: %w", err)
+			}
+			return nil, false, nil
+		}
+	}
+	return binding, true, nil
+}
+
+// handleAccessBindingNotFound will update the given ar status to invalid as
+// its AccessBinding no longer exists. The subject is removed from the Argo CD
+// role first as invalid requests are not reconciled anymore and the access
+// would never expire.
+func (s *Service) handleAccessBindingNotFound(ctx context.Context, ar *api.AccessRequest) error {
+	ref := ar.Spec.AccessBindingRef
+	msg := fmt.Sprintf("AccessBinding %s/%s not found", ref.Namespace, ref.Name)
+	hash := ar.Status.RoleTemplateHash
+	// requests that were never initialized have no access to be removed
+	if ar.Status.TargetProject != "" {
+		role, err := s.getRenderedRole(ctx, ar, ar.Status.TargetProject)
+		if err != nil {
+			return fmt.Errorf("error getting rendered RoleTemplate: %w", err)
+		}
+		err = s.RemoveArgoCDAccess(ctx, ar, role)
+		if err != nil {
+			return fmt.Errorf("error removing access for not found access binding: %w", err)
+		}
+		hash = RoleTemplateHash(role)
+	}
+	err := s.updateStatus(ctx, ar, api.InvalidStatus, msg, hash)
+	if err != nil {
+		return fmt.Errorf("error updating status to invalid when access binding not found: %w", err)
+	}
+	return nil
+}
+
 // handlePermission will analyse the given ar and proceed with granting
 // or removing Argo CD access for the subject listed in the AccessRequest.
 // The following validations will be executed:
-//  1. Check if the given ar is expired. If so, the subject will be removed from
-//     the Argo CD role.
+//  1. Check if the given ar is expired, revoked or cancelled. If so, the subject
+//     will be removed from the Argo CD role without validating the AccessBinding.
 //  2. Check if the subject is allowed to be assigned in the given AccessRequest
 //     target role. If so, it will proceed with grating Argo CD access. Otherwise
 //     it will return DeniedStatus.
@@ -158,7 +449,8 @@ func (s *Service) HandlePermission(ctx context.Context, ar *api.AccessRequest) (
 			}
 			return api.InvalidStatus, nil
 		}
-		// TODO send an event to explain why the access request is failing
+		s.recordEvent(ar, corev1.EventTypeWarning, EventReasonApplicationError,
+			"Error retrieving Application %s/%s: %s", ar.Spec.Application.Namespace, ar.Spec.Application.Name, err)
 		return "", fmt.Errorf("error getting Argo CD Application: %w", err)
 	}
 
@@ -170,50 +462,95 @@ func (s *Service) HandlePermission(ctx context.Context, ar *api.AccessRequest) (
 		return api.InvalidStatus, nil
 	}
 
-	role, err := s.getRenderedRole(ctx, ar, app.Spec.Project)
+	// expired, revoked and cancelled requests are concluded even if they no
+	// longer comply with the binding so the access is removed and the
+	// plugins are notified. The binding is only used to select the plugins.
+	if ar.IsExpiring() || ar.Spec.Revoke || ar.Spec.Cancel {
+		role, err := s.getRenderedRole(ctx, ar, app.Spec.Project)
+		if err != nil {
+			return "", fmt.Errorf("error getting rendered RoleTemplate: %w", err)
+		}
+		pluginNames := pluginSelection(s.lookupAccessBinding(ctx, ar), role)
+		return s.handleConclusion(ctx, ar, app, role, pluginNames)
+	}
+
+	binding, validBinding, err := s.ValidateAccessBinding(ctx, ar)
 	if err != nil {
-		return "", fmt.Errorf("error getting rendered RoleTemplate: %w", err)
+		return "", fmt.Errorf("error validating access binding: %w", err)
+	}
+	if !validBinding {
+		return api.InvalidStatus, nil
 	}
 
-	if ar.IsExpiring() {
-		logger.Info("AccessRequest is expired")
-		err := s.handleAccessExpired(ctx, ar, app, role)
-		if err != nil {
-			return "", fmt.Errorf("error handling access expired: %w", err)
-		}
-		return api.ExpiredStatus, nil
+	role, err := s.getRenderedRole(ctx, ar, app.Spec.Project)
+	if err != nil {
+		return "", fmt.Errorf("error getting rendered RoleTemplate: %w", err)
 	}
+	pluginNames := pluginSelection(binding, role)
 
 	// initialize the status if not done yet
 	if !ar.IsInitialized() {
 		logger.Debug("Initializing status")
 		ar.Status.TargetProject = app.Spec.Project
 		ar.Status.RoleName = role.AppProjectRoleName(app.GetName(), app.GetNamespace())
+		if s.notifier != nil {
+			ar.Status.ExpiryWarning = s.notifier.ExpiryWarning(binding)
+		}
 		err := s.updateStatus(ctx, ar, api.InitiatedStatus, "", RoleTemplateHash(role))
 		if err != nil {
 			return "", fmt.Errorf("error initializing access request status: %w", err)
 		}
 	}
 
-	// invoke the configured plugin to check if the ar.Spec.Subject
+	// break-glass access requests are granted immediately without waiting
+	// for approvals or the plugin decision.
+	if ar.Spec.BreakGlass {
+		return s.handleBreakGlass(ctx, ar, app, binding, role, pluginNames)
+	}
+
+	// access requests requiring approval are kept in the requested state
+	// until the quorum is reached.
+	if ar.RequiresApproval() && ar.Status.RequestState != api.GrantedStatus {
+		approved, err := s.handleApprovals(ctx, ar, RoleTemplateHash(role))
+		if err != nil {
+			return "", fmt.Errorf("error handling approvals: %w", err)
+		}
+		if !approved {
+			return ar.Status.RequestState, nil
+		}
+	}
+
+	// scheduled access requests were already allowed by the plugin and are
+	// held until the start time. The plugin isn't invoked again once the
+	// start time is reached.
+	if ar.Status.RequestState == api.ScheduledStatus {
+		if ar.IsScheduled() {
+			return api.ScheduledStatus, nil
+		}
+		logger.Info("Scheduled AccessRequest started")
+		return s.grantAccess(ctx, ar, role, "Scheduled access started")
+	}
+
+	// invoke the selected plugins to check if the ar.Spec.Subject
 	// is allowed to get their access elevated. If no plugin is configured
 	// it will always allow.
-	resp, err := s.Allowed(ctx, ar, app)
+	resp, err := s.Allowed(ctx, ar, app, pluginNames)
 	if err != nil {
 		return "", fmt.Errorf("error verifying if subject is allowed: %w", err)
 	}
+	if s.hasPlugin() && ar.Status.RequestState != api.GrantedStatus {
+		previous := ar.GetCondition(api.ConditionPluginApproved).DeepCopy()
+		setPluginApprovedCondition(ar, resp)
+		// pending requests are reevaluated periodically so only changes
+		// in the plugin decision are audited
+		current := ar.GetCondition(api.ConditionPluginApproved)
+		if previous == nil || previous.Reason != current.Reason || previous.Message != current.Message {
+			s.Audit(ctx, ar, audit.ActionPluginResponse, fmt.Sprintf("%s: %s", current.Reason, current.Message))
+		}
+	}
 
-	// if accessRequest is already granted but not yet expired there is no
-	// permission to be modified but it is still necessary to ensure that
-	// the AppProject role is synced.
 	if ar.Status.RequestState == api.GrantedStatus {
-		if resp.Allowed {
-			err = s.ensureRoleIsSynced(ctx, ar, role)
-			if err != nil {
-				return "", fmt.Errorf("error while ensuring role is synced: %w", err)
-			}
-		}
-		return api.GrantedStatus, nil
+		return s.handleGranted(ctx, ar, binding, role, resp)
 	}
 
 	if !resp.Allowed {
@@ -233,17 +570,40 @@ func (s *Service) HandlePermission(ctx context.Context, ar *api.AccessRequest) (
 				return "", fmt.Errorf("error updating access request status to requested: %w", err)
 			}
 			return api.RequestedStatus, nil
+		default:
+			return "", fmt.Errorf("access not allowed with unknown plugin status %q", resp.Status)
 		}
 	}
 
-	details := resp.Message
+	if ar.IsScheduled() {
+		details := fmt.Sprintf("Access scheduled to start at %s", ar.Spec.StartsAt.Format(time.RFC3339))
+		if resp.Message != "" {
+			details = fmt.Sprintf("%s: %s", details, resp.Message)
+		}
+		logger.Info("AccessRequest scheduled", "message", resp.Message, "status", api.ScheduledStatus)
+		err = s.updateStatus(ctx, ar, api.ScheduledStatus, details, RoleTemplateHash(role))
+		if err != nil {
+			return "", fmt.Errorf("error updating access request status to scheduled: %w", err)
+		}
+		return api.ScheduledStatus, nil
+	}
+
+	return s.grantAccess(ctx, ar, role, resp.Message)
+}
+
+// grantAccess will add the subject to the AppProject role and update the
+// access request status with the result.
+func (s *Service) grantAccess(ctx context.Context, ar *api.AccessRequest, role *api.RoleTemplate, details string) (api.Status, error) {
+	logger := log.FromContext(ctx)
+	message := details
 	status, err := s.grantArgoCDAccess(ctx, ar, role)
 	if err != nil {
 		details = fmt.Sprintf("Error granting Argo CD Access: %s", err)
+		ar.SetCondition(api.ConditionProjectSynced, metav1.ConditionFalse, "SyncError", details)
 	}
 	// only update status if the current state is different
 	if ar.Status.RequestState != status {
-		logger.Info(fmt.Sprintf("AccessRequest %s", status), "message", resp.Message, "status", status)
+		logger.Info(fmt.Sprintf("AccessRequest %s", status), "message", message, "status", status)
 		rtHash := RoleTemplateHash(role)
 		err = s.updateStatus(ctx, ar, status, details, rtHash)
 		if err != nil {
@@ -253,6 +613,187 @@ func (s *Service) HandlePermission(ctx context.Context, ar *api.AccessRequest) (
 	return status, nil
 }
 
+// handleGranted will process the given AccessRequest that is already granted
+// but not yet expired. There is no permission to be modified but it is still
+// necessary to ensure that the AppProject role is synced, to conclude the
+// pending extension and to send the expiry warning.
+func (s *Service) handleGranted(ctx context.Context, ar *api.AccessRequest, binding *api.AccessBinding, role *api.RoleTemplate, resp *AllowedResponse) (api.Status, error) {
+	if resp.Allowed {
+		err := s.ensureRoleIsSynced(ctx, ar, role)
+		if err != nil {
+			return "", fmt.Errorf("error while ensuring role is synced: %w", err)
+		}
+	}
+	if ar.GetPendingExtension() != nil {
+		err := s.handleAccessExtension(ctx, ar, binding, resp, RoleTemplateHash(role))
+		if err != nil {
+			return "", fmt.Errorf("error handling access extension: %w", err)
+		}
+	}
+	err := s.handleExpiryWarning(ctx, ar)
+	if err != nil {
+		return "", fmt.Errorf("error handling expiry warning: %w", err)
+	}
+	return api.GrantedStatus, nil
+}
+
+// handleBreakGlass will grant the emergency access defined by the given ar
+// without invoking the plugin. Once granted, the plugin is invoked
+// asynchronously so the access can be reviewed after the fact. The review
+// result is published as a Kubernetes Event and doesn't change the access.
+// Break-glass access can't be extended.
+func (s *Service) handleBreakGlass(ctx context.Context, ar *api.AccessRequest, app *argocd.Application, binding *api.AccessBinding, role *api.RoleTemplate, pluginNames []string) (api.Status, error) {
+	logger := log.FromContext(ctx)
+	if ar.Status.RequestState == api.GrantedStatus {
+		return s.handleGranted(ctx, ar, binding, role, &AllowedResponse{Allowed: true})
+	}
+
+	logger.Info("Granting break-glass access")
+	status, err := s.grantAccess(ctx, ar, role, "Break-glass access granted without approval")
+	if err != nil || status != api.GrantedStatus {
+		return status, err
+	}
+	metrics.IncrementBreakGlassCounter(ar)
+	s.recordEvent(ar, corev1.EventTypeWarning, EventReasonBreakGlassGranted,
+		"Break-glass access granted to %s for application %s/%s until %s: %s",
+		ar.Spec.Subject.Username, ar.Spec.Application.Namespace, ar.Spec.Application.Name,
+		ar.Status.ExpiresAt.Format(time.RFC3339), ar.Spec.Justification)
+	if s.hasPlugin() {
+		go s.reviewBreakGlass(context.WithoutCancel(ctx), ar.DeepCopy(), app.DeepCopy(), pluginNames)
+	}
+	return status, nil
+}
+
+// reviewBreakGlass will invoke the selected plugins for the given break-glass
+// access that was already granted and publish the result as a Kubernetes Event.
+func (s *Service) reviewBreakGlass(ctx context.Context, ar *api.AccessRequest, app *argocd.Application, pluginNames []string) {
+	logger := log.FromContext(ctx)
+	resp, err := s.Allowed(ctx, ar, app, pluginNames)
+	if err != nil {
+		logger.Error(err, "Error reviewing break-glass access")
+		s.recordEvent(ar, corev1.EventTypeWarning, EventReasonBreakGlassReviewFailed, "Error reviewing break-glass access: %s", err)
+		return
+	}
+	logger.Info("Break-glass access reviewed", "status", resp.Status, "message", resp.Message)
+	eventType := corev1.EventTypeNormal
+	if !resp.Allowed {
+		eventType = corev1.EventTypeWarning
+	}
+	s.recordEvent(ar, eventType, EventReasonBreakGlassReviewed, "Break-glass access review result: %s: %s", resp.Status, resp.Message)
+}
+
+// handleAccessExtension will process the pending extension of the given granted
+// AccessRequest. The extension is validated against the AccessBinding limits and
+// the plugin response is used to decide if it should be granted. Pending
+// extensions are left untouched so the plugin is invoked again in the next
+// reconciliation. The access expiration is only moved forward if the extension
+// is granted. Extensions of break-glass access are always denied.
+func (s *Service) handleAccessExtension(ctx context.Context, ar *api.AccessRequest, binding *api.AccessBinding, resp *AllowedResponse, rtHash string) error {
+	logger := log.FromContext(ctx)
+	ext := ar.GetPendingExtension()
+
+	// the limits are validated again as the binding could have been changed
+	// after the extension was requested
+	var validationErr error
+	switch {
+	case ar.Spec.BreakGlass:
+		validationErr = fmt.Errorf("break-glass access cannot be extended")
+	case binding == nil:
+		validationErr = fmt.Errorf("extensions require an AccessBinding reference")
+	default:
+		validationErr = binding.ValidateExtension(ar, ext.Duration.Duration)
+	}
+
+	var details string
+	switch {
+	case validationErr != nil:
+		ar.ConcludePendingExtension(api.DeniedStatus)
+		details = fmt.Sprintf("Access extension denied: %s", validationErr)
+	case resp.Allowed:
+		duration := ext.Duration.Duration
+		ar.ConcludePendingExtension(api.GrantedStatus)
+		details = fmt.Sprintf("Access extended by %s until %s", duration, ar.Status.ExpiresAt.Format(time.RFC3339))
+		if resp.Message != "" {
+			details = fmt.Sprintf("%s: %s", details, resp.Message)
+		}
+	case resp.Status == plugin.GrantStatusPending:
+		details = fmt.Sprintf("Access extension pending: %s", resp.Message)
+	default:
+		ar.ConcludePendingExtension(api.DeniedStatus)
+		details = fmt.Sprintf("Access extension denied: %s", resp.Message)
+	}
+
+	logger.Info("Processing AccessRequest extension", "duration", ext.Duration.Duration.String(), "details", details)
+	err := s.updateStatus(ctx, ar, api.GrantedStatus, details, rtHash)
+	if err != nil {
+		return fmt.Errorf("error updating access request extension status: %w", err)
+	}
+	return nil
+}
+
+// handleApprovals will process the approver decisions in the given ar spec
+// recording each new decision in the status history. Decisions made by the
+// subject or repeated by the same approver are ignored. The ar is denied as
+// soon as one rejection is found. Returns true if the number of approvals
+// required by the ar approval policy is reached.
+func (s *Service) handleApprovals(ctx context.Context, ar *api.AccessRequest, rtHash string) (bool, error) {
+	logger := log.FromContext(ctx)
+	required := ar.Spec.Approval.GetRequiredApprovals()
+
+	changed := false
+	for _, decision := range ar.Spec.Approvals {
+		if decision.Username == ar.Spec.Subject.Username {
+			logger.Info("Ignoring self-approval", "username", decision.Username)
+			continue
+		}
+		if slices.ContainsFunc(ar.Status.Approvals, func(d api.ApprovalDecision) bool {
+			return d.Username == decision.Username
+		}) {
+			continue
+		}
+		ar.Status.Approvals = append(ar.Status.Approvals, decision)
+		changed = true
+		if decision.Decision == api.RejectedDecision {
+			logger.Info("AccessRequest rejected", "approver", decision.Username)
+			details := approvalDetails("Rejected by", decision)
+			s.Audit(ctx, ar, audit.ActionRejected, details)
+			err := s.updateStatus(ctx, ar, api.DeniedStatus, details, rtHash)
+			if err != nil {
+				return false, fmt.Errorf("error updating access request status to denied: %w", err)
+			}
+			return false, nil
+		}
+		logger.Info("AccessRequest approved", "approver", decision.Username)
+		details := approvalDetails(fmt.Sprintf("Approved (%d/%d) by", ar.ApprovalCount(), required), decision)
+		s.Audit(ctx, ar, audit.ActionApproved, details)
+		err := s.updateStatus(ctx, ar, api.RequestedStatus, details, rtHash)
+		if err != nil {
+			return false, fmt.Errorf("error updating access request approvals: %w", err)
+		}
+	}
+
+	if ar.ApprovalCount() >= required {
+		return true, nil
+	}
+	if !changed {
+		msg := fmt.Sprintf("Waiting for approval (%d/%d) from groups: %s", ar.ApprovalCount(), required, strings.Join(ar.Spec.Approval.Approvers, ", "))
+		err := s.updateStatus(ctx, ar, api.RequestedStatus, msg, rtHash)
+		if err != nil {
+			return false, fmt.Errorf("error updating access request status to requested: %w", err)
+		}
+	}
+	return false, nil
+}
+
+// approvalDetails builds the history details for the given approval decision.
+func approvalDetails(prefix string, decision api.ApprovalDecision) string {
+	details := fmt.Sprintf("%s %s", prefix, decision.Username)
+	if decision.Comment != "" {
+		details = fmt.Sprintf("%s: %s", details, decision.Comment)
+	}
+	return details
+}
+
 // handleAppNotFound handles the scenario where the application associated with the AccessRequest
 // is not found. It updates the AccessRequest status and removes Argo CD access if necessary.
 //
@@ -293,31 +834,84 @@ func (s *Service) handleAppNotFound(ctx context.Context, ar *api.AccessRequest)
 	return nil
 }
 
+// handleConclusion will conclude the given AccessRequest if it is expiring,
+// revoked or cancelled, in this order of precedence, and return the final
+// status.
+func (s *Service) handleConclusion(ctx context.Context, ar *api.AccessRequest, app *argocd.Application, rt *api.RoleTemplate, pluginNames []string) (api.Status, error) {
+	logger := log.FromContext(ctx)
+	switch {
+	case ar.IsExpiring():
+		logger.Info("AccessRequest is expired")
+		err := s.handleAccessExpired(ctx, ar, app, rt, pluginNames)
+		if err != nil {
+			return "", fmt.Errorf("error handling access expired: %w", err)
+		}
+		return api.ExpiredStatus, nil
+	case ar.Spec.Revoke:
+		logger.Info("AccessRequest revoked")
+		err := s.handleAccessRevoked(ctx, ar, app, rt, pluginNames)
+		if err != nil {
+			return "", fmt.Errorf("error handling access revoked: %w", err)
+		}
+		return api.RevokedStatus, nil
+	default:
+		logger.Info("AccessRequest cancelled")
+		err := s.handleAccessCancelled(ctx, ar, app, rt, pluginNames)
+		if err != nil {
+			return "", fmt.Errorf("error handling access cancelled: %w", err)
+		}
+		return api.CancelledStatus, nil
+	}
+}
+
 // handleAccessExpired will remove the Argo CD access for the subject and
 // update the AccessRequest status field.
-func (s *Service) handleAccessExpired(ctx context.Context, ar *api.AccessRequest, app *argocd.Application, rt *api.RoleTemplate) error {
-	log := log.FromContext(ctx)
-	statusDetails := ""
+func (s *Service) handleAccessExpired(ctx context.Context, ar *api.AccessRequest, app *argocd.Application, rt *api.RoleTemplate, pluginNames []string) error {
+	return s.concludeAccess(ctx, ar, app, rt, pluginNames, api.ExpiredStatus, "")
+}
+
+// handleAccessRevoked will remove the Argo CD access for the subject that
+// requested to give the access back before the expiration time and update
+// the AccessRequest status field.
+func (s *Service) handleAccessRevoked(ctx context.Context, ar *api.AccessRequest, app *argocd.Application, rt *api.RoleTemplate, pluginNames []string) error {
+	return s.concludeAccess(ctx, ar, app, rt, pluginNames, api.RevokedStatus, "Access revoked by the requester")
+}
+
+// handleAccessCancelled will conclude the AccessRequest that the subject
+// cancelled before it was granted. The plugin is still invoked so it can
+// clean up any pending approval (e.g. close the associated ticket). The
+// subject is also removed from the role in case the access was granted
+// while the cancellation was in progress.
+func (s *Service) handleAccessCancelled(ctx context.Context, ar *api.AccessRequest, app *argocd.Application, rt *api.RoleTemplate, pluginNames []string) error {
+	return s.concludeAccess(ctx, ar, app, rt, pluginNames, api.CancelledStatus, "Access request cancelled by the requester")
+}
+
+// concludeAccess will invoke the plugin RevokeAccess function, remove the Argo CD
+// access for the subject and update the AccessRequest status
+// to the given status. The message returned by the plugin takes precedence over
+// the given defaultDetails when updating the status history.
+func (s *Service) concludeAccess(ctx context.Context, ar *api.AccessRequest, app *argocd.Application, rt *api.RoleTemplate, pluginNames []string, status api.Status, defaultDetails string) error {
+	statusDetails := defaultDetails
 	if s.hasPlugin() {
-		resp, err := s.accessRequester.RevokeAccess(ar, app)
+		message, err := s.revokeAccess(ctx, ar, app, pluginNames)
 		if err != nil {
-			metrics.RecordPluginOperationResult("revoke_access", err)
-			return fmt.Errorf("error invoking plugin RevokeAccess function: %w", err)
+			return err
 		}
-		if resp != nil {
-			log.Info("Plugin RevokeAccess called", "plugin.status", resp.Status, "message", resp.Message)
-			statusDetails = resp.Message
-			metrics.RecordPluginOperationResult("revoke_access", resp.Status)
+		if message != "" {
+			statusDetails = message
 		}
 	}
-	err := s.RemoveArgoCDAccess(ctx, ar, rt)
-	if err != nil {
-		return fmt.Errorf("error removing access for expired request: %w", err)
+	// requests that were never initialized have no project to be updated
+	if ar.Status.TargetProject != "" {
+		err := s.RemoveArgoCDAccess(ctx, ar, rt)
+		if err != nil {
+			return fmt.Errorf("error removing access for %s request: %w", status, err)
+		}
 	}
 	hash := RoleTemplateHash(rt)
-	err = s.updateStatus(ctx, ar, api.ExpiredStatus, statusDetails, hash)
+	err := s.updateStatus(ctx, ar, status, statusDetails, hash)
 	if err != nil {
-		return fmt.Errorf("error updating access request status to expired: %w", err)
+		return fmt.Errorf("error updating access request status to %s: %w", status, err)
 	}
 	return nil
 }
@@ -529,6 +1123,56 @@ func (s *Service) getRoleTemplate(ctx context.Context, ar *api.AccessRequest) (*
 	return roleTemplate, nil
 }
 
+// handleExpiryWarning will send the "expiring" notification once the expiry
+// warning of the given AccessRequest is reached. The notified expiration
+// time is persisted in the status so the warning is sent again only if the
+// access is extended.
+func (s *Service) handleExpiryWarning(ctx context.Context, ar *api.AccessRequest) error {
+	due := ar.ExpiryWarningDue()
+	if due == nil || time.Now().Before(*due) {
+		return nil
+	}
+	expiresIn := time.Until(ar.Status.ExpiresAt.Time).Round(time.Minute)
+	message := fmt.Sprintf("Access expires in %s at %s", expiresIn, ar.Status.ExpiresAt.Format(time.RFC3339))
+	ar.Status.NotifiedExpiresAt = ar.Status.ExpiresAt.DeepCopy()
+	err := s.k8sClient.Status().Update(ctx, ar)
+	if err != nil {
+		return fmt.Errorf("error updating notified expiration: %w", err)
+	}
+	s.notify(ctx, ar, api.NotificationExpiring, message)
+	return nil
+}
+
+// getAccessBinding retrieves the AccessBinding resource referenced by the given
+// AccessRequest. The caller must make sure that ar.Spec.AccessBindingRef is set.
+func (s *Service) getAccessBinding(ctx context.Context, ar *api.AccessRequest) (*api.AccessBinding, error) {
+	binding := &api.AccessBinding{}
+	objKey := client.ObjectKey{
+		Name:      ar.Spec.AccessBindingRef.Name,
+		Namespace: ar.Spec.AccessBindingRef.Namespace,
+	}
+	err := s.k8sClient.Get(ctx, objKey, binding)
+	if err != nil {
+		return nil, err
+	}
+	return binding, nil
+}
+
+// lookupAccessBinding returns the AccessBinding referenced by the given
+// AccessRequest. Returns nil if the AccessRequest has no binding reference or
+// if the binding can't be retrieved (e.g. it was deleted).
+func (s *Service) lookupAccessBinding(ctx context.Context, ar *api.AccessRequest) *api.AccessBinding {
+	if ar.Spec.AccessBindingRef == nil {
+		return nil
+	}
+	binding, err := s.getAccessBinding(ctx, ar)
+	if err != nil {
+		log.FromContext(ctx).Info(fmt.Sprintf("Ignoring AccessBinding: error retrieving AccessBinding: %s", err))
+		return nil
+	}
+	return binding
+}
+
 // updateStatusWithRetry will retrieve the latest AccessRequest state before
 // attempting to update its status. In case of conflict error, it will retry
 // using the DefaultRetry backoff which has the following configs:
@@ -556,9 +1200,22 @@ func (s *Service) updateStatus(ctx context.Context, ar *api.AccessRequest, statu
 		log.Debug("No need to update AccessRequest status")
 		return nil
 	}
+	changed := ar.Status.RequestState != status
+	transitioned := changed || curMessage != message
 	ar.UpdateStatusHistory(status, message)
 	ar.Status.RoleTemplateHash = rtHash
-	return s.k8sClient.Status().Update(ctx, ar)
+	err := s.k8sClient.Status().Update(ctx, ar)
+	if err != nil {
+		return err
+	}
+	if transitioned {
+		s.RecordStatusEvent(ctx, ar, status, message)
+	}
+	if changed {
+		s.Notify(ctx, ar, status, message)
+		s.Audit(ctx, ar, audit.ActionFromStatus(status), message)
+	}
+	return nil
 }
 
 // removeSubjectFromRole will iterate over the roles in the given project and
@@ -672,37 +1329,178 @@ type AllowedResponse struct {
 	Message string
 }
 
+// setPluginApprovedCondition will update the PluginApproved condition in the
+// given ar with the decision returned by the plugin.
+func setPluginApprovedCondition(ar *api.AccessRequest, resp *AllowedResponse) {
+	message := resp.Message
+	switch resp.Status {
+	case plugin.GrantStatusGranted:
+		if message == "" {
+			message = "Access approved by the plugin"
+		}
+		ar.SetCondition(api.ConditionPluginApproved, metav1.ConditionTrue, "Granted", message)
+	case plugin.GrantStatusDenied:
+		if message == "" {
+			message = "Access denied by the plugin"
+		}
+		ar.SetCondition(api.ConditionPluginApproved, metav1.ConditionFalse, "Denied", message)
+	default:
+		if message == "" {
+			message = "Waiting for the plugin decision"
+		}
+		ar.SetCondition(api.ConditionPluginApproved, metav1.ConditionUnknown, "Pending", message)
+	}
+}
+
 // hasPlugin will check if this service is configured with an AccessRequester plugin.
 func (s *Service) hasPlugin() bool {
-	if s.accessRequester == nil {
-		return false
+	return len(s.plugins) > 0
+}
+
+// pluginSelection returns the names of the plugins selected by the given
+// binding or role. The binding selection takes precedence over the role
+// selection. Returns nil if no plugin is selected.
+func pluginSelection(binding *api.AccessBinding, role *api.RoleTemplate) []string {
+	if binding != nil && len(binding.Spec.Plugins) > 0 {
+		return binding.Spec.Plugins
+	}
+	if role != nil {
+		return role.Spec.Plugins
+	}
+	return nil
+}
+
+// selectPlugins returns the registered plugins with the given names in the
+// same order. Returns all registered plugins if no name is provided. Returns
+// an error if any of the names isn't registered.
+func (s *Service) selectPlugins(names []string) ([]namedPlugin, error) {
+	if len(names) == 0 {
+		return s.plugins, nil
 	}
-	return true
+	selected := []namedPlugin{}
+	for _, name := range names {
+		i := slices.IndexFunc(s.plugins, func(p namedPlugin) bool {
+			return p.name == name
+		})
+		if i < 0 {
+			return nil, fmt.Errorf("plugin %q is not configured in the controller", name)
+		}
+		selected = append(selected, s.plugins[i])
+	}
+	return selected, nil
+}
+
+// pluginContext returns the context used to invoke the plugin. The returned
+// context is done once the configured plugin timeout elapses.
+func (s *Service) pluginContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return plugin.ContextWithTimeout(ctx, s.pluginTimeout)
 }
 
-// Allowed will invoke the GrantAccess() function from this Service.accessRequester plugin.
-// If the Service.accessRequester plugin is nil, it will allow the controller to proceed with
-// handling the permission.
-func (s *Service) Allowed(ctx context.Context, ar *api.AccessRequest, app *argocd.Application) (*AllowedResponse, error) {
+// Allowed will invoke the GrantAccess() function of the plugins with the given
+// names, or of all registered plugins if no name is provided. The access is
+// only allowed if all plugins grant it. Denials are final: the remaining
+// plugins aren't invoked once a plugin denies the access. The messages
+// returned by the invoked plugins are concatenated in the response. If no
+// plugin is registered, it will allow the controller to proceed with handling
+// the permission.
+func (s *Service) Allowed(ctx context.Context, ar *api.AccessRequest, app *argocd.Application, pluginNames []string) (*AllowedResponse, error) {
 	// always return true if there is no plugin registered
 	if !s.hasPlugin() {
 		return &AllowedResponse{Allowed: true, Message: ""}, nil
 	}
-	resp, err := s.accessRequester.GrantAccess(ar, app)
+	plugins, err := s.selectPlugins(pluginNames)
+	if err != nil {
+		return nil, fmt.Errorf("error selecting plugins: %w", err)
+	}
+	status := plugin.GrantStatusGranted
+	messages := []string{}
+	for _, p := range plugins {
+		resp, err := s.invokeGrantAccess(ctx, p, ar, app)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Message != "" {
+			messages = append(messages, resp.Message)
+		}
+		if resp.Status == plugin.GrantStatusDenied {
+			status = plugin.GrantStatusDenied
+			break
+		}
+		// the first plugin not granting the access defines the status
+		if status == plugin.GrantStatusGranted {
+			status = resp.Status
+		}
+	}
+	return &AllowedResponse{
+		Allowed: status == plugin.GrantStatusGranted,
+		Status:  status,
+		Message: strings.Join(messages, pluginMessageSeparator),
+	}, nil
+}
+
+// pluginMessageSeparator is used to concatenate the messages returned by
+// multiple plugins.
+const pluginMessageSeparator = "; "
+
+// invokeGrantAccess will invoke the GrantAccess() function of the given plugin
+// and record the result in the plugin metrics.
+func (s *Service) invokeGrantAccess(ctx context.Context, p namedPlugin, ar *api.AccessRequest, app *argocd.Application) (*plugin.GrantResponse, error) {
+	pluginCtx, cancel := s.pluginContext(ctx)
+	defer cancel()
+	resp, err := p.accessRequester.GrantAccess(pluginCtx, ar, app)
 	if err != nil {
 		metrics.RecordPluginOperationResult("grant_access", err)
-		return nil, fmt.Errorf("error invoking plugin GrantAccess function: %w", err)
+		return nil, fmt.Errorf("error invoking plugin %s GrantAccess function: %w", p.name, err)
 	}
 	if resp == nil {
 		metrics.RecordPluginOperationResult("grant_access", errors.New("null response"))
-		return nil, errors.New("plugin GrantAccess call returned null response")
+		return nil, fmt.Errorf("plugin %s GrantAccess call returned null response", p.name)
+	}
+	if !resp.Status.IsValid() {
+		metrics.RecordPluginOperationResult("grant_access", errors.New("invalid status"))
+		return nil, fmt.Errorf("plugin %s GrantAccess call returned invalid status %q", p.name, resp.Status)
 	}
-	allowed := resp.Status == plugin.GrantStatusGranted
-
 	metrics.RecordPluginOperationResult("grant_access", resp.Status)
-	return &AllowedResponse{
-		Allowed: allowed,
-		Status:  resp.Status,
-		Message: resp.Message,
-	}, nil
+	return resp, nil
+}
+
+// revokeAccess will invoke the RevokeAccess() function of the plugins with the
+// given names, or of all registered plugins if no name is provided. All plugins
+// are invoked even if some of them fail, so the access is revoked in as many
+// systems as possible. Returns the concatenated messages returned by the
+// plugins.
+func (s *Service) revokeAccess(ctx context.Context, ar *api.AccessRequest, app *argocd.Application, pluginNames []string) (string, error) {
+	log := log.FromContext(ctx)
+	plugins, err := s.selectPlugins(pluginNames)
+	if err != nil {
+		return "", fmt.Errorf("error selecting plugins: %w", err)
+	}
+	messages := []string{}
+	errs := []error{}
+	for _, p := range plugins {
+		pluginCtx, cancel := s.pluginContext(ctx)
+		resp, err := p.accessRequester.RevokeAccess(pluginCtx, ar, app)
+		cancel()
+		if err != nil {
+			metrics.RecordPluginOperationResult("revoke_access", err)
+			errs = append(errs, fmt.Errorf("error invoking plugin %s RevokeAccess function: %w", p.name, err))
+			continue
+		}
+		if resp != nil && !resp.Status.IsValid() {
+			metrics.RecordPluginOperationResult("revoke_access", errors.New("invalid status"))
+			errs = append(errs, fmt.Errorf("plugin %s RevokeAccess call returned invalid status %q", p.name, resp.Status))
+			continue
+		}
+		if resp != nil {
+			log.Info("Plugin RevokeAccess called", "plugin", p.name, "plugin.status", resp.Status, "message", resp.Message)
+			if resp.Message != "" {
+				messages = append(messages, resp.Message)
+			}
+			metrics.RecordPluginOperationResult("revoke_access", resp.Status)
+		}
+	}
+	if len(errs) > 0 {
+		return "", errors.Join(errs...)
+	}
+	return strings.Join(messages, pluginMessageSeparator), nil
 }
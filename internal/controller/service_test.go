@@ -3,21 +3,27 @@ package controller_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	argocd "github.com/argoproj-labs/argocd-ephemeral-access/api/argoproj/v1alpha1"
 	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
 	"github.com/argoproj-labs/argocd-ephemeral-access/internal/controller"
+	"github.com/argoproj-labs/argocd-ephemeral-access/internal/controller/audit"
+	"github.com/argoproj-labs/argocd-ephemeral-access/internal/controller/notification"
 	"github.com/argoproj-labs/argocd-ephemeral-access/pkg/plugin"
 	"github.com/argoproj-labs/argocd-ephemeral-access/test/mocks"
 	"github.com/argoproj-labs/argocd-ephemeral-access/test/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -89,6 +95,20 @@ func TestHandlePermission(t *testing.T) {
 			}).Maybe()
 		clientMock.EXPECT().Status().Return(resourceWriterMock).Maybe()
 	}
+	newNotifier := func(t *testing.T) (*notification.Notifier, *channelSink) {
+		t.Helper()
+		cfg := mocks.NewMockConfigurer(t)
+		cfg.EXPECT().NotificationExpiryWarning().Return(15 * time.Minute)
+		cfg.EXPECT().NotificationMaxRetries().Return(0)
+		cfg.EXPECT().NotificationSecretName().Return("controller-notification-secret")
+		cfg.EXPECT().NotificationSecretNamespace().Return("controller-ns")
+		cfg.EXPECT().NotificationWebhookURL().Return("")
+		notifier, err := notification.NewNotifier(nil, cfg)
+		require.NoError(t, err)
+		sink := &channelSink{notifications: make(chan *notification.Notification, 10)}
+		notifier.AddSink(sink)
+		return notifier, sink
+	}
 
 	t.Run("will validate the project", func(t *testing.T) {
 		t.Run("will invalidate the AccessRequest if the application project is not set", func(t *testing.T) {
@@ -341,6 +361,211 @@ func TestHandlePermission(t *testing.T) {
 		})
 	})
 
+	t.Run("will validate the access binding", func(t *testing.T) {
+		newBinding := func(policy *api.DurationPolicy) *api.AccessBinding {
+			return &api.AccessBinding{
+				Spec: api.AccessBindingSpec{
+					RoleTemplateRef: api.RoleTemplateReference{Name: "someRole"},
+					Duration:        policy,
+				},
+			}
+		}
+		setupBinding := func(clientMock *mocks.MockK8sClient, binding *api.AccessBinding) {
+			clientMock.EXPECT().
+				Get(mock.Anything, mock.Anything, mock.AnythingOfType("*v1alpha1.AccessBinding")).
+				RunAndReturn(func(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					if binding == nil {
+						return apierrors.NewNotFound(schema.GroupResource{Group: "ephemeral-access.argoproj-labs.io", Resource: "AccessBinding"}, key.Name)
+					}
+					abLocal := obj.(*api.AccessBinding)
+					abLocal.Spec = binding.DeepCopy().Spec
+					return nil
+				})
+		}
+		newAccessRequest := func(duration time.Duration) *api.AccessRequest {
+			ar := utils.NewAccessRequest("test", "default", "someApp", "someAppNs", "someRole", "someRoleNs", "user-id", "some-user")
+			ar.Spec.Duration = metav1.Duration{Duration: duration}
+			ar.Spec.AccessBindingRef = &api.AccessBindingReference{
+				Name:      "some-binding",
+				Namespace: "default",
+			}
+			return ar
+		}
+		t.Run("will invalidate the AccessRequest if the duration is out of range", func(t *testing.T) {
+			// Given
+			updatedAR := &api.AccessRequest{}
+			clientMock := mocks.NewMockK8sClient(t)
+			setup(clientMock, newApp("some-project"), nil, newProject(nil), nil, updatedAR)
+			setupBinding(clientMock, newBinding(&api.DurationPolicy{
+				Max: &metav1.Duration{Duration: time.Hour},
+			}))
+			ar := newAccessRequest(2 * time.Hour)
+			svc := controller.NewService(clientMock, nil, nil)
+
+			// When
+			status, err := svc.HandlePermission(context.Background(), ar)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, api.InvalidStatus, status, "status must be invalid")
+			assert.Equal(t, api.InvalidStatus, updatedAR.Status.RequestState)
+			assert.Contains(t, updatedAR.GetLastStatusDetails(api.InvalidStatus), "longer than the maximum allowed")
+		})
+		t.Run("will invalidate the AccessRequest if the required justification is missing", func(t *testing.T) {
+			// Given
+			updatedAR := &api.AccessRequest{}
+			clientMock := mocks.NewMockK8sClient(t)
+			setup(clientMock, newApp("some-project"), nil, newProject(nil), nil, updatedAR)
+			binding := newBinding(nil)
+			binding.Spec.Justification = &api.JustificationPolicy{Required: true}
+			setupBinding(clientMock, binding)
+			ar := newAccessRequest(time.Hour)
+			svc := controller.NewService(clientMock, nil, nil)
+
+			// When
+			status, err := svc.HandlePermission(context.Background(), ar)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, api.InvalidStatus, status, "status must be invalid")
+			assert.Equal(t, "Invalid justification: justification is required", updatedAR.GetLastStatusDetails(api.InvalidStatus))
+		})
+		t.Run("will invalidate the AccessRequest if the access binding is not found", func(t *testing.T) {
+			// Given
+			updatedAR := &api.AccessRequest{}
+			clientMock := mocks.NewMockK8sClient(t)
+			setup(clientMock, newApp("some-project"), nil, newProject(nil), nil, updatedAR)
+			setupBinding(clientMock, nil)
+			ar := newAccessRequest(time.Hour)
+			svc := controller.NewService(clientMock, nil, nil)
+
+			// When
+			status, err := svc.HandlePermission(context.Background(), ar)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, api.InvalidStatus, status, "status must be invalid")
+			assert.Equal(t, "AccessBinding default/some-binding not found", updatedAR.GetLastStatusDetails(api.InvalidStatus))
+		})
+		t.Run("will remove the Argo CD access if the access binding of a granted request is deleted", func(t *testing.T) {
+			// Given
+			updatedAR := &api.AccessRequest{}
+			updatedProject := &argocd.AppProject{}
+			rt := newRoleTemplate(api.RoleTemplateSpec{
+				Name:     "some-role-template",
+				Policies: []string{"policy1"},
+			})
+			prj := newProject([]argocd.ProjectRole{
+				{
+					Name:     "ephemeral-some-role-template-someAppNs-someApp",
+					Policies: []string{"policy1"},
+					Groups:   []string{"other-user", "some-user"},
+				},
+			})
+			clientMock := mocks.NewMockK8sClient(t)
+			setup(clientMock, newApp("some-project"), rt, prj, updatedProject, updatedAR)
+			setupBinding(clientMock, nil)
+			ar := newAccessRequest(time.Hour)
+			ar.Status.TargetProject = "some-project"
+			ar.Status.RequestState = api.GrantedStatus
+			svc := controller.NewService(clientMock, nil, nil)
+
+			// When
+			status, err := svc.HandlePermission(context.Background(), ar)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, api.InvalidStatus, status, "status must be invalid")
+			assert.Equal(t, api.InvalidStatus, updatedAR.Status.RequestState)
+			require.Len(t, updatedProject.Spec.Roles, 1)
+			assert.Equal(t, []string{"other-user"}, updatedProject.Spec.Roles[0].Groups, "subject must be removed from role")
+		})
+		t.Run("will cancel a pending request and invoke the plugin if the access binding is deleted", func(t *testing.T) {
+			// Given
+			updatedAR := &api.AccessRequest{}
+			rt := newRoleTemplate(api.RoleTemplateSpec{
+				Name:     "some-role-template",
+				Policies: []string{"policy1"},
+			})
+			clientMock := mocks.NewMockK8sClient(t)
+			setup(clientMock, newApp("some-project"), rt, newProject(nil), &argocd.AppProject{}, updatedAR)
+			setupBinding(clientMock, nil)
+			pluginMock := mocks.NewMockAccessRequesterV2(t)
+			pluginMock.EXPECT().
+				RevokeAccess(mock.Anything, mock.AnythingOfType("*v1alpha1.AccessRequest"), mock.AnythingOfType("*v1alpha1.Application")).
+				Return(&plugin.RevokeResponse{Status: plugin.RevokeStatusRevoked, Message: "ticket closed"}, nil)
+			ar := newAccessRequest(time.Hour)
+			ar.Status.TargetProject = "some-project"
+			ar.Status.RequestState = api.RequestedStatus
+			ar.Spec.Cancel = true
+			svc := controller.NewService(clientMock, nil, pluginMock)
+
+			// When
+			status, err := svc.HandlePermission(context.Background(), ar)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, api.CancelledStatus, status)
+			assert.Equal(t, api.CancelledStatus, updatedAR.Status.RequestState)
+			assert.Equal(t, "ticket closed", updatedAR.GetLastStatusDetails(api.CancelledStatus))
+		})
+		t.Run("will revoke a request even if the duration is no longer valid", func(t *testing.T) {
+			// Given
+			updatedAR := &api.AccessRequest{}
+			rt := newRoleTemplate(api.RoleTemplateSpec{
+				Name:     "some-role-template",
+				Policies: []string{"policy1"},
+			})
+			clientMock := mocks.NewMockK8sClient(t)
+			setup(clientMock, newApp("some-project"), rt, newProject(nil), &argocd.AppProject{}, updatedAR)
+			setupBinding(clientMock, newBinding(&api.DurationPolicy{
+				Max: &metav1.Duration{Duration: time.Hour},
+			}))
+			pluginMock := mocks.NewMockAccessRequesterV2(t)
+			pluginMock.EXPECT().
+				RevokeAccess(mock.Anything, mock.AnythingOfType("*v1alpha1.AccessRequest"), mock.AnythingOfType("*v1alpha1.Application")).
+				Return(&plugin.RevokeResponse{Status: plugin.RevokeStatusRevoked}, nil)
+			ar := newAccessRequest(2 * time.Hour)
+			ar.Status.TargetProject = "some-project"
+			ar.Status.RequestState = api.RequestedStatus
+			ar.Spec.Revoke = true
+			svc := controller.NewService(clientMock, nil, pluginMock)
+
+			// When
+			status, err := svc.HandlePermission(context.Background(), ar)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, api.RevokedStatus, status)
+			assert.Equal(t, api.RevokedStatus, updatedAR.Status.RequestState)
+		})
+		t.Run("will grant access if the duration is within range", func(t *testing.T) {
+			// Given
+			updatedAR := &api.AccessRequest{}
+			updatedProject := &argocd.AppProject{}
+			rt := newRoleTemplate(api.RoleTemplateSpec{
+				Name:     "some-role-template",
+				Policies: []string{"policy1"},
+			})
+			clientMock := mocks.NewMockK8sClient(t)
+			setup(clientMock, newApp("some-project"), rt, newProject(nil), updatedProject, updatedAR)
+			setupBinding(clientMock, newBinding(&api.DurationPolicy{
+				Min: &metav1.Duration{Duration: 30 * time.Minute},
+				Max: &metav1.Duration{Duration: time.Hour},
+			}))
+			ar := newAccessRequest(time.Hour)
+			svc := controller.NewService(clientMock, nil, nil)
+
+			// When
+			status, err := svc.HandlePermission(context.Background(), ar)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, api.GrantedStatus, status)
+			assert.Equal(t, api.GrantedStatus, updatedAR.Status.RequestState)
+		})
+	})
+
 	t.Run("will handle application not found", func(t *testing.T) {
 		t.Run("will remove Argo CD permissions successfully", func(t *testing.T) {
 			// Given
@@ -549,110 +774,1090 @@ func TestHandlePermission(t *testing.T) {
 		})
 	})
 
-	t.Run("will handle plugins", func(t *testing.T) {
-		t.Run("will update the history with the latest plugin message", func(t *testing.T) {
+	t.Run("will handle access revoked", func(t *testing.T) {
+		t.Run("will remove argocd access and invoke plugin revoke", func(t *testing.T) {
 			// Given
-			resourceWriterMock := mocks.NewMockSubResourceWriter(t)
-			resourceWriterMock.EXPECT().Update(mock.Anything, mock.AnythingOfType("*v1alpha1.AccessRequest")).Return(nil)
+			updatedAR := &api.AccessRequest{}
+			updatedProject := &argocd.AppProject{}
+			rt := newRoleTemplate(api.RoleTemplateSpec{
+				Name:        "some-role-template",
+				Description: "some role description",
+				Policies:    []string{"policy1"},
+			})
+			prj := newProject(
+				[]argocd.ProjectRole{
+					{
+						Name:        "ephemeral-some-role-template-someAppNs-someApp",
+						Description: "some role description",
+						Policies:    []string{"policy1"},
+						JWTTokens:   []argocd.JWTToken{},
+						Groups:      []string{"some-user", "user-to-be-removed"},
+					},
+				},
+			)
 			clientMock := mocks.NewMockK8sClient(t)
-			clientMock.EXPECT().Status().Return(resourceWriterMock)
-			clientMock.EXPECT().
-				Get(mock.Anything, mock.Anything, mock.AnythingOfType("*v1alpha1.Application")).
-				RunAndReturn(func(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
-					app := obj.(*argocd.Application)
-					app.Spec.Project = "some-project"
-					return nil
-				})
-			clientMock.EXPECT().
-				Get(mock.Anything, mock.Anything, mock.AnythingOfType("*v1alpha1.RoleTemplate")).
-				RunAndReturn(func(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
-					rt := obj.(*api.RoleTemplate)
-					rt.Spec.Name = "some-role"
-					rt.Spec.Description = "some-role-description"
-					rt.Spec.Policies = []string{"some-policy"}
-					return nil
-				})
-			pluginMock := mocks.NewMockAccessRequester(t)
-			response1 := &plugin.GrantResponse{
-				Status:  plugin.GrantStatusPending,
-				Message: "message 1",
-			}
-			pluginMock.EXPECT().
-				GrantAccess(mock.AnythingOfType("*v1alpha1.AccessRequest"), mock.AnythingOfType("*v1alpha1.Application")).
-				Return(response1, nil).Once()
-			pendingMessage := "expected message"
-
-			response2 := &plugin.GrantResponse{
-				Status:  plugin.GrantStatusPending,
-				Message: pendingMessage,
-			}
+			setup(clientMock, newApp("someProject"), rt, prj, updatedProject, updatedAR)
+			pluginMock := mocks.NewMockAccessRequesterV2(t)
 			pluginMock.EXPECT().
-				GrantAccess(mock.AnythingOfType("*v1alpha1.AccessRequest"), mock.AnythingOfType("*v1alpha1.Application")).
-				Return(response2, nil).Times(2)
+				RevokeAccess(mock.Anything, mock.AnythingOfType("*v1alpha1.AccessRequest"), mock.AnythingOfType("*v1alpha1.Application")).
+				Return(&plugin.RevokeResponse{Status: plugin.RevokeStatusRevoked}, nil)
+			ar := utils.NewAccessRequest("test", "default", "someApp", "someAppNs", "someRole", "someRoleNs", "user-id", "user-to-be-removed")
+			ar.Status.TargetProject = "someProject"
+			ar.Status.RequestState = api.GrantedStatus
+			ar.Spec.Revoke = true
+			svc := controller.NewService(clientMock, nil, pluginMock)
 
-			approvedMessage := "approved"
-			response3 := &plugin.GrantResponse{
-				Status:  plugin.GrantStatusGranted,
-				Message: "approved",
-			}
-			pluginMock.EXPECT().
-				GrantAccess(mock.AnythingOfType("*v1alpha1.AccessRequest"), mock.AnythingOfType("*v1alpha1.Application")).
-				Return(response3, nil)
+			// When
+			status, err := svc.HandlePermission(context.Background(), ar)
 
-			clientMock.EXPECT().
-				Get(mock.Anything, mock.Anything, mock.AnythingOfType("*v1alpha1.AppProject")).
-				RunAndReturn(func(ctx context.Context, key types.NamespacedName, obj client.Object, opts ...client.GetOption) error {
-					obj = &argocd.AppProject{}
-					return nil
-				})
-			clientMock.EXPECT().
-				Patch(mock.Anything, mock.AnythingOfType("*v1alpha1.AppProject"), mock.Anything, mock.Anything).
-				Return(nil).
-				Once()
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, api.RevokedStatus, status)
+			assert.Equal(t, api.RevokedStatus, updatedAR.Status.RequestState)
+			assert.Equal(t, "Access revoked by the requester", updatedAR.GetLastStatusDetails(api.RevokedStatus))
+			assert.Equal(t, []string{"some-user"}, updatedProject.Spec.Roles[0].Groups, "subject must be removed from role")
+			assert.True(t, updatedAR.IsConcluded())
+		})
+	})
 
+	t.Run("will handle access cancelled", func(t *testing.T) {
+		t.Run("will invoke plugin revoke and conclude the request without granting access", func(t *testing.T) {
+			// Given
+			updatedAR := &api.AccessRequest{}
+			updatedProject := &argocd.AppProject{}
+			rt := newRoleTemplate(api.RoleTemplateSpec{
+				Name:        "some-role-template",
+				Description: "some role description",
+				Policies:    []string{"policy1"},
+			})
+			prj := newProject(
+				[]argocd.ProjectRole{
+					{
+						Name:        "ephemeral-some-role-template-someAppNs-someApp",
+						Description: "some role description",
+						Policies:    []string{"policy1"},
+						JWTTokens:   []argocd.JWTToken{},
+						Groups:      []string{"some-user"},
+					},
+				},
+			)
+			clientMock := mocks.NewMockK8sClient(t)
+			setup(clientMock, newApp("someProject"), rt, prj, updatedProject, updatedAR)
+			pluginMock := mocks.NewMockAccessRequesterV2(t)
+			pluginMock.EXPECT().
+				RevokeAccess(mock.Anything, mock.AnythingOfType("*v1alpha1.AccessRequest"), mock.AnythingOfType("*v1alpha1.Application")).
+				Return(&plugin.RevokeResponse{Status: plugin.RevokeStatusRevoked, Message: "Ticket closed"}, nil)
+			ar := utils.NewAccessRequest("test", "default", "someApp", "someAppNs", "someRole", "someRoleNs", "user-id", "pending-user")
+			ar.Status.TargetProject = "someProject"
+			ar.Status.RequestState = api.RequestedStatus
+			ar.Spec.Cancel = true
 			svc := controller.NewService(clientMock, nil, pluginMock)
-			ar := utils.NewAccessRequest("test", "default", "someApp", "someAppNs", "someRole", "someRoleNs", "user-id", "")
-			ar.Status.TargetProject = "some-project"
 
 			// When
-			svc.HandlePermission(context.Background(), ar)
-			svc.HandlePermission(context.Background(), ar)
-			svc.HandlePermission(context.Background(), ar)
 			status, err := svc.HandlePermission(context.Background(), ar)
 
 			// Then
 			assert.NoError(t, err)
-			assert.NotNil(t, status, "status is nil")
-			assert.Equal(t, api.GrantedStatus, status)
-			assert.Equal(t, pendingMessage, ar.GetLastStatusDetails(api.RequestedStatus))
-			assert.Equal(t, approvedMessage, ar.GetLastStatusDetails(api.GrantedStatus))
-			assert.Len(t, ar.Status.History, 4)
+			assert.Equal(t, api.CancelledStatus, status)
+			assert.Equal(t, api.CancelledStatus, updatedAR.Status.RequestState)
+			assert.Equal(t, "Ticket closed", updatedAR.GetLastStatusDetails(api.CancelledStatus))
+			assert.NotContains(t, updatedProject.Spec.Roles[0].Groups, "pending-user")
+			assert.True(t, updatedAR.IsConcluded())
+			pluginMock.AssertNotCalled(t, "GrantAccess", mock.Anything, mock.Anything, mock.Anything)
 		})
 	})
-}
 
-func newAR(history ...api.AccessRequestHistory) *api.AccessRequest {
-	return &api.AccessRequest{
-		Status: api.AccessRequestStatus{History: history},
-	}
-}
+	t.Run("will handle approvals", func(t *testing.T) {
+		rt := newRoleTemplate(api.RoleTemplateSpec{
+			Name:     "some-role-template",
+			Policies: []string{"policy1"},
+		})
+		newAccessRequest := func(decisions ...api.ApprovalDecision) *api.AccessRequest {
+			ar := utils.NewAccessRequest("test", "default", "someApp", "someAppNs", "someRole", "someRoleNs", "user-id", "some-user")
+			ar.Spec.Duration = metav1.Duration{Duration: time.Hour}
+			ar.Spec.Approval = &api.ApprovalPolicy{
+				Approvers:         []string{"approvers", "admins"},
+				RequiredApprovals: 2,
+			}
+			ar.Spec.Approvals = decisions
+			return ar
+		}
+		approval := func(username string, decision api.ApprovalDecisionType, comment string) api.ApprovalDecision {
+			return api.ApprovalDecision{Username: username, Decision: decision, Comment: comment, Time: metav1.Now()}
+		}
+		t.Run("will keep the request waiting for approval", func(t *testing.T) {
+			// Given
+			updatedAR := &api.AccessRequest{}
+			clientMock := mocks.NewMockK8sClient(t)
+			setup(clientMock, newApp("some-project"), rt, newProject(nil), &argocd.AppProject{}, updatedAR)
+			ar := newAccessRequest()
+			svc := controller.NewService(clientMock, nil, nil)
 
-func TestGetLastHistory(t *testing.T) {
-	t1 := metav1.NewTime(time.Now().Add(-2 * time.Second))
-	t2 := metav1.NewTime(time.Now().Add(-1 * time.Second))
+			// When
+			status, err := svc.HandlePermission(context.Background(), ar)
 
-	tests := []struct {
-		name     string
-		ar       *api.AccessRequest
-		status   api.Status
-		wantNil  bool
-		wantTime metav1.Time
-	}{
-		{
-			name:    "returns nil when history is empty",
-			ar:      newAR(),
-			status:  api.GrantedStatus,
-			wantNil: true,
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, api.RequestedStatus, status)
+			assert.Equal(t, api.RequestedStatus, updatedAR.Status.RequestState)
+			assert.Equal(t, "Waiting for approval (0/2) from groups: approvers, admins", updatedAR.GetLastStatusDetails(api.RequestedStatus))
+		})
+		t.Run("will record each decision in the history until quorum is reached", func(t *testing.T) {
+			// Given
+			updatedAR := &api.AccessRequest{}
+			clientMock := mocks.NewMockK8sClient(t)
+			setup(clientMock, newApp("some-project"), rt, newProject(nil), &argocd.AppProject{}, updatedAR)
+			ar := newAccessRequest(
+				approval("some-user", api.ApprovedDecision, ""),
+				approval("approver1", api.ApprovedDecision, "looks good"),
+				approval("approver1", api.ApprovedDecision, ""),
+			)
+			svc := controller.NewService(clientMock, nil, nil)
+
+			// When
+			status, err := svc.HandlePermission(context.Background(), ar)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, api.RequestedStatus, status)
+			assert.Equal(t, 1, updatedAR.ApprovalCount(), "self-approval and duplicates must be ignored")
+			assert.Equal(t, "Approved (1/2) by approver1: looks good", updatedAR.GetLastStatusDetails(api.RequestedStatus))
+		})
+		t.Run("will grant access once quorum is reached", func(t *testing.T) {
+			// Given
+			updatedAR := &api.AccessRequest{}
+			clientMock := mocks.NewMockK8sClient(t)
+			setup(clientMock, newApp("some-project"), rt, newProject(nil), &argocd.AppProject{}, updatedAR)
+			ar := newAccessRequest(
+				approval("approver1", api.ApprovedDecision, ""),
+				approval("approver2", api.ApprovedDecision, ""),
+			)
+			svc := controller.NewService(clientMock, nil, nil)
+
+			// When
+			status, err := svc.HandlePermission(context.Background(), ar)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, api.GrantedStatus, status)
+			assert.Equal(t, api.GrantedStatus, updatedAR.Status.RequestState)
+			assert.Equal(t, 2, updatedAR.ApprovalCount())
+			assert.NotNil(t, updatedAR.Status.GetLastHistory(api.RequestedStatus))
+			assert.Equal(t, "Approved (2/2) by approver2", updatedAR.GetLastStatusDetails(api.RequestedStatus))
+		})
+		t.Run("will deny access when rejected", func(t *testing.T) {
+			// Given
+			updatedAR := &api.AccessRequest{}
+			clientMock := mocks.NewMockK8sClient(t)
+			setup(clientMock, newApp("some-project"), rt, newProject(nil), &argocd.AppProject{}, updatedAR)
+			ar := newAccessRequest(
+				approval("approver1", api.ApprovedDecision, ""),
+				approval("approver2", api.RejectedDecision, "not during the freeze"),
+			)
+			svc := controller.NewService(clientMock, nil, nil)
+
+			// When
+			status, err := svc.HandlePermission(context.Background(), ar)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, api.DeniedStatus, status)
+			assert.Equal(t, api.DeniedStatus, updatedAR.Status.RequestState)
+			assert.Equal(t, "Rejected by approver2: not during the freeze", updatedAR.GetLastStatusDetails(api.DeniedStatus))
+			assert.Equal(t, "Approved (1/2) by approver1", updatedAR.GetLastStatusDetails(api.RequestedStatus))
+		})
+		t.Run("will audit decisions and publish the rejection", func(t *testing.T) {
+			// Given
+			updatedAR := &api.AccessRequest{}
+			clientMock := mocks.NewMockK8sClient(t)
+			setup(clientMock, newApp("some-project"), rt, newProject(nil), &argocd.AppProject{}, updatedAR)
+			ar := newAccessRequest(
+				approval("approver1", api.ApprovedDecision, ""),
+				approval("approver2", api.RejectedDecision, "not during the freeze"),
+			)
+			var buf strings.Builder
+			recorder := record.NewFakeRecorder(10)
+			svc := controller.NewService(clientMock, nil, nil,
+				controller.WithAuditLogger(audit.NewLogger(&buf)),
+				controller.WithEventRecorder(recorder))
+
+			// When
+			status, err := svc.HandlePermission(context.Background(), ar)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, api.DeniedStatus, status)
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			require.Len(t, lines, 5)
+			assert.Contains(t, lines[0], `"action":"created"`)
+			assert.Contains(t, lines[1], `"action":"approved"`)
+			assert.Contains(t, lines[2], `"action":"requested"`)
+			assert.Contains(t, lines[3], `"action":"rejected"`)
+			assert.Contains(t, lines[3], `"details":"Rejected by approver2: not during the freeze"`)
+			assert.Contains(t, lines[4], `"action":"denied"`)
+			events := []string{}
+			for len(recorder.Events) > 0 {
+				events = append(events, <-recorder.Events)
+			}
+			assert.Contains(t, events, "Normal AccessRequested Access requested for some-user: Approved (1/2) by approver1")
+			assert.Contains(t, events, "Warning AccessDenied Access denied for some-user: Rejected by approver2: not during the freeze")
+		})
+	})
+
+	t.Run("will handle scheduled access", func(t *testing.T) {
+		rt := newRoleTemplate(api.RoleTemplateSpec{
+			Name:     "some-role-template",
+			Policies: []string{"policy1"},
+		})
+		newAccessRequest := func(startsAt time.Time) *api.AccessRequest {
+			ar := utils.NewAccessRequest("test", "default", "someApp", "someAppNs", "someRole", "someRoleNs", "user-id", "some-user")
+			ar.Spec.Duration = metav1.Duration{Duration: time.Hour}
+			ar.Spec.StartsAt = &metav1.Time{Time: startsAt}
+			return ar
+		}
+		t.Run("will hold the request until the start time", func(t *testing.T) {
+			// Given
+			updatedAR := &api.AccessRequest{}
+			updatedProject := &argocd.AppProject{}
+			clientMock := mocks.NewMockK8sClient(t)
+			setup(clientMock, newApp("some-project"), rt, newProject(nil), updatedProject, updatedAR)
+			startsAt := time.Now().Add(time.Hour).Truncate(time.Second)
+			ar := newAccessRequest(startsAt)
+			svc := controller.NewService(clientMock, nil, nil)
+
+			// When
+			status, err := svc.HandlePermission(context.Background(), ar)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, api.ScheduledStatus, status)
+			assert.Equal(t, api.ScheduledStatus, updatedAR.Status.RequestState)
+			assert.Equal(t, "Access scheduled to start at "+startsAt.Format(time.RFC3339), updatedAR.GetLastStatusDetails(api.ScheduledStatus))
+			assert.Nil(t, updatedAR.Status.ExpiresAt)
+			assert.Empty(t, updatedProject.Spec.Roles, "access must not be granted before the start time")
+		})
+		t.Run("will keep the request scheduled while the start time is in the future", func(t *testing.T) {
+			// Given
+			updatedAR := &api.AccessRequest{}
+			clientMock := mocks.NewMockK8sClient(t)
+			setup(clientMock, newApp("some-project"), rt, newProject(nil), &argocd.AppProject{}, updatedAR)
+			ar := newAccessRequest(time.Now().Add(time.Hour))
+			ar.Status.RequestState = api.ScheduledStatus
+			ar.Status.TargetProject = "some-project"
+			svc := controller.NewService(clientMock, nil, nil)
+
+			// When
+			status, err := svc.HandlePermission(context.Background(), ar)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, api.ScheduledStatus, status)
+			assert.Empty(t, updatedAR.Status.RequestState, "status must not be updated")
+		})
+		t.Run("will grant access once the start time is reached", func(t *testing.T) {
+			// Given
+			updatedAR := &api.AccessRequest{}
+			updatedProject := &argocd.AppProject{}
+			clientMock := mocks.NewMockK8sClient(t)
+			setup(clientMock, newApp("some-project"), rt, newProject(nil), updatedProject, updatedAR)
+			startsAt := time.Now().Add(-time.Minute).Truncate(time.Second)
+			ar := newAccessRequest(startsAt)
+			ar.Status.RequestState = api.ScheduledStatus
+			ar.Status.TargetProject = "some-project"
+			svc := controller.NewService(clientMock, nil, nil)
+
+			// When
+			status, err := svc.HandlePermission(context.Background(), ar)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, api.GrantedStatus, status)
+			assert.Equal(t, api.GrantedStatus, updatedAR.Status.RequestState)
+			assert.Equal(t, "Scheduled access started", updatedAR.GetLastStatusDetails(api.GrantedStatus))
+			assert.Equal(t, startsAt.Add(time.Hour), updatedAR.Status.ExpiresAt.Time, "expiration must be based on the start time")
+			assert.Contains(t, updatedProject.Spec.Roles[0].Groups, "some-user")
+		})
+	})
+
+	t.Run("will handle break-glass access", func(t *testing.T) {
+		rt := newRoleTemplate(api.RoleTemplateSpec{
+			Name:     "some-role-template",
+			Policies: []string{"policy1"},
+		})
+		setupBinding := func(clientMock *mocks.MockK8sClient, policy *api.BreakGlassPolicy) {
+			clientMock.EXPECT().
+				Get(mock.Anything, mock.Anything, mock.AnythingOfType("*v1alpha1.AccessBinding")).
+				RunAndReturn(func(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					abLocal := obj.(*api.AccessBinding)
+					abLocal.Spec = api.AccessBindingSpec{
+						RoleTemplateRef: api.RoleTemplateReference{Name: "someRole"},
+						BreakGlass:      policy.DeepCopy(),
+					}
+					return nil
+				})
+		}
+		newAccessRequest := func(duration time.Duration) *api.AccessRequest {
+			ar := utils.NewAccessRequest("test", "default", "someApp", "someAppNs", "someRole", "someRoleNs", "user-id", "some-user")
+			ar.Spec.Duration = metav1.Duration{Duration: duration}
+			ar.Spec.BreakGlass = true
+			ar.Spec.Justification = "production is down"
+			ar.Spec.AccessBindingRef = &api.AccessBindingReference{
+				Name:      "some-binding",
+				Namespace: "default",
+			}
+			return ar
+		}
+		waitEvent := func(t *testing.T, recorder *record.FakeRecorder, reason string) string {
+			t.Helper()
+			for {
+				select {
+				case event := <-recorder.Events:
+					if strings.Contains(event, " "+reason+" ") {
+						return event
+					}
+				case <-time.After(5 * time.Second):
+					t.Fatalf("timeout waiting for %s event", reason)
+					return ""
+				}
+			}
+		}
+		t.Run("will grant access without approvals or plugin decision", func(t *testing.T) {
+			// Given
+			updatedAR := &api.AccessRequest{}
+			updatedProject := &argocd.AppProject{}
+			clientMock := mocks.NewMockK8sClient(t)
+			setup(clientMock, newApp("some-project"), rt, newProject(nil), updatedProject, updatedAR)
+			setupBinding(clientMock, &api.BreakGlassPolicy{MaxDuration: metav1.Duration{Duration: time.Hour}})
+			ar := newAccessRequest(time.Hour)
+			ar.Spec.Approval = &api.ApprovalPolicy{Approvers: []string{"approvers"}, RequiredApprovals: 1}
+			recorder := record.NewFakeRecorder(10)
+			svc := controller.NewService(clientMock, nil, nil, controller.WithEventRecorder(recorder))
+
+			// When
+			status, err := svc.HandlePermission(context.Background(), ar)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, api.GrantedStatus, status)
+			assert.Equal(t, api.GrantedStatus, updatedAR.Status.RequestState)
+			assert.Equal(t, "Break-glass access granted without approval", updatedAR.GetLastStatusDetails(api.GrantedStatus))
+			assert.Contains(t, updatedProject.Spec.Roles[0].Groups, "some-user")
+			event := waitEvent(t, recorder, controller.EventReasonBreakGlassGranted)
+			assert.Contains(t, event, "Warning BreakGlassGranted Break-glass access granted to some-user for application someAppNs/someApp")
+			assert.Contains(t, event, "production is down")
+		})
+		t.Run("will invalidate the AccessRequest if the binding doesn't allow break-glass", func(t *testing.T) {
+			// Given
+			updatedAR := &api.AccessRequest{}
+			updatedProject := &argocd.AppProject{}
+			clientMock := mocks.NewMockK8sClient(t)
+			setup(clientMock, newApp("some-project"), rt, newProject(nil), updatedProject, updatedAR)
+			setupBinding(clientMock, nil)
+			ar := newAccessRequest(time.Hour)
+			svc := controller.NewService(clientMock, nil, nil)
+
+			// When
+			status, err := svc.HandlePermission(context.Background(), ar)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, api.InvalidStatus, status)
+			assert.Equal(t, "Invalid break-glass request: break-glass access is not allowed for role someRole", updatedAR.GetLastStatusDetails(api.InvalidStatus))
+			assert.Empty(t, updatedProject.Spec.Roles, "access must not be granted")
+		})
+		t.Run("will invalidate the AccessRequest if the duration exceeds the break-glass maximum", func(t *testing.T) {
+			// Given
+			updatedAR := &api.AccessRequest{}
+			clientMock := mocks.NewMockK8sClient(t)
+			setup(clientMock, newApp("some-project"), rt, newProject(nil), &argocd.AppProject{}, updatedAR)
+			setupBinding(clientMock, &api.BreakGlassPolicy{MaxDuration: metav1.Duration{Duration: 30 * time.Minute}})
+			ar := newAccessRequest(time.Hour)
+			svc := controller.NewService(clientMock, nil, nil)
+
+			// When
+			status, err := svc.HandlePermission(context.Background(), ar)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, api.InvalidStatus, status)
+			assert.Contains(t, updatedAR.GetLastStatusDetails(api.InvalidStatus), "longer than the maximum allowed for break-glass access")
+		})
+		t.Run("will review the granted access with the plugin asynchronously", func(t *testing.T) {
+			// Given
+			updatedAR := &api.AccessRequest{}
+			clientMock := mocks.NewMockK8sClient(t)
+			setup(clientMock, newApp("some-project"), rt, newProject(nil), &argocd.AppProject{}, updatedAR)
+			setupBinding(clientMock, &api.BreakGlassPolicy{MaxDuration: metav1.Duration{Duration: time.Hour}})
+			pluginMock := mocks.NewMockAccessRequesterV2(t)
+			pluginMock.EXPECT().
+				GrantAccess(mock.Anything, mock.AnythingOfType("*v1alpha1.AccessRequest"), mock.AnythingOfType("*v1alpha1.Application")).
+				Return(&plugin.GrantResponse{Status: plugin.GrantStatusDenied, Message: "no incident found"}, nil)
+			ar := newAccessRequest(time.Hour)
+			recorder := record.NewFakeRecorder(10)
+			svc := controller.NewService(clientMock, nil, pluginMock, controller.WithEventRecorder(recorder))
+
+			// When
+			status, err := svc.HandlePermission(context.Background(), ar)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, api.GrantedStatus, status, "plugin decision must not change the access")
+			assert.NotEmpty(t, waitEvent(t, recorder, controller.EventReasonBreakGlassGranted))
+			assert.Equal(t, "Warning BreakGlassReviewed Break-glass access review result: denied: no incident found", waitEvent(t, recorder, controller.EventReasonBreakGlassReviewed))
+		})
+		newGrantedAccessRequest := func(expiresAt time.Time) *api.AccessRequest {
+			ar := newAccessRequest(time.Hour)
+			ar.Status.TargetProject = "some-project"
+			ar.Status.RoleName = "ephemeral-some-role-template-someAppNs-someApp"
+			ar.Status.RequestState = api.GrantedStatus
+			ar.Status.ExpiresAt = &metav1.Time{Time: expiresAt}
+			return ar
+		}
+		t.Run("will deny the extension of granted access", func(t *testing.T) {
+			// Given
+			updatedAR := &api.AccessRequest{}
+			clientMock := mocks.NewMockK8sClient(t)
+			setup(clientMock, newApp("some-project"), rt, newProject(nil), &argocd.AppProject{}, updatedAR)
+			setupBinding(clientMock, &api.BreakGlassPolicy{MaxDuration: metav1.Duration{Duration: time.Hour}})
+			expiresAt := time.Now().Add(30 * time.Minute).Truncate(time.Second)
+			ar := newGrantedAccessRequest(expiresAt)
+			ar.Spec.Extensions = []api.AccessExtension{{Duration: metav1.Duration{Duration: 30 * time.Minute}}}
+			svc := controller.NewService(clientMock, nil, nil)
+
+			// When
+			status, err := svc.HandlePermission(context.Background(), ar)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, api.GrantedStatus, status)
+			require.Len(t, updatedAR.Status.Extensions, 1)
+			assert.Equal(t, api.DeniedStatus, updatedAR.Status.Extensions[0].RequestState)
+			assert.Nil(t, updatedAR.GetPendingExtension())
+			assert.Equal(t, "Access extension denied: break-glass access cannot be extended", updatedAR.GetLastStatusDetails(api.GrantedStatus))
+			assert.True(t, expiresAt.Equal(updatedAR.Status.ExpiresAt.Time), "expiration must not change")
+		})
+		t.Run("will send the expiry warning of granted access", func(t *testing.T) {
+			// Given
+			updatedAR := &api.AccessRequest{}
+			clientMock := mocks.NewMockK8sClient(t)
+			setup(clientMock, newApp("some-project"), rt, newProject(nil), &argocd.AppProject{}, updatedAR)
+			setupBinding(clientMock, &api.BreakGlassPolicy{MaxDuration: metav1.Duration{Duration: time.Hour}})
+			ar := newGrantedAccessRequest(time.Now().Add(10 * time.Minute))
+			ar.Status.ExpiryWarning = &metav1.Duration{Duration: 15 * time.Minute}
+			notifier, sink := newNotifier(t)
+			svc := controller.NewService(clientMock, nil, nil, controller.WithNotifier(notifier))
+
+			// When
+			status, err := svc.HandlePermission(context.Background(), ar)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, api.GrantedStatus, status)
+			n := sink.wait(t)
+			assert.Equal(t, api.NotificationExpiring, n.Event)
+			require.NotNil(t, updatedAR.Status.NotifiedExpiresAt)
+		})
+	})
+
+	t.Run("will handle access extension", func(t *testing.T) {
+		rt := newRoleTemplate(api.RoleTemplateSpec{
+			Name:        "some-role-template",
+			Description: "some role description",
+			Policies:    []string{"policy1"},
+		})
+		prj := newProject(
+			[]argocd.ProjectRole{
+				{
+					Name:        "ephemeral-some-role-template-someAppNs-someApp",
+					Description: "some role description",
+					Policies:    []string{"policy1"},
+					JWTTokens:   []argocd.JWTToken{},
+					Groups:      []string{"some-user"},
+				},
+			},
+		)
+		setupBinding := func(clientMock *mocks.MockK8sClient, policy *api.ExtensionPolicy) {
+			clientMock.EXPECT().
+				Get(mock.Anything, mock.Anything, mock.AnythingOfType("*v1alpha1.AccessBinding")).
+				RunAndReturn(func(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					abLocal := obj.(*api.AccessBinding)
+					abLocal.Spec.RoleTemplateRef = api.RoleTemplateReference{Name: "someRole"}
+					abLocal.Spec.Extension = policy
+					return nil
+				})
+		}
+		newAccessRequest := func(expiresAt time.Time, extension time.Duration) *api.AccessRequest {
+			ar := utils.NewAccessRequest("test", "default", "someApp", "someAppNs", "someRole", "someRoleNs", "user-id", "some-user")
+			ar.Spec.Duration = metav1.Duration{Duration: time.Hour}
+			ar.Spec.AccessBindingRef = &api.AccessBindingReference{
+				Name:      "some-binding",
+				Namespace: "default",
+			}
+			ar.Spec.Extensions = []api.AccessExtension{{Duration: metav1.Duration{Duration: extension}}}
+			ar.Status.TargetProject = "someProject"
+			ar.Status.RoleName = "ephemeral-some-role-template-someAppNs-someApp"
+			ar.Status.RequestState = api.GrantedStatus
+			ar.Status.ExpiresAt = &metav1.Time{Time: expiresAt}
+			return ar
+		}
+		policy := &api.ExtensionPolicy{
+			MaxDuration:      metav1.Duration{Duration: time.Hour},
+			MaxTotalDuration: metav1.Duration{Duration: 3 * time.Hour},
+		}
+		t.Run("will move the expiration forward if plugin grants the extension", func(t *testing.T) {
+			// Given
+			updatedAR := &api.AccessRequest{}
+			clientMock := mocks.NewMockK8sClient(t)
+			setup(clientMock, newApp("someProject"), rt, prj, &argocd.AppProject{}, updatedAR)
+			setupBinding(clientMock, policy)
+			pluginMock := mocks.NewMockAccessRequesterV2(t)
+			pluginMock.EXPECT().
+				GrantAccess(mock.Anything, mock.AnythingOfType("*v1alpha1.AccessRequest"), mock.AnythingOfType("*v1alpha1.Application")).
+				Return(&plugin.GrantResponse{Status: plugin.GrantStatusGranted, Message: "approved"}, nil)
+			expiresAt := time.Now().Add(10 * time.Minute).Truncate(time.Second)
+			ar := newAccessRequest(expiresAt, 30*time.Minute)
+			svc := controller.NewService(clientMock, nil, pluginMock)
+
+			// When
+			status, err := svc.HandlePermission(context.Background(), ar)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, api.GrantedStatus, status)
+			assert.Equal(t, expiresAt.Add(30*time.Minute), updatedAR.Status.ExpiresAt.Time)
+			require.Len(t, updatedAR.Status.Extensions, 1)
+			assert.Equal(t, api.GrantedStatus, updatedAR.Status.Extensions[0].RequestState)
+			assert.Nil(t, updatedAR.GetPendingExtension())
+			details := updatedAR.GetLastStatusDetails(api.GrantedStatus)
+			assert.Contains(t, details, "Access extended by 30m0s until")
+			assert.Contains(t, details, "approved")
+		})
+		t.Run("will keep the extension pending if plugin has not decided", func(t *testing.T) {
+			// Given
+			updatedAR := &api.AccessRequest{}
+			clientMock := mocks.NewMockK8sClient(t)
+			setup(clientMock, newApp("someProject"), rt, prj, &argocd.AppProject{}, updatedAR)
+			setupBinding(clientMock, policy)
+			pluginMock := mocks.NewMockAccessRequesterV2(t)
+			pluginMock.EXPECT().
+				GrantAccess(mock.Anything, mock.AnythingOfType("*v1alpha1.AccessRequest"), mock.AnythingOfType("*v1alpha1.Application")).
+				Return(&plugin.GrantResponse{Status: plugin.GrantStatusPending, Message: "waiting approval"}, nil)
+			expiresAt := time.Now().Add(10 * time.Minute).Truncate(time.Second)
+			ar := newAccessRequest(expiresAt, 30*time.Minute)
+			svc := controller.NewService(clientMock, nil, pluginMock)
+
+			// When
+			status, err := svc.HandlePermission(context.Background(), ar)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, api.GrantedStatus, status)
+			assert.Equal(t, expiresAt, updatedAR.Status.ExpiresAt.Time)
+			assert.Empty(t, updatedAR.Status.Extensions)
+			assert.NotNil(t, updatedAR.GetPendingExtension())
+			assert.Equal(t, "Access extension pending: waiting approval", updatedAR.GetLastStatusDetails(api.GrantedStatus))
+		})
+		t.Run("will deny the extension if it exceeds the binding limits", func(t *testing.T) {
+			// Given
+			updatedAR := &api.AccessRequest{}
+			clientMock := mocks.NewMockK8sClient(t)
+			setup(clientMock, newApp("someProject"), rt, prj, &argocd.AppProject{}, updatedAR)
+			setupBinding(clientMock, policy)
+			expiresAt := time.Now().Add(10 * time.Minute).Truncate(time.Second)
+			ar := newAccessRequest(expiresAt, 2*time.Hour)
+			svc := controller.NewService(clientMock, nil, nil)
+
+			// When
+			status, err := svc.HandlePermission(context.Background(), ar)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, api.GrantedStatus, status)
+			assert.Equal(t, expiresAt, updatedAR.Status.ExpiresAt.Time)
+			require.Len(t, updatedAR.Status.Extensions, 1)
+			assert.Equal(t, api.DeniedStatus, updatedAR.Status.Extensions[0].RequestState)
+			assert.Contains(t, updatedAR.GetLastStatusDetails(api.GrantedStatus), "Access extension denied: extension duration 2h0m0s is longer than the maximum allowed")
+		})
+	})
+
+	t.Run("will record events", func(t *testing.T) {
+		rt := newRoleTemplate(api.RoleTemplateSpec{
+			Name:     "some-role-template",
+			Policies: []string{"policy1"},
+		})
+		drainEvents := func(recorder *record.FakeRecorder) []string {
+			events := []string{}
+			for {
+				select {
+				case event := <-recorder.Events:
+					events = append(events, event)
+				default:
+					return events
+				}
+			}
+		}
+		t.Run("will record an event for every transition", func(t *testing.T) {
+			// Given
+			updatedAR := &api.AccessRequest{}
+			clientMock := mocks.NewMockK8sClient(t)
+			setup(clientMock, newApp("some-project"), rt, newProject(nil), &argocd.AppProject{}, updatedAR)
+			ar := utils.NewAccessRequest("test", "default", "someApp", "someAppNs", "someRole", "someRoleNs", "user-id", "some-user")
+			ar.Spec.Duration = metav1.Duration{Duration: time.Hour}
+			recorder := record.NewFakeRecorder(10)
+			svc := controller.NewService(clientMock, nil, nil, controller.WithEventRecorder(recorder))
+
+			// When
+			status, err := svc.HandlePermission(context.Background(), ar)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, api.GrantedStatus, status)
+			events := drainEvents(recorder)
+			assert.Contains(t, events, "Normal AccessInitiated Access initiated for some-user")
+			assert.Contains(t, events, "Normal AccessGranted Access granted for some-user")
+			assert.Contains(t, events, "Normal AccessGranted AccessRequest default/test: granted role someRole in application someAppNs/someApp for some-user",
+				"summary event must be recorded in the AppProject")
+		})
+		t.Run("will include the plugin decision in the event and conditions", func(t *testing.T) {
+			// Given
+			updatedAR := &api.AccessRequest{}
+			clientMock := mocks.NewMockK8sClient(t)
+			setup(clientMock, newApp("some-project"), rt, newProject(nil), &argocd.AppProject{}, updatedAR)
+			pluginMock := mocks.NewMockAccessRequesterV2(t)
+			pluginMock.EXPECT().
+				GrantAccess(mock.Anything, mock.AnythingOfType("*v1alpha1.AccessRequest"), mock.AnythingOfType("*v1alpha1.Application")).
+				Return(&plugin.GrantResponse{Status: plugin.GrantStatusDenied, Message: "change freeze in place"}, nil)
+			ar := utils.NewAccessRequest("test", "default", "someApp", "someAppNs", "someRole", "someRoleNs", "user-id", "some-user")
+			ar.Spec.Duration = metav1.Duration{Duration: time.Hour}
+			recorder := record.NewFakeRecorder(10)
+			svc := controller.NewService(clientMock, nil, pluginMock, controller.WithEventRecorder(recorder))
+
+			// When
+			status, err := svc.HandlePermission(context.Background(), ar)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, api.DeniedStatus, status)
+			assert.Contains(t, drainEvents(recorder), "Warning AccessDenied Access denied for some-user: change freeze in place")
+			pluginApproved := updatedAR.GetCondition(api.ConditionPluginApproved)
+			require.NotNil(t, pluginApproved)
+			assert.Equal(t, metav1.ConditionFalse, pluginApproved.Status)
+			assert.Equal(t, "Denied", pluginApproved.Reason)
+			assert.Equal(t, "change freeze in place", pluginApproved.Message)
+		})
+		t.Run("will record an event if the application can't be retrieved", func(t *testing.T) {
+			// Given
+			clientMock := mocks.NewMockK8sClient(t)
+			clientMock.EXPECT().
+				Get(mock.Anything, mock.Anything, mock.AnythingOfType("*v1alpha1.Application")).
+				Return(errors.New("connection refused"))
+			ar := utils.NewAccessRequest("test", "default", "someApp", "someAppNs", "someRole", "someRoleNs", "user-id", "some-user")
+			recorder := record.NewFakeRecorder(10)
+			svc := controller.NewService(clientMock, nil, nil, controller.WithEventRecorder(recorder))
+
+			// When
+			_, err := svc.HandlePermission(context.Background(), ar)
+
+			// Then
+			assert.Error(t, err)
+			assert.Equal(t, []string{"Warning ApplicationError Error retrieving Application someAppNs/someApp: connection refused"}, drainEvents(recorder))
+		})
+	})
+
+	t.Run("will send notifications", func(t *testing.T) {
+		rt := newRoleTemplate(api.RoleTemplateSpec{
+			Name:        "some-role-template",
+			Description: "some role description",
+			Policies:    []string{"policy1"},
+		})
+		t.Run("will notify the status transitions", func(t *testing.T) {
+			// Given
+			updatedAR := &api.AccessRequest{}
+			clientMock := mocks.NewMockK8sClient(t)
+			setup(clientMock, newApp("some-project"), rt, newProject(nil), &argocd.AppProject{}, updatedAR)
+			ar := utils.NewAccessRequest("test", "default", "someApp", "someAppNs", "someRole", "someRoleNs", "user-id", "some-user")
+			ar.Spec.Duration = metav1.Duration{Duration: time.Hour}
+			notifier, sink := newNotifier(t)
+			svc := controller.NewService(clientMock, nil, nil, controller.WithNotifier(notifier))
+
+			// When
+			status, err := svc.HandlePermission(context.Background(), ar)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, api.GrantedStatus, status)
+			n := sink.wait(t)
+			assert.Equal(t, api.NotificationGranted, n.Event)
+			assert.Equal(t, api.GrantedStatus, n.AccessRequest.Status.RequestState)
+			require.NotNil(t, updatedAR.Status.ExpiryWarning)
+			assert.Equal(t, 15*time.Minute, updatedAR.Status.ExpiryWarning.Duration)
+		})
+		t.Run("will notify once when the access is expiring", func(t *testing.T) {
+			// Given
+			updatedAR := &api.AccessRequest{}
+			clientMock := mocks.NewMockK8sClient(t)
+			prj := newProject([]argocd.ProjectRole{
+				{
+					Name:        "ephemeral-some-role-template-someAppNs-someApp",
+					Description: "some role description",
+					Policies:    []string{"policy1"},
+					JWTTokens:   []argocd.JWTToken{},
+					Groups:      []string{"some-user"},
+				},
+			})
+			setup(clientMock, newApp("someProject"), rt, prj, &argocd.AppProject{}, updatedAR)
+			ar := utils.NewAccessRequest("test", "default", "someApp", "someAppNs", "someRole", "someRoleNs", "user-id", "some-user")
+			ar.Spec.Duration = metav1.Duration{Duration: time.Hour}
+			ar.Status.TargetProject = "someProject"
+			ar.Status.RoleName = "ephemeral-some-role-template-someAppNs-someApp"
+			ar.Status.RequestState = api.GrantedStatus
+			ar.Status.ExpiresAt = &metav1.Time{Time: time.Now().Add(10 * time.Minute)}
+			ar.Status.ExpiryWarning = &metav1.Duration{Duration: 15 * time.Minute}
+			notifier, sink := newNotifier(t)
+			svc := controller.NewService(clientMock, nil, nil, controller.WithNotifier(notifier))
+
+			// When
+			status, err := svc.HandlePermission(context.Background(), ar)
+			require.NoError(t, err)
+			_, err = svc.HandlePermission(context.Background(), ar)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, api.GrantedStatus, status)
+			n := sink.wait(t)
+			assert.Equal(t, api.NotificationExpiring, n.Event)
+			assert.Contains(t, n.Message, "Access expires in 10m0s at ")
+			require.NotNil(t, updatedAR.Status.NotifiedExpiresAt)
+			assert.True(t, ar.Status.ExpiresAt.Equal(updatedAR.Status.NotifiedExpiresAt))
+			select {
+			case n := <-sink.notifications:
+				t.Fatalf("unexpected notification: %s", n.Event)
+			case <-time.After(100 * time.Millisecond):
+			}
+		})
+	})
+
+	t.Run("will audit decisions", func(t *testing.T) {
+		// Given
+		rt := newRoleTemplate(api.RoleTemplateSpec{
+			Name:     "some-role-template",
+			Policies: []string{"policy1"},
+		})
+		updatedAR := &api.AccessRequest{}
+		clientMock := mocks.NewMockK8sClient(t)
+		setup(clientMock, newApp("some-project"), rt, newProject(nil), &argocd.AppProject{}, updatedAR)
+		pluginMock := mocks.NewMockAccessRequesterV2(t)
+		pluginMock.EXPECT().
+			GrantAccess(mock.Anything, mock.AnythingOfType("*v1alpha1.AccessRequest"), mock.AnythingOfType("*v1alpha1.Application")).
+			Return(&plugin.GrantResponse{Status: plugin.GrantStatusDenied, Message: "change freeze in place"}, nil)
+		ar := utils.NewAccessRequest("test", "default", "someApp", "someAppNs", "someRole", "someRoleNs", "user-id", "some-user")
+		ar.Spec.Duration = metav1.Duration{Duration: time.Hour}
+		var buf strings.Builder
+		svc := controller.NewService(clientMock, nil, pluginMock, controller.WithAuditLogger(audit.NewLogger(&buf)))
+
+		// When
+		status, err := svc.HandlePermission(context.Background(), ar)
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, api.DeniedStatus, status)
+		result, err := audit.Verify(strings.NewReader(buf.String()))
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Records)
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 3)
+		assert.Contains(t, lines[0], `"action":"created"`)
+		assert.Contains(t, lines[1], `"action":"plugin-response","accessRequest":"default/test"`)
+		assert.Contains(t, lines[1], `"details":"Denied: change freeze in place"`)
+		assert.Contains(t, lines[2], `"action":"denied"`)
+	})
+
+	t.Run("will not grant access if the plugin returns an unknown status", func(t *testing.T) {
+		for _, pluginStatus := range []plugin.GrantStatus{"", "pending"} {
+			t.Run(fmt.Sprintf("status %q", pluginStatus), func(t *testing.T) {
+				// Given
+				rt := newRoleTemplate(api.RoleTemplateSpec{
+					Name:     "some-role-template",
+					Policies: []string{"policy1"},
+				})
+				updatedAR := &api.AccessRequest{}
+				updatedProj := &argocd.AppProject{}
+				clientMock := mocks.NewMockK8sClient(t)
+				setup(clientMock, newApp("some-project"), rt, newProject(nil), updatedProj, updatedAR)
+				pluginMock := mocks.NewMockAccessRequesterV2(t)
+				pluginMock.EXPECT().
+					GrantAccess(mock.Anything, mock.AnythingOfType("*v1alpha1.AccessRequest"), mock.AnythingOfType("*v1alpha1.Application")).
+					Return(&plugin.GrantResponse{Status: pluginStatus}, nil)
+				ar := utils.NewAccessRequest("test", "default", "someApp", "someAppNs", "someRole", "someRoleNs", "user-id", "some-user")
+				ar.Spec.Duration = metav1.Duration{Duration: time.Hour}
+				svc := controller.NewService(clientMock, nil, pluginMock)
+
+				// When
+				status, err := svc.HandlePermission(context.Background(), ar)
+
+				// Then
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "invalid status")
+				assert.Empty(t, status)
+				assert.Empty(t, updatedProj.Spec.Roles)
+				assert.NotEqual(t, api.GrantedStatus, updatedAR.Status.RequestState)
+			})
+		}
+	})
+
+	t.Run("will handle plugins", func(t *testing.T) {
+		t.Run("will update the history with the latest plugin message", func(t *testing.T) {
+			// Given
+			resourceWriterMock := mocks.NewMockSubResourceWriter(t)
+			resourceWriterMock.EXPECT().Update(mock.Anything, mock.AnythingOfType("*v1alpha1.AccessRequest")).Return(nil)
+			clientMock := mocks.NewMockK8sClient(t)
+			clientMock.EXPECT().Status().Return(resourceWriterMock)
+			clientMock.EXPECT().
+				Get(mock.Anything, mock.Anything, mock.AnythingOfType("*v1alpha1.Application")).
+				RunAndReturn(func(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					app := obj.(*argocd.Application)
+					app.Spec.Project = "some-project"
+					return nil
+				})
+			clientMock.EXPECT().
+				Get(mock.Anything, mock.Anything, mock.AnythingOfType("*v1alpha1.RoleTemplate")).
+				RunAndReturn(func(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					rt := obj.(*api.RoleTemplate)
+					rt.Spec.Name = "some-role"
+					rt.Spec.Description = "some-role-description"
+					rt.Spec.Policies = []string{"some-policy"}
+					return nil
+				})
+			pluginMock := mocks.NewMockAccessRequesterV2(t)
+			response1 := &plugin.GrantResponse{
+				Status:  plugin.GrantStatusPending,
+				Message: "message 1",
+			}
+			pluginMock.EXPECT().
+				GrantAccess(mock.Anything, mock.AnythingOfType("*v1alpha1.AccessRequest"), mock.AnythingOfType("*v1alpha1.Application")).
+				Return(response1, nil).Once()
+			pendingMessage := "expected message"
+
+			response2 := &plugin.GrantResponse{
+				Status:  plugin.GrantStatusPending,
+				Message: pendingMessage,
+			}
+			pluginMock.EXPECT().
+				GrantAccess(mock.Anything, mock.AnythingOfType("*v1alpha1.AccessRequest"), mock.AnythingOfType("*v1alpha1.Application")).
+				Return(response2, nil).Times(2)
+
+			approvedMessage := "approved"
+			response3 := &plugin.GrantResponse{
+				Status:  plugin.GrantStatusGranted,
+				Message: "approved",
+			}
+			pluginMock.EXPECT().
+				GrantAccess(mock.Anything, mock.AnythingOfType("*v1alpha1.AccessRequest"), mock.AnythingOfType("*v1alpha1.Application")).
+				Return(response3, nil)
+
+			clientMock.EXPECT().
+				Get(mock.Anything, mock.Anything, mock.AnythingOfType("*v1alpha1.AppProject")).
+				RunAndReturn(func(ctx context.Context, key types.NamespacedName, obj client.Object, opts ...client.GetOption) error {
+					obj = &argocd.AppProject{}
+					return nil
+				})
+			clientMock.EXPECT().
+				Patch(mock.Anything, mock.AnythingOfType("*v1alpha1.AppProject"), mock.Anything, mock.Anything).
+				Return(nil).
+				Once()
+
+			svc := controller.NewService(clientMock, nil, pluginMock)
+			ar := utils.NewAccessRequest("test", "default", "someApp", "someAppNs", "someRole", "someRoleNs", "user-id", "")
+			ar.Status.TargetProject = "some-project"
+
+			// When
+			svc.HandlePermission(context.Background(), ar)
+			svc.HandlePermission(context.Background(), ar)
+			svc.HandlePermission(context.Background(), ar)
+			status, err := svc.HandlePermission(context.Background(), ar)
+
+			// Then
+			assert.NoError(t, err)
+			assert.NotNil(t, status, "status is nil")
+			assert.Equal(t, api.GrantedStatus, status)
+			assert.Equal(t, pendingMessage, ar.GetLastStatusDetails(api.RequestedStatus))
+			assert.Equal(t, approvedMessage, ar.GetLastStatusDetails(api.GrantedStatus))
+			assert.Len(t, ar.Status.History, 4)
+		})
+		t.Run("will return timeout error if the plugin doesn't respond in time", func(t *testing.T) {
+			// Given
+			clientMock := mocks.NewMockK8sClient(t)
+			pluginMock := mocks.NewMockAccessRequesterV2(t)
+			pluginMock.EXPECT().
+				GrantAccess(mock.Anything, mock.AnythingOfType("*v1alpha1.AccessRequest"), mock.AnythingOfType("*v1alpha1.Application")).
+				RunAndReturn(func(ctx context.Context, ar *api.AccessRequest, app *argocd.Application) (*plugin.GrantResponse, error) {
+					<-ctx.Done()
+					return nil, fmt.Errorf("%w: %w", plugin.ErrTimeout, ctx.Err())
+				})
+			svc := controller.NewService(clientMock, nil, pluginMock, controller.WithPluginTimeout(50*time.Millisecond))
+			ar := utils.NewAccessRequest("test", "default", "someApp", "someAppNs", "someRole", "someRoleNs", "user-id", "")
+
+			// When
+			resp, err := svc.Allowed(context.Background(), ar, &argocd.Application{}, nil)
+
+			// Then
+			assert.Error(t, err)
+			assert.Nil(t, resp)
+			assert.ErrorIs(t, err, plugin.ErrTimeout)
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+		})
+	})
+	t.Run("will chain plugins", func(t *testing.T) {
+		grantMock := func(t *testing.T, status plugin.GrantStatus, message string) *mocks.MockAccessRequesterV2 {
+			pluginMock := mocks.NewMockAccessRequesterV2(t)
+			pluginMock.EXPECT().
+				GrantAccess(mock.Anything, mock.AnythingOfType("*v1alpha1.AccessRequest"), mock.AnythingOfType("*v1alpha1.Application")).
+				Return(&plugin.GrantResponse{Status: status, Message: message}, nil).Once()
+			return pluginMock
+		}
+		t.Run("will allow if all plugins grant the access", func(t *testing.T) {
+			// Given
+			clientMock := mocks.NewMockK8sClient(t)
+			svc := controller.NewService(clientMock, nil, grantMock(t, plugin.GrantStatusGranted, "no change freeze"),
+				controller.WithPlugin("servicenow", grantMock(t, plugin.GrantStatusGranted, "CHG0001 approved")))
+			ar := utils.NewAccessRequest("test", "default", "someApp", "someAppNs", "someRole", "someRoleNs", "user-id", "")
+
+			// When
+			resp, err := svc.Allowed(context.Background(), ar, &argocd.Application{}, nil)
+
+			// Then
+			require.NoError(t, err)
+			assert.True(t, resp.Allowed)
+			assert.Equal(t, plugin.GrantStatusGranted, resp.Status)
+			assert.Equal(t, "no change freeze; CHG0001 approved", resp.Message)
+		})
+		t.Run("will be pending if any plugin is pending", func(t *testing.T) {
+			// Given
+			clientMock := mocks.NewMockK8sClient(t)
+			svc := controller.NewService(clientMock, nil, grantMock(t, plugin.GrantStatusGranted, ""),
+				controller.WithPlugin("servicenow", grantMock(t, plugin.GrantStatusPending, "waiting for CHG0001")))
+			ar := utils.NewAccessRequest("test", "default", "someApp", "someAppNs", "someRole", "someRoleNs", "user-id", "")
+
+			// When
+			resp, err := svc.Allowed(context.Background(), ar, &argocd.Application{}, nil)
+
+			// Then
+			require.NoError(t, err)
+			assert.False(t, resp.Allowed)
+			assert.Equal(t, plugin.GrantStatusPending, resp.Status)
+			assert.Equal(t, "waiting for CHG0001", resp.Message)
+		})
+		t.Run("will not invoke the remaining plugins once denied", func(t *testing.T) {
+			// Given
+			clientMock := mocks.NewMockK8sClient(t)
+			svc := controller.NewService(clientMock, nil, grantMock(t, plugin.GrantStatusPending, "waiting for approval"),
+				controller.WithPlugin("freeze", grantMock(t, plugin.GrantStatusDenied, "change freeze in place")),
+				controller.WithPlugin("servicenow", mocks.NewMockAccessRequesterV2(t)))
+			ar := utils.NewAccessRequest("test", "default", "someApp", "someAppNs", "someRole", "someRoleNs", "user-id", "")
+
+			// When
+			resp, err := svc.Allowed(context.Background(), ar, &argocd.Application{}, nil)
+
+			// Then
+			require.NoError(t, err)
+			assert.False(t, resp.Allowed)
+			assert.Equal(t, plugin.GrantStatusDenied, resp.Status)
+			assert.Equal(t, "waiting for approval; change freeze in place", resp.Message)
+		})
+		t.Run("will only invoke the selected plugins", func(t *testing.T) {
+			// Given
+			clientMock := mocks.NewMockK8sClient(t)
+			svc := controller.NewService(clientMock, nil, mocks.NewMockAccessRequesterV2(t),
+				controller.WithPlugin("servicenow", grantMock(t, plugin.GrantStatusGranted, "CHG0001 approved")))
+			ar := utils.NewAccessRequest("test", "default", "someApp", "someAppNs", "someRole", "someRoleNs", "user-id", "")
+
+			// When
+			resp, err := svc.Allowed(context.Background(), ar, &argocd.Application{}, []string{"servicenow"})
+
+			// Then
+			require.NoError(t, err)
+			assert.True(t, resp.Allowed)
+			assert.Equal(t, "CHG0001 approved", resp.Message)
+		})
+		t.Run("will return error if the selected plugin is not configured", func(t *testing.T) {
+			// Given
+			clientMock := mocks.NewMockK8sClient(t)
+			svc := controller.NewService(clientMock, nil, mocks.NewMockAccessRequesterV2(t))
+			ar := utils.NewAccessRequest("test", "default", "someApp", "someAppNs", "someRole", "someRoleNs", "user-id", "")
+
+			// When
+			resp, err := svc.Allowed(context.Background(), ar, &argocd.Application{}, []string{"servicenow"})
+
+			// Then
+			assert.Error(t, err)
+			assert.Nil(t, resp)
+			assert.Contains(t, err.Error(), `plugin "servicenow" is not configured`)
+		})
+		t.Run("will revoke the access in the plugins selected by the RoleTemplate", func(t *testing.T) {
+			// Given
+			updatedAR := &api.AccessRequest{}
+			updatedProject := &argocd.AppProject{}
+			rt := newRoleTemplate(api.RoleTemplateSpec{
+				Name:     "some-role-template",
+				Policies: []string{"policy1"},
+				Plugins:  []string{"freeze", "servicenow"},
+			})
+			prj := newProject([]argocd.ProjectRole{
+				{
+					Name:   "ephemeral-some-role-template-someAppNs-someApp",
+					Groups: []string{"user-to-be-removed"},
+				},
+			})
+			clientMock := mocks.NewMockK8sClient(t)
+			setup(clientMock, newApp("someProject"), rt, prj, updatedProject, updatedAR)
+			revokeMock := func(message string) *mocks.MockAccessRequesterV2 {
+				pluginMock := mocks.NewMockAccessRequesterV2(t)
+				pluginMock.EXPECT().
+					RevokeAccess(mock.Anything, mock.AnythingOfType("*v1alpha1.AccessRequest"), mock.AnythingOfType("*v1alpha1.Application")).
+					Return(&plugin.RevokeResponse{Status: plugin.RevokeStatusRevoked, Message: message}, nil).Once()
+				return pluginMock
+			}
+			svc := controller.NewService(clientMock, nil, mocks.NewMockAccessRequesterV2(t),
+				controller.WithPlugin("freeze", revokeMock("freeze exception closed")),
+				controller.WithPlugin("servicenow", revokeMock("CHG0001 closed")))
+			ar := utils.NewAccessRequest("test", "default", "someApp", "someAppNs", "someRole", "someRoleNs", "user-id", "user-to-be-removed")
+			ar.Status.TargetProject = "someProject"
+			ar.Status.RequestState = api.GrantedStatus
+			ar.Spec.Revoke = true
+
+			// When
+			status, err := svc.HandlePermission(context.Background(), ar)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, api.RevokedStatus, status)
+			assert.Equal(t, "freeze exception closed; CHG0001 closed", updatedAR.GetLastStatusDetails(api.RevokedStatus))
+		})
+	})
+}
+
+func newAR(history ...api.AccessRequestHistory) *api.AccessRequest {
+	return &api.AccessRequest{
+		Status: api.AccessRequestStatus{History: history},
+	}
+}
+
+func TestGetLastHistory(t *testing.T) {
+	t1 := metav1.NewTime(time.Now().Add(-2 * time.Second))
+	t2 := metav1.NewTime(time.Now().Add(-1 * time.Second))
+
+	tests := []struct {
+		name     string
+		ar       *api.AccessRequest
+		status   api.Status
+		wantNil  bool
+		wantTime metav1.Time
+	}{
+		{
+			name:    "returns nil when history is empty",
+			ar:      newAR(),
+			status:  api.GrantedStatus,
+			wantNil: true,
 		},
 		{
 			name:    "returns nil when status not in history",
@@ -810,4 +2015,92 @@ func TestUpdateStatusHistory(t *testing.T) {
 		assert.Len(t, ar.Status.History, 3)
 		assert.Equal(t, api.ExpiredStatus, ar.Status.History[2].RequestState)
 	})
+
+	t.Run("sets the expiration based on the start time when granting scheduled access", func(t *testing.T) {
+		ar := newAR(
+			api.AccessRequestHistory{RequestState: api.InitiatedStatus},
+			api.AccessRequestHistory{RequestState: api.ScheduledStatus},
+		)
+		startsAt := time.Now().Add(-10 * time.Minute).Truncate(time.Second)
+		ar.Spec.StartsAt = &metav1.Time{Time: startsAt}
+		ar.Spec.Duration = metav1.Duration{Duration: time.Hour}
+
+		ar.UpdateStatusHistory(api.GrantedStatus, "")
+
+		assert.NotNil(t, ar.Status.ExpiresAt)
+		assert.Equal(t, startsAt.Add(time.Hour), ar.Status.ExpiresAt.Time)
+	})
+
+	t.Run("sets the standard conditions when granting access", func(t *testing.T) {
+		ar := newAR(api.AccessRequestHistory{RequestState: api.InitiatedStatus})
+		ar.Spec.Duration = metav1.Duration{Duration: time.Hour}
+
+		ar.UpdateStatusHistory(api.GrantedStatus, "approved")
+
+		for _, conditionType := range []string{api.ConditionReady, api.ConditionGranted, api.ConditionProjectSynced} {
+			condition := ar.GetCondition(conditionType)
+			require.NotNil(t, condition, conditionType)
+			assert.Equal(t, metav1.ConditionTrue, condition.Status, conditionType)
+			assert.Equal(t, "Granted", condition.Reason, conditionType)
+		}
+		assert.Equal(t, "approved", ar.GetCondition(api.ConditionGranted).Message)
+		expired := ar.GetCondition(api.ConditionExpired)
+		require.NotNil(t, expired)
+		assert.Equal(t, metav1.ConditionFalse, expired.Status)
+		assert.Equal(t, "Access expires at "+ar.Status.ExpiresAt.Format(time.RFC3339), expired.Message)
+	})
+
+	t.Run("sets the standard conditions when access expires", func(t *testing.T) {
+		ar := newAR(api.AccessRequestHistory{RequestState: api.InitiatedStatus})
+		ar.Status.TargetProject = "some-project"
+		ar.UpdateStatusHistory(api.GrantedStatus, "")
+
+		ar.UpdateStatusHistory(api.ExpiredStatus, "")
+
+		assert.Equal(t, metav1.ConditionTrue, ar.GetCondition(api.ConditionExpired).Status)
+		assert.Equal(t, metav1.ConditionFalse, ar.GetCondition(api.ConditionGranted).Status)
+		assert.Equal(t, "Expired", ar.GetCondition(api.ConditionGranted).Reason)
+		assert.Equal(t, metav1.ConditionTrue, ar.GetCondition(api.ConditionReady).Status)
+		assert.Equal(t, "Subject removed from the AppProject role", ar.GetCondition(api.ConditionProjectSynced).Message)
+	})
+
+	t.Run("sets ready to false when access is denied", func(t *testing.T) {
+		ar := newAR(api.AccessRequestHistory{RequestState: api.InitiatedStatus})
+
+		ar.UpdateStatusHistory(api.DeniedStatus, "not allowed")
+
+		ready := ar.GetCondition(api.ConditionReady)
+		require.NotNil(t, ready)
+		assert.Equal(t, metav1.ConditionFalse, ready.Status)
+		assert.Equal(t, "Denied", ready.Reason)
+		assert.Equal(t, "not allowed", ready.Message)
+		assert.Nil(t, ar.GetCondition(api.ConditionExpired))
+	})
+}
+
+// channelSink is a notification.Sink publishing all notifications in a
+// channel.
+type channelSink struct {
+	notifications chan *notification.Notification
+}
+
+func (s *channelSink) Accepts(api.NotificationEvent) bool {
+	return true
+}
+
+func (s *channelSink) Send(_ context.Context, n *notification.Notification) error {
+	s.notifications <- n
+	return nil
+}
+
+// wait returns the next notification sent to the sink.
+func (s *channelSink) wait(t *testing.T) *notification.Notification {
+	t.Helper()
+	select {
+	case n := <-s.notifications:
+		return n
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for notification")
+		return nil
+	}
 }
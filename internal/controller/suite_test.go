@@ -19,6 +19,7 @@ package controller
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 	"runtime"
 	"testing"
@@ -42,6 +43,7 @@ import (
 
 	argocd "github.com/argoproj-labs/argocd-ephemeral-access/api/argoproj/v1alpha1"
 	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
+	"github.com/argoproj-labs/argocd-ephemeral-access/internal/archive"
 	"github.com/argoproj-labs/argocd-ephemeral-access/pkg/plugin"
 	"github.com/argoproj-labs/argocd-ephemeral-access/test/mocks"
 	// +kubebuilder:scaffold:imports
@@ -58,12 +60,13 @@ var (
 	cancel               context.CancelFunc
 	ctx                  context.Context
 	controllerConfigMock *mocks.MockControllerConfigurer
-	accessRequesterMock  *mocks.MockAccessRequester
+	accessRequesterMock  *mocks.MockAccessRequesterV2
+	accessRequestArchive archive.Archive
 )
 
 func TestControllers(t *testing.T) {
 	controllerConfigMock = mocks.NewMockControllerConfigurer(t)
-	accessRequesterMock = mocks.NewMockAccessRequester(t)
+	accessRequesterMock = mocks.NewMockAccessRequesterV2(t)
 	RegisterFailHandler(Fail)
 
 	RunSpecs(t, "Controller Suite")
@@ -126,15 +129,22 @@ var _ = BeforeSuite(func() {
 	pluginResponse := &plugin.GrantResponse{
 		Status: plugin.GrantStatusGranted,
 	}
-	accessRequesterMock.EXPECT().GrantAccess(mock.Anything, mock.Anything).Return(pluginResponse, nil).Maybe()
-	accessRequesterMock.EXPECT().RevokeAccess(mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	accessRequesterMock.EXPECT().GrantAccess(mock.Anything, mock.Anything, mock.Anything).Return(pluginResponse, nil).Maybe()
+	accessRequesterMock.EXPECT().RevokeAccess(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+
+	archiveDir, err := os.MkdirTemp("", "ephemeral-access-archive")
+	Expect(err).NotTo(HaveOccurred())
+	DeferCleanup(os.RemoveAll, archiveDir)
+	accessRequestArchive, err = archive.NewFileArchive(archiveDir)
+	Expect(err).NotTo(HaveOccurred())
 
 	service := NewService(k8sManager.GetClient(), controllerConfigMock, accessRequesterMock)
 	arReconciler := &AccessRequestReconciler{
-		Client:  k8sManager.GetClient(),
-		Scheme:  k8sManager.GetScheme(),
-		Service: service,
-		Config:  controllerConfigMock,
+		Client:   k8sManager.GetClient(),
+		Scheme:   k8sManager.GetScheme(),
+		Service:  service,
+		Config:   controllerConfigMock,
+		Archiver: accessRequestArchive,
 	}
 	err = arReconciler.SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
@@ -0,0 +1,107 @@
+// Package httpretry provides the retry logic shared by the clients posting
+// JSON documents to remote services.
+package httpretry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultTimeout is the maximum duration of each attempt when the
+	// client doesn't define its own timeout.
+	DefaultTimeout = 30 * time.Second
+	// responses larger than this are considered invalid
+	maxResponseSize = 1024 * 1024
+	// maximum size of the response body included in errors
+	maxErrorBodySize = 512
+)
+
+// Do will call fn until it succeeds. Failed calls are retried up to
+// maxRetries times waiting the given backoff between attempts. The backoff
+// is doubled after each attempt. Errors created with Permanent aren't
+// retried. Returns the context error if ctx is done before fn succeeds.
+func Do(ctx context.Context, maxRetries int, backoff time.Duration, fn func(ctx context.Context) error) error {
+	for attempt := 0; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		var permanent *permanentError
+		if errors.As(err, &permanent) || attempt >= maxRetries {
+			return fmt.Errorf("failed after %d attempts: %w", attempt+1, err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// Post will post the given JSON payload to url with the given headers and
+// return the response body. Responses with status 429 or 5xx are returned as
+// errors that can be retried. Other error responses are permanent.
+func Post(ctx context.Context, client *http.Client, url string, header http.Header, payload []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, Permanent(fmt.Errorf("error creating request: %w", err))
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "argocd-ephemeral-access")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error posting request: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("error reading response: %w", err)
+	}
+	if resp.StatusCode < 300 {
+		return body, nil
+	}
+	if len(body) > maxErrorBodySize {
+		body = body[:maxErrorBodySize]
+	}
+	msg := fmt.Sprintf("remote service responded with status %d", resp.StatusCode)
+	if trimmed := strings.TrimSpace(string(body)); trimmed != "" {
+		msg = fmt.Sprintf("%s: %s", msg, trimmed)
+	}
+	err = errors.New(msg)
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		return nil, err
+	}
+	return nil, Permanent(err)
+}
+
+// Permanent wraps the given error so it isn't retried by Do.
+func Permanent(err error) error {
+	return &permanentError{err}
+}
+
+// permanentError defines an error that must not be retried.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
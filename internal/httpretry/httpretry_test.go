@@ -0,0 +1,149 @@
+package httpretry_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/argoproj-labs/argocd-ephemeral-access/internal/httpretry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDo(t *testing.T) {
+	t.Run("will retry until the call succeeds", func(t *testing.T) {
+		// Given
+		calls := 0
+		fn := func(ctx context.Context) error {
+			calls++
+			if calls < 3 {
+				return errors.New("some error")
+			}
+			return nil
+		}
+
+		// When
+		err := httpretry.Do(context.Background(), 3, time.Millisecond, fn)
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+	t.Run("will return the last error once retries are exhausted", func(t *testing.T) {
+		// Given
+		calls := 0
+		fn := func(ctx context.Context) error {
+			calls++
+			return errors.New("some error")
+		}
+
+		// When
+		err := httpretry.Do(context.Background(), 2, time.Millisecond, fn)
+
+		// Then
+		require.Error(t, err)
+		assert.Equal(t, "failed after 3 attempts: some error", err.Error())
+		assert.Equal(t, 3, calls)
+	})
+	t.Run("will not retry permanent errors", func(t *testing.T) {
+		// Given
+		calls := 0
+		someErr := errors.New("some error")
+		fn := func(ctx context.Context) error {
+			calls++
+			return httpretry.Permanent(someErr)
+		}
+
+		// When
+		err := httpretry.Do(context.Background(), 3, time.Millisecond, fn)
+
+		// Then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, someErr)
+		assert.Equal(t, 1, calls)
+	})
+	t.Run("will return the context error if the context is done", func(t *testing.T) {
+		// Given
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		fn := func(ctx context.Context) error {
+			return errors.New("some error")
+		}
+
+		// When
+		err := httpretry.Do(ctx, 3, time.Hour, fn)
+
+		// Then
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestPost(t *testing.T) {
+	newServer := func(t *testing.T, statusCode int, body string) (*httptest.Server, chan *http.Request) {
+		t.Helper()
+		requests := make(chan *http.Request, 10)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.Copy(io.Discard, r.Body)
+			requests <- r
+			w.WriteHeader(statusCode)
+			_, _ = w.Write([]byte(body))
+		}))
+		t.Cleanup(server.Close)
+		return server, requests
+	}
+	t.Run("will post the payload with the given headers", func(t *testing.T) {
+		// Given
+		server, requests := newServer(t, http.StatusOK, `{"status": "ok"}`)
+		header := http.Header{}
+		header.Set("X-Some-Header", "some-value")
+
+		// When
+		body, err := httpretry.Post(context.Background(), server.Client(), server.URL, header, []byte(`{}`))
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, `{"status": "ok"}`, string(body))
+		req := <-requests
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "some-value", req.Header.Get("X-Some-Header"))
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	})
+	t.Run("will return a retryable error on server errors", func(t *testing.T) {
+		// Given
+		server, _ := newServer(t, http.StatusServiceUnavailable, "some failure")
+		calls := 0
+
+		// When
+		err := httpretry.Do(context.Background(), 1, time.Millisecond, func(ctx context.Context) error {
+			calls++
+			_, err := httpretry.Post(ctx, server.Client(), server.URL, nil, []byte(`{}`))
+			return err
+		})
+
+		// Then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "status 503: some failure")
+		assert.Equal(t, 2, calls)
+	})
+	t.Run("will return a permanent error on client errors", func(t *testing.T) {
+		// Given
+		server, _ := newServer(t, http.StatusBadRequest, "")
+		calls := 0
+
+		// When
+		err := httpretry.Do(context.Background(), 1, time.Millisecond, func(ctx context.Context) error {
+			calls++
+			_, err := httpretry.Post(ctx, server.Client(), server.URL, nil, []byte(`{}`))
+			return err
+		})
+
+		// Then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "remote service responded with status 400")
+		assert.Equal(t, 1, calls)
+	})
+}
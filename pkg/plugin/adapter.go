@@ -0,0 +1,111 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	argocd "github.com/argoproj-labs/argocd-ephemeral-access/api/argoproj/v1alpha1"
+	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// v1Adapter implements AccessRequesterV2 on top of an AccessRequester.
+type v1Adapter struct {
+	impl AccessRequester
+}
+
+// NewV1Adapter returns an AccessRequesterV2 invoking the given
+// AccessRequester. As the AccessRequester can't be interrupted, calls return
+// as soon as the context is done and the pending call result is discarded.
+func NewV1Adapter(impl AccessRequester) AccessRequesterV2 {
+	return &v1Adapter{impl: impl}
+}
+
+// Init will invoke the AccessRequester Init function.
+func (a *v1Adapter) Init(ctx context.Context) error {
+	_, err := callWithContext(ctx, func() (any, error) {
+		return nil, a.impl.Init()
+	})
+	return err
+}
+
+// GrantAccess will invoke the AccessRequester GrantAccess function.
+func (a *v1Adapter) GrantAccess(ctx context.Context, ar *api.AccessRequest, app *argocd.Application) (*GrantResponse, error) {
+	return callWithContext(ctx, func() (*GrantResponse, error) {
+		return a.impl.GrantAccess(ar, app)
+	})
+}
+
+// RevokeAccess will invoke the AccessRequester RevokeAccess function.
+func (a *v1Adapter) RevokeAccess(ctx context.Context, ar *api.AccessRequest, app *argocd.Application) (*RevokeResponse, error) {
+	return callWithContext(ctx, func() (*RevokeResponse, error) {
+		return a.impl.RevokeAccess(ar, app)
+	})
+}
+
+// v2Adapter implements AccessRequester on top of an AccessRequesterV2. It is
+// used to serve AccessRequesterV2 plugins with net/rpc.
+type v2Adapter struct {
+	impl AccessRequesterV2
+}
+
+// Init will invoke the AccessRequesterV2 Init function.
+func (a *v2Adapter) Init() error {
+	return a.impl.Init(context.Background())
+}
+
+// GrantAccess will invoke the AccessRequesterV2 GrantAccess function.
+func (a *v2Adapter) GrantAccess(ar *api.AccessRequest, app *argocd.Application) (*GrantResponse, error) {
+	return a.impl.GrantAccess(context.Background(), ar, app)
+}
+
+// RevokeAccess will invoke the AccessRequesterV2 RevokeAccess function.
+func (a *v2Adapter) RevokeAccess(ar *api.AccessRequest, app *argocd.Application) (*RevokeResponse, error) {
+	return a.impl.RevokeAccess(context.Background(), ar, app)
+}
+
+// ContextWithTimeout returns a context that is done once the given timeout
+// elapses. A timeout of zero or less disables it and the returned context is
+// only done when the parent context is done or the returned cancel function is
+// invoked.
+func ContextWithTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// callWithContext invokes fn in a new goroutine and waits until it returns or
+// the given context is done.
+func callWithContext[T any](ctx context.Context, fn func() (T, error)) (T, error) {
+	type result struct {
+		value T
+		err   error
+	}
+	// buffered so the goroutine doesn't leak if the context is done first
+	ch := make(chan result, 1)
+	go func() {
+		value, err := fn()
+		ch <- result{value: value, err: err}
+	}()
+	select {
+	case r := <-ch:
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero T
+		return zero, contextError(ctx.Err())
+	}
+}
+
+// contextError wraps the given error with ErrTimeout if it was caused by a
+// deadline being exceeded, locally or in the plugin process.
+func contextError(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) || status.Code(err) == codes.DeadlineExceeded {
+		return fmt.Errorf("%w: %w", ErrTimeout, err)
+	}
+	return err
+}
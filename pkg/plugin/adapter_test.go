@@ -0,0 +1,117 @@
+package plugin_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	argocd "github.com/argoproj-labs/argocd-ephemeral-access/api/argoproj/v1alpha1"
+	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
+	"github.com/argoproj-labs/argocd-ephemeral-access/pkg/plugin"
+	"github.com/argoproj-labs/argocd-ephemeral-access/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextWithTimeout(t *testing.T) {
+	t.Run("will return a context with deadline if timeout is positive", func(t *testing.T) {
+		// When
+		ctx, cancel := plugin.ContextWithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		// Then
+		_, ok := ctx.Deadline()
+		assert.True(t, ok)
+		assert.NoError(t, ctx.Err())
+	})
+	t.Run("will return a context without deadline if timeout is zero", func(t *testing.T) {
+		// When
+		ctx, cancel := plugin.ContextWithTimeout(context.Background(), 0)
+
+		// Then
+		_, ok := ctx.Deadline()
+		assert.False(t, ok)
+		assert.NoError(t, ctx.Err())
+		cancel()
+		assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	})
+}
+
+func TestV1Adapter(t *testing.T) {
+	t.Run("will return the AccessRequester response", func(t *testing.T) {
+		// Given
+		pluginMock := mocks.NewMockAccessRequester(t)
+		pluginMock.EXPECT().GrantAccess(mock.Anything, mock.Anything).
+			Return(&plugin.GrantResponse{Status: plugin.GrantStatusGranted, Message: "some message"}, nil)
+		adapter := plugin.NewV1Adapter(pluginMock)
+
+		// When
+		resp, err := adapter.GrantAccess(context.Background(), nil, nil)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, plugin.GrantStatusGranted, resp.Status)
+		assert.Equal(t, "some message", resp.Message)
+	})
+	t.Run("will return the AccessRequester error", func(t *testing.T) {
+		// Given
+		pluginMock := mocks.NewMockAccessRequester(t)
+		pluginMock.EXPECT().Init().Return(errors.New("some error"))
+		adapter := plugin.NewV1Adapter(pluginMock)
+
+		// When
+		err := adapter.Init(context.Background())
+
+		// Then
+		require.Error(t, err)
+		assert.Equal(t, "some error", err.Error())
+	})
+	t.Run("will return ErrTimeout if the deadline is exceeded", func(t *testing.T) {
+		// Given
+		pluginMock := mocks.NewMockAccessRequester(t)
+		release := make(chan struct{})
+		defer close(release)
+		runFn := func(ar *api.AccessRequest, app *argocd.Application) (*plugin.RevokeResponse, error) {
+			<-release
+			return &plugin.RevokeResponse{Status: plugin.RevokeStatusRevoked}, nil
+		}
+		pluginMock.EXPECT().RevokeAccess(mock.Anything, mock.Anything).RunAndReturn(runFn)
+		adapter := plugin.NewV1Adapter(pluginMock)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		// When
+		resp, err := adapter.RevokeAccess(ctx, nil, nil)
+
+		// Then
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, plugin.ErrTimeout)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+	t.Run("will return when the context is cancelled", func(t *testing.T) {
+		// Given
+		pluginMock := mocks.NewMockAccessRequester(t)
+		release := make(chan struct{})
+		defer close(release)
+		runFn := func(ar *api.AccessRequest, app *argocd.Application) (*plugin.GrantResponse, error) {
+			<-release
+			return &plugin.GrantResponse{Status: plugin.GrantStatusGranted}, nil
+		}
+		pluginMock.EXPECT().GrantAccess(mock.Anything, mock.Anything).RunAndReturn(runFn)
+		adapter := plugin.NewV1Adapter(pluginMock)
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+
+		// When
+		resp, err := adapter.GrantAccess(ctx, nil, nil)
+
+		// Then
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.NotErrorIs(t, err, plugin.ErrTimeout)
+	})
+}
@@ -0,0 +1,206 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	argocd "github.com/argoproj-labs/argocd-ephemeral-access/api/argoproj/v1alpha1"
+	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
+	"github.com/argoproj-labs/argocd-ephemeral-access/pkg/plugin/proto"
+
+	goPlugin "github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AccessRequesterGRPCServer is the server side stub used by AccessRequester
+// plugins served with the gRPC protocol. The context received by the
+// implementation carries the deadline and cancellation of the controller call.
+type AccessRequesterGRPCServer struct {
+	proto.UnimplementedAccessRequesterServer
+	Impl AccessRequesterV2
+}
+
+// Init is the server side stub implementation of the Init function.
+func (s *AccessRequesterGRPCServer) Init(ctx context.Context, req *proto.InitRequest) (*proto.InitResponse, error) {
+	err := s.Impl.Init(ctx)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &proto.InitResponse{}, nil
+}
+
+// GrantAccess is the server side stub implementation of the GrantAccess function.
+func (s *AccessRequesterGRPCServer) GrantAccess(ctx context.Context, req *proto.GrantAccessRequest) (*proto.GrantAccessResponse, error) {
+	ar, app, err := decodeArgs(req.GetAccessRequest(), req.GetApplication())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	gr, err := s.Impl.GrantAccess(ctx, ar, app)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	resp := &proto.GrantAccessResponse{}
+	if gr != nil {
+		resp.Status = string(gr.Status)
+		resp.Message = gr.Message
+	}
+	return resp, nil
+}
+
+// RevokeAccess is the server side stub implementation of the RevokeAccess function.
+func (s *AccessRequesterGRPCServer) RevokeAccess(ctx context.Context, req *proto.RevokeAccessRequest) (*proto.RevokeAccessResponse, error) {
+	ar, app, err := decodeArgs(req.GetAccessRequest(), req.GetApplication())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	rr, err := s.Impl.RevokeAccess(ctx, ar, app)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	resp := &proto.RevokeAccessResponse{}
+	if rr != nil {
+		resp.Status = string(rr.Status)
+		resp.Message = rr.Message
+	}
+	return resp, nil
+}
+
+// AccessRequesterGRPCClient is the client side stub used by AccessRequester
+// plugins served with the gRPC protocol. It implements AccessRequesterV2 and
+// propagates the context deadline and cancellation to the plugin. Errors
+// returned by the plugin are gRPC status errors and can be inspected with
+// status.FromError.
+type AccessRequesterGRPCClient struct {
+	client proto.AccessRequesterClient
+}
+
+// Init is the client side stub implementation of the Init function.
+func (c *AccessRequesterGRPCClient) Init(ctx context.Context) error {
+	_, err := c.client.Init(ctx, &proto.InitRequest{})
+	if err != nil {
+		return contextError(err)
+	}
+	return nil
+}
+
+// GrantAccess is the client side stub implementation of the GrantAccess function.
+func (c *AccessRequesterGRPCClient) GrantAccess(ctx context.Context, ar *api.AccessRequest, app *argocd.Application) (*GrantResponse, error) {
+	arData, appData, err := encodeArgs(ar, app)
+	if err != nil {
+		return nil, fmt.Errorf("GrantAccess gRPC call error: %w", err)
+	}
+	resp, err := c.client.GrantAccess(ctx, &proto.GrantAccessRequest{
+		AccessRequest: arData,
+		Application:   appData,
+	})
+	if err != nil {
+		return nil, contextError(err)
+	}
+	grantStatus := GrantStatus(resp.GetStatus())
+	if !grantStatus.IsValid() {
+		return nil, fmt.Errorf("GrantAccess gRPC call error: invalid status %q", resp.GetStatus())
+	}
+	return &GrantResponse{
+		Status:  grantStatus,
+		Message: resp.GetMessage(),
+	}, nil
+}
+
+// RevokeAccess is the client side stub implementation of the RevokeAccess function.
+func (c *AccessRequesterGRPCClient) RevokeAccess(ctx context.Context, ar *api.AccessRequest, app *argocd.Application) (*RevokeResponse, error) {
+	arData, appData, err := encodeArgs(ar, app)
+	if err != nil {
+		return nil, fmt.Errorf("RevokeAccess gRPC call error: %w", err)
+	}
+	resp, err := c.client.RevokeAccess(ctx, &proto.RevokeAccessRequest{
+		AccessRequest: arData,
+		Application:   appData,
+	})
+	if err != nil {
+		return nil, contextError(err)
+	}
+	revokeStatus := RevokeStatus(resp.GetStatus())
+	if !revokeStatus.IsValid() {
+		return nil, fmt.Errorf("RevokeAccess gRPC call error: invalid status %q", resp.GetStatus())
+	}
+	return &RevokeResponse{
+		Status:  revokeStatus,
+		Message: resp.GetMessage(),
+	}, nil
+}
+
+// AccessRequestGRPCPlugin is the implementation of plugin.GRPCPlugin so we can
+// serve/consume AccessRequester plugins with the gRPC protocol.
+type AccessRequestGRPCPlugin struct {
+	goPlugin.NetRPCUnsupportedPlugin
+	Impl AccessRequesterV2
+}
+
+// GRPCServer will register the server side stub for AccessRequester plugins.
+func (p *AccessRequestGRPCPlugin) GRPCServer(b *goPlugin.GRPCBroker, s *grpc.Server) error {
+	proto.RegisterAccessRequesterServer(s, &AccessRequesterGRPCServer{Impl: p.Impl})
+	return nil
+}
+
+// GRPCClient will build and return the client side stub for AccessRequester plugins.
+func (p *AccessRequestGRPCPlugin) GRPCClient(ctx context.Context, b *goPlugin.GRPCBroker, c *grpc.ClientConn) (interface{}, error) {
+	return &AccessRequesterGRPCClient{client: proto.NewAccessRequesterClient(c)}, nil
+}
+
+// toStatusError converts the error returned by the plugin implementation in a
+// gRPC status error preserving the status if one is already defined.
+func toStatusError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return status.FromContextError(err).Err()
+	}
+	return status.Error(codes.Unknown, err.Error())
+}
+
+// encodeArgs returns the JSON representation of the given AccessRequest and
+// Application as sent over the wire.
+func encodeArgs(ar *api.AccessRequest, app *argocd.Application) ([]byte, []byte, error) {
+	var arData, appData []byte
+	var err error
+	if ar != nil {
+		arData, err = json.Marshal(ar)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error marshaling AccessRequest: %w", err)
+		}
+	}
+	if app != nil {
+		appData, err = json.Marshal(app)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error marshaling Application: %w", err)
+		}
+	}
+	return arData, appData, nil
+}
+
+// decodeArgs returns the AccessRequest and Application from their JSON
+// representation. Empty data is decoded as nil.
+func decodeArgs(arData, appData []byte) (*api.AccessRequest, *argocd.Application, error) {
+	var ar *api.AccessRequest
+	var app *argocd.Application
+	if len(arData) > 0 {
+		ar = &api.AccessRequest{}
+		err := json.Unmarshal(arData, ar)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error unmarshaling AccessRequest: %w", err)
+		}
+	}
+	if len(appData) > 0 {
+		app = &argocd.Application{}
+		err := json.Unmarshal(appData, app)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error unmarshaling Application: %w", err)
+		}
+	}
+	return ar, app, nil
+}
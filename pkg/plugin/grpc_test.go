@@ -0,0 +1,331 @@
+package plugin_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	argocd "github.com/argoproj-labs/argocd-ephemeral-access/api/argoproj/v1alpha1"
+	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
+	"github.com/argoproj-labs/argocd-ephemeral-access/pkg/plugin"
+	goPlugin "github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// newGRPCFixture returns a fixture negotiating the gRPC protocol in the same
+// way the plugin client does when launching a plugin process.
+func newGRPCFixture(t *testing.T) *fixture {
+	t.Setenv("PLUGIN_PROTOCOL_VERSIONS", "1,2")
+	return newFixture(t)
+}
+
+// newGRPCFixtureV2 returns a fixture serving an AccessRequesterV2 plugin
+// with the gRPC protocol.
+func newGRPCFixtureV2(t *testing.T) *fixture {
+	t.Setenv("PLUGIN_PROTOCOL_VERSIONS", "1,2")
+	return newFixtureV2(t)
+}
+
+func TestAccessRequesterGRPC(t *testing.T) {
+	newAccessRequest := func() *api.AccessRequest {
+		return &api.AccessRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some-ar",
+				Namespace: "some-ns",
+			},
+			Spec: api.AccessRequestSpec{
+				Justification: "some reason",
+				TicketRef:     "OPS-123",
+				Role: api.TargetRole{
+					TemplateRef: api.TargetRoleTemplate{
+						Name:      "some-roletmpl",
+						Namespace: "ephemeral",
+					},
+				},
+				Application: api.TargetApplication{
+					Name:      "some-app",
+					Namespace: "argocd",
+				},
+				Subject: api.Subject{
+					Username: "some-user",
+				},
+			},
+		}
+	}
+	newApplication := func() *argocd.Application {
+		return &argocd.Application{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some-app",
+				Namespace: "argocd",
+			},
+			Spec: argocd.ApplicationSpec{
+				Project: "some-project",
+			},
+		}
+	}
+	t.Run("will negotiate the gRPC protocol", func(t *testing.T) {
+		// Given
+		f := newGRPCFixture(t)
+		defer f.cancel()
+		f.accessRequesterMock.EXPECT().Init().Return(nil)
+
+		// When
+		err := f.client.Init()
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, goPlugin.ProtocolGRPC, f.pluginClient.Protocol())
+		assert.IsType(t, &plugin.AccessRequesterGRPCClient{}, f.clientV2)
+	})
+	t.Run("will fall back to net/rpc if the version is not negotiated", func(t *testing.T) {
+		// Given
+		f := newFixture(t)
+		defer f.cancel()
+		f.accessRequesterMock.EXPECT().Init().Return(nil)
+
+		// When
+		err := f.client.Init()
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, goPlugin.ProtocolNetRPC, f.pluginClient.Protocol())
+		assert.IsType(t, &plugin.AccessRequesterRPCClient{}, f.client)
+	})
+	t.Run("will return Init error as status error", func(t *testing.T) {
+		// Given
+		f := newGRPCFixture(t)
+		defer f.cancel()
+		f.accessRequesterMock.EXPECT().Init().Return(errors.New("Init error"))
+
+		// When
+		err := f.client.Init()
+
+		// Then
+		require.Error(t, err)
+		st, ok := status.FromError(err)
+		require.True(t, ok)
+		assert.Equal(t, codes.Unknown, st.Code())
+		assert.Equal(t, "Init error", st.Message())
+	})
+	t.Run("will send AccessRequest and Application to GrantAccess", func(t *testing.T) {
+		// Given
+		f := newGRPCFixture(t)
+		defer f.cancel()
+		ar := newAccessRequest()
+		app := newApplication()
+		var receivedAr *api.AccessRequest
+		var receivedApp *argocd.Application
+		runFn := func(ar *api.AccessRequest, app *argocd.Application) (*plugin.GrantResponse, error) {
+			receivedAr = ar
+			receivedApp = app
+			return &plugin.GrantResponse{
+				Status:  plugin.GrantStatusPending,
+				Message: "some grant message",
+			}, nil
+		}
+		f.accessRequesterMock.EXPECT().GrantAccess(mock.Anything, mock.Anything).
+			RunAndReturn(runFn)
+
+		// When
+		resp, err := f.client.GrantAccess(ar, app)
+
+		// Then
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+		assert.Equal(t, plugin.GrantStatusPending, resp.Status)
+		assert.Equal(t, "some grant message", resp.Message)
+		assert.Equal(t, ar, receivedAr)
+		assert.Equal(t, app, receivedApp)
+	})
+	t.Run("will send nil arguments to GrantAccess", func(t *testing.T) {
+		// Given
+		f := newGRPCFixture(t)
+		defer f.cancel()
+		f.accessRequesterMock.EXPECT().GrantAccess((*api.AccessRequest)(nil), (*argocd.Application)(nil)).
+			Return(&plugin.GrantResponse{Status: plugin.GrantStatusDenied}, nil)
+
+		// When
+		resp, err := f.client.GrantAccess(nil, nil)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, plugin.GrantStatusDenied, resp.Status)
+	})
+	t.Run("will preserve the status code returned by GrantAccess", func(t *testing.T) {
+		// Given
+		f := newGRPCFixture(t)
+		defer f.cancel()
+		f.accessRequesterMock.EXPECT().GrantAccess(mock.Anything, mock.Anything).
+			Return(nil, status.Error(codes.PermissionDenied, "approver not allowed"))
+
+		// When
+		resp, err := f.client.GrantAccess(newAccessRequest(), newApplication())
+
+		// Then
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.Contains(t, err.Error(), "approver not allowed")
+	})
+	t.Run("will return error if GrantAccess returns an invalid status", func(t *testing.T) {
+		for _, grantStatus := range []plugin.GrantStatus{"", "pending"} {
+			t.Run(fmt.Sprintf("status %q", grantStatus), func(t *testing.T) {
+				// Given
+				f := newGRPCFixtureV2(t)
+				defer f.cancel()
+				f.accessRequesterV2Mock.EXPECT().GrantAccess(mock.Anything, mock.Anything, mock.Anything).
+					Return(&plugin.GrantResponse{Status: grantStatus}, nil)
+
+				// When
+				resp, err := f.clientV2.GrantAccess(context.Background(), newAccessRequest(), newApplication())
+
+				// Then
+				require.Error(t, err)
+				assert.Nil(t, resp)
+				assert.Contains(t, err.Error(), "invalid status")
+			})
+		}
+	})
+	t.Run("will return error if GrantAccess returns a nil response", func(t *testing.T) {
+		// Given
+		f := newGRPCFixtureV2(t)
+		defer f.cancel()
+		f.accessRequesterV2Mock.EXPECT().GrantAccess(mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, nil)
+
+		// When
+		resp, err := f.clientV2.GrantAccess(context.Background(), newAccessRequest(), newApplication())
+
+		// Then
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), `invalid status ""`)
+	})
+	t.Run("will return error if RevokeAccess returns an invalid status", func(t *testing.T) {
+		// Given
+		f := newGRPCFixtureV2(t)
+		defer f.cancel()
+		f.accessRequesterV2Mock.EXPECT().RevokeAccess(mock.Anything, mock.Anything, mock.Anything).
+			Return(&plugin.RevokeResponse{Status: "done"}, nil)
+
+		// When
+		resp, err := f.clientV2.RevokeAccess(context.Background(), newAccessRequest(), newApplication())
+
+		// Then
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), `invalid status "done"`)
+	})
+	t.Run("will send AccessRequest and Application to RevokeAccess", func(t *testing.T) {
+		// Given
+		f := newGRPCFixture(t)
+		defer f.cancel()
+		ar := newAccessRequest()
+		app := newApplication()
+		f.accessRequesterMock.EXPECT().RevokeAccess(ar, app).
+			Return(&plugin.RevokeResponse{
+				Status:  plugin.RevokeStatusRevoked,
+				Message: "some revoke message",
+			}, nil)
+
+		// When
+		resp, err := f.client.RevokeAccess(ar, app)
+
+		// Then
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+		assert.Equal(t, plugin.RevokeStatusRevoked, resp.Status)
+		assert.Equal(t, "some revoke message", resp.Message)
+	})
+	t.Run("will return RevokeAccess error as status error", func(t *testing.T) {
+		// Given
+		f := newGRPCFixture(t)
+		defer f.cancel()
+		f.accessRequesterMock.EXPECT().RevokeAccess(mock.Anything, mock.Anything).
+			Return(nil, errors.New("revoke access error"))
+
+		// When
+		resp, err := f.client.RevokeAccess(newAccessRequest(), newApplication())
+
+		// Then
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Equal(t, codes.Unknown, status.Code(err))
+		assert.Contains(t, err.Error(), "revoke access error")
+	})
+	t.Run("will propagate the deadline to the plugin", func(t *testing.T) {
+		// Given
+		f := newGRPCFixtureV2(t)
+		defer f.cancel()
+		var hasDeadline bool
+		runFn := func(ctx context.Context, ar *api.AccessRequest, app *argocd.Application) (*plugin.GrantResponse, error) {
+			_, hasDeadline = ctx.Deadline()
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		f.accessRequesterV2Mock.EXPECT().GrantAccess(mock.Anything, mock.Anything, mock.Anything).
+			RunAndReturn(runFn)
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+
+		// When
+		resp, err := f.clientV2.GrantAccess(ctx, newAccessRequest(), newApplication())
+
+		// Then
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, plugin.ErrTimeout)
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+		assert.True(t, hasDeadline)
+	})
+	t.Run("will propagate the cancellation to the plugin", func(t *testing.T) {
+		// Given
+		f := newGRPCFixtureV2(t)
+		defer f.cancel()
+		cancelled := make(chan struct{})
+		runFn := func(ctx context.Context, ar *api.AccessRequest, app *argocd.Application) (*plugin.RevokeResponse, error) {
+			<-ctx.Done()
+			close(cancelled)
+			return nil, ctx.Err()
+		}
+		f.accessRequesterV2Mock.EXPECT().RevokeAccess(mock.Anything, mock.Anything, mock.Anything).
+			RunAndReturn(runFn)
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(100*time.Millisecond, cancel)
+
+		// When
+		_, err := f.clientV2.RevokeAccess(ctx, newAccessRequest(), newApplication())
+
+		// Then
+		require.Error(t, err)
+		assert.Equal(t, codes.Canceled, status.Code(err))
+		assert.NotErrorIs(t, err, plugin.ErrTimeout)
+		select {
+		case <-cancelled:
+		case <-time.After(2 * time.Second):
+			t.Fatal("plugin context was not cancelled")
+		}
+	})
+	t.Run("will serve AccessRequesterV2 plugins with net/rpc", func(t *testing.T) {
+		// Given
+		f := newFixtureV2(t)
+		defer f.cancel()
+		f.accessRequesterV2Mock.EXPECT().GrantAccess(mock.Anything, mock.Anything, mock.Anything).
+			Return(&plugin.GrantResponse{Status: plugin.GrantStatusGranted}, nil)
+
+		// When
+		resp, err := f.clientV2.GrantAccess(context.Background(), newAccessRequest(), newApplication())
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, goPlugin.ProtocolNetRPC, f.pluginClient.Protocol())
+		assert.Equal(t, plugin.GrantStatusGranted, resp.Status)
+	})
+}
@@ -0,0 +1,190 @@
+package plugin
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	argocd "github.com/argoproj-labs/argocd-ephemeral-access/api/argoproj/v1alpha1"
+	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
+	"github.com/argoproj-labs/argocd-ephemeral-access/internal/httpretry"
+)
+
+const (
+	// HTTPGrantPath is the path, relative to the base URL, receiving the
+	// GrantAccess requests.
+	HTTPGrantPath = "/grant"
+	// HTTPRevokePath is the path, relative to the base URL, receiving the
+	// RevokeAccess requests.
+	HTTPRevokePath = "/revoke"
+
+	defaultHTTPMaxRetries = 3
+	defaultHTTPBackoff    = time.Second
+)
+
+// HTTPRequest is the JSON document posted to the remote AccessRequester.
+type HTTPRequest struct {
+	// AccessRequest is the AccessRequest being evaluated.
+	AccessRequest *api.AccessRequest `json:"accessRequest"`
+	// Application is the Argo CD Application the access is requested for.
+	Application *argocd.Application `json:"application"`
+}
+
+// HTTPResponse is the JSON document returned by the remote AccessRequester.
+type HTTPResponse struct {
+	// Status is the plugin decision. Possible values for grant requests:
+	// granted, grant-pending, denied. Possible values for revoke requests:
+	// revoked, revoke-pending.
+	Status string `json:"status"`
+	// Message is an optional message displayed to the user.
+	Message string `json:"message,omitempty"`
+}
+
+// HTTPAccessRequester is an AccessRequesterV2 delegating the decisions to a
+// remote service over HTTP. Requests are sent as HTTPRequest documents to the
+// HTTPGrantPath and HTTPRevokePath of the configured base URL and the service
+// must answer with an HTTPResponse document.
+type HTTPAccessRequester struct {
+	url        string
+	token      string
+	client     *http.Client
+	maxRetries int
+	backoff    time.Duration
+}
+
+// HTTPOption defines an optional configuration of the HTTPAccessRequester.
+type HTTPOption func(*HTTPAccessRequester)
+
+// WithBearerToken configures the token sent in the Authorization header.
+func WithBearerToken(token string) HTTPOption {
+	return func(h *HTTPAccessRequester) {
+		h.token = token
+	}
+}
+
+// WithTLSConfig configures the TLS settings used to connect to the remote
+// service, e.g. the client certificate for mTLS and the trusted CAs.
+func WithTLSConfig(config *tls.Config) HTTPOption {
+	return func(h *HTTPAccessRequester) {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = config
+		h.client.Transport = transport
+	}
+}
+
+// WithHTTPTimeout configures the maximum duration of each attempt to call
+// the remote service. Setting it to 0 disables the timeout so the attempts
+// are only bounded by the context of each call.
+func WithHTTPTimeout(timeout time.Duration) HTTPOption {
+	return func(h *HTTPAccessRequester) {
+		h.client.Timeout = timeout
+	}
+}
+
+// WithHTTPMaxRetries configures how many times a failed request is retried.
+func WithHTTPMaxRetries(maxRetries int) HTTPOption {
+	return func(h *HTTPAccessRequester) {
+		h.maxRetries = maxRetries
+	}
+}
+
+// WithHTTPBackoff configures the initial interval between retries. The
+// interval is doubled after each attempt.
+func WithHTTPBackoff(backoff time.Duration) HTTPOption {
+	return func(h *HTTPAccessRequester) {
+		h.backoff = backoff
+	}
+}
+
+// NewHTTPAccessRequester returns an HTTPAccessRequester sending the requests
+// to the given base URL. Each attempt times out after 30 seconds unless
+// configured otherwise with WithHTTPTimeout, even if the context of the call
+// has no deadline.
+func NewHTTPAccessRequester(url string, opts ...HTTPOption) (*HTTPAccessRequester, error) {
+	if url == "" {
+		return nil, errors.New("plugin url cannot be empty")
+	}
+	h := &HTTPAccessRequester{
+		url:        strings.TrimSuffix(url, "/"),
+		client:     &http.Client{Timeout: httpretry.DefaultTimeout},
+		maxRetries: defaultHTTPMaxRetries,
+		backoff:    defaultHTTPBackoff,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h, nil
+}
+
+// Init is a noop as the remote service lifecycle is not managed by the
+// controller.
+func (h *HTTPAccessRequester) Init(ctx context.Context) error {
+	return nil
+}
+
+// GrantAccess will post the given AccessRequest and Application to the grant
+// endpoint of the remote service.
+func (h *HTTPAccessRequester) GrantAccess(ctx context.Context, ar *api.AccessRequest, app *argocd.Application) (*GrantResponse, error) {
+	resp, err := h.call(ctx, HTTPGrantPath, ar, app)
+	if err != nil {
+		return nil, fmt.Errorf("GrantAccess HTTP call error: %w", err)
+	}
+	status := GrantStatus(resp.Status)
+	if !status.IsValid() {
+		return nil, fmt.Errorf("GrantAccess HTTP call error: invalid status %q", resp.Status)
+	}
+	return &GrantResponse{Status: status, Message: resp.Message}, nil
+}
+
+// RevokeAccess will post the given AccessRequest and Application to the
+// revoke endpoint of the remote service.
+func (h *HTTPAccessRequester) RevokeAccess(ctx context.Context, ar *api.AccessRequest, app *argocd.Application) (*RevokeResponse, error) {
+	resp, err := h.call(ctx, HTTPRevokePath, ar, app)
+	if err != nil {
+		return nil, fmt.Errorf("RevokeAccess HTTP call error: %w", err)
+	}
+	status := RevokeStatus(resp.Status)
+	if !status.IsValid() {
+		return nil, fmt.Errorf("RevokeAccess HTTP call error: invalid status %q", resp.Status)
+	}
+	return &RevokeResponse{Status: status, Message: resp.Message}, nil
+}
+
+// call will post the request to the given path. Requests failing with
+// network errors, 429 or 5xx responses are retried with exponential backoff
+// until the context is done. Each attempt is bounded by the client timeout.
+func (h *HTTPAccessRequester) call(ctx context.Context, path string, ar *api.AccessRequest, app *argocd.Application) (*HTTPResponse, error) {
+	payload, err := json.Marshal(&HTTPRequest{AccessRequest: ar, Application: app})
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling request: %w", err)
+	}
+	header := http.Header{}
+	header.Set("Accept", "application/json")
+	if h.token != "" {
+		header.Set("Authorization", "Bearer "+h.token)
+	}
+	result := &HTTPResponse{}
+	err = httpretry.Do(ctx, h.maxRetries, h.backoff, func(ctx context.Context) error {
+		body, err := httpretry.Post(ctx, h.client, h.url+path, header, payload)
+		if err != nil {
+			return err
+		}
+		err = json.Unmarshal(body, result)
+		if err != nil {
+			return httpretry.Permanent(fmt.Errorf("error unmarshaling response: %w", err))
+		}
+		return nil
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, contextError(ctx.Err())
+		}
+		return nil, fmt.Errorf("remote AccessRequester request %w", err)
+	}
+	return result, nil
+}
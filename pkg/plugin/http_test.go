@@ -0,0 +1,224 @@
+package plugin_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	argocd "github.com/argoproj-labs/argocd-ephemeral-access/api/argoproj/v1alpha1"
+	"github.com/argoproj-labs/argocd-ephemeral-access/pkg/plugin"
+	"github.com/argoproj-labs/argocd-ephemeral-access/test/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type httpRequest struct {
+	path   string
+	header http.Header
+	body   []byte
+}
+
+// newHTTPServer returns a server answering with the given responses in order.
+// The last response is repeated once all responses are used.
+func newHTTPServer(t *testing.T, tls bool, responses ...func(w http.ResponseWriter)) (*httptest.Server, chan httpRequest) {
+	t.Helper()
+	requests := make(chan httpRequest, 10)
+	var calls atomic.Int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests <- httpRequest{path: r.URL.Path, header: r.Header.Clone(), body: body}
+		call := min(int(calls.Add(1))-1, len(responses)-1)
+		responses[call](w)
+	})
+	server := httptest.NewUnstartedServer(handler)
+	if tls {
+		server.StartTLS()
+	} else {
+		server.Start()
+	}
+	t.Cleanup(server.Close)
+	return server, requests
+}
+
+func respond(statusCode int, body string) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+func TestHTTPAccessRequester(t *testing.T) {
+	ar := utils.NewAccessRequest("some-ar", "ephemeral", "some-app", "argocd", "some-role", "ephemeral", "user-id", "some-user")
+	app := &argocd.Application{Spec: argocd.ApplicationSpec{Project: "some-project"}}
+
+	t.Run("will post the grant request", func(t *testing.T) {
+		// Given
+		server, requests := newHTTPServer(t, false, respond(http.StatusOK, `{"status": "grant-pending", "message": "waiting approval"}`))
+		requester, err := plugin.NewHTTPAccessRequester(server.URL+"/", plugin.WithBearerToken("some-token"))
+		require.NoError(t, err)
+
+		// When
+		resp, err := requester.GrantAccess(context.Background(), ar, app)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, plugin.GrantStatusPending, resp.Status)
+		assert.Equal(t, "waiting approval", resp.Message)
+		require.Len(t, requests, 1)
+		req := <-requests
+		assert.Equal(t, plugin.HTTPGrantPath, req.path)
+		assert.Equal(t, "application/json", req.header.Get("Content-Type"))
+		assert.Equal(t, "Bearer some-token", req.header.Get("Authorization"))
+		payload := &plugin.HTTPRequest{}
+		require.NoError(t, json.Unmarshal(req.body, payload))
+		assert.Equal(t, "some-ar", payload.AccessRequest.GetName())
+		assert.Equal(t, "some-user", payload.AccessRequest.Spec.Subject.Username)
+		assert.Equal(t, "some-project", payload.Application.Spec.Project)
+	})
+	t.Run("will post the revoke request", func(t *testing.T) {
+		// Given
+		server, requests := newHTTPServer(t, false, respond(http.StatusOK, `{"status": "revoked"}`))
+		requester, err := plugin.NewHTTPAccessRequester(server.URL)
+		require.NoError(t, err)
+
+		// When
+		resp, err := requester.RevokeAccess(context.Background(), ar, app)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, plugin.RevokeStatusRevoked, resp.Status)
+		req := <-requests
+		assert.Equal(t, plugin.HTTPRevokePath, req.path)
+		assert.Empty(t, req.header.Get("Authorization"))
+	})
+	t.Run("will retry on server errors", func(t *testing.T) {
+		// Given
+		server, requests := newHTTPServer(t, false,
+			respond(http.StatusServiceUnavailable, ""),
+			respond(http.StatusTooManyRequests, ""),
+			respond(http.StatusOK, `{"status": "granted"}`))
+		requester, err := plugin.NewHTTPAccessRequester(server.URL, plugin.WithHTTPBackoff(time.Millisecond))
+		require.NoError(t, err)
+
+		// When
+		resp, err := requester.GrantAccess(context.Background(), ar, app)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, plugin.GrantStatusGranted, resp.Status)
+		assert.Len(t, requests, 3)
+	})
+	t.Run("will return error once retries are exhausted", func(t *testing.T) {
+		// Given
+		server, requests := newHTTPServer(t, false, respond(http.StatusInternalServerError, "some failure"))
+		requester, err := plugin.NewHTTPAccessRequester(server.URL,
+			plugin.WithHTTPBackoff(time.Millisecond), plugin.WithHTTPMaxRetries(2))
+		require.NoError(t, err)
+
+		// When
+		resp, err := requester.GrantAccess(context.Background(), ar, app)
+
+		// Then
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), "failed after 3 attempts")
+		assert.Contains(t, err.Error(), "status 500: some failure")
+		assert.Len(t, requests, 3)
+	})
+	t.Run("will not retry on client errors", func(t *testing.T) {
+		// Given
+		server, requests := newHTTPServer(t, false, respond(http.StatusUnauthorized, "invalid token"))
+		requester, err := plugin.NewHTTPAccessRequester(server.URL, plugin.WithHTTPBackoff(time.Millisecond))
+		require.NoError(t, err)
+
+		// When
+		_, err = requester.RevokeAccess(context.Background(), ar, app)
+
+		// Then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "status 401: invalid token")
+		assert.Len(t, requests, 1)
+	})
+	t.Run("will return error if the status is invalid", func(t *testing.T) {
+		// Given
+		server, _ := newHTTPServer(t, false, respond(http.StatusOK, `{"status": "revoked"}`))
+		requester, err := plugin.NewHTTPAccessRequester(server.URL)
+		require.NoError(t, err)
+
+		// When
+		resp, err := requester.GrantAccess(context.Background(), ar, app)
+
+		// Then
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), `invalid status "revoked"`)
+	})
+	t.Run("will return ErrTimeout if the deadline is exceeded", func(t *testing.T) {
+		// Given
+		server, _ := newHTTPServer(t, false, respond(http.StatusServiceUnavailable, ""))
+		requester, err := plugin.NewHTTPAccessRequester(server.URL, plugin.WithHTTPBackoff(time.Hour))
+		require.NoError(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		// When
+		_, err = requester.GrantAccess(ctx, ar, app)
+
+		// Then
+		require.Error(t, err)
+		assert.ErrorIs(t, err, plugin.ErrTimeout)
+	})
+	t.Run("will time out attempts to an unresponsive service", func(t *testing.T) {
+		// Given
+		blocked := make(chan struct{})
+		server, requests := newHTTPServer(t, false, func(w http.ResponseWriter) {
+			<-blocked
+		})
+		defer close(blocked)
+		requester, err := plugin.NewHTTPAccessRequester(server.URL,
+			plugin.WithHTTPTimeout(50*time.Millisecond),
+			plugin.WithHTTPBackoff(time.Millisecond),
+			plugin.WithHTTPMaxRetries(1))
+		require.NoError(t, err)
+
+		// When
+		resp, err := requester.GrantAccess(context.Background(), ar, app)
+
+		// Then
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), "failed after 2 attempts")
+		assert.Len(t, requests, 2)
+	})
+	t.Run("will connect with the given TLS config", func(t *testing.T) {
+		// Given
+		server, _ := newHTTPServer(t, true, respond(http.StatusOK, `{"status": "granted"}`))
+		pool := x509.NewCertPool()
+		pool.AddCert(server.Certificate())
+		requester, err := plugin.NewHTTPAccessRequester(server.URL, plugin.WithTLSConfig(&tls.Config{RootCAs: pool}))
+		require.NoError(t, err)
+
+		// When
+		resp, err := requester.GrantAccess(context.Background(), ar, app)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, plugin.GrantStatusGranted, resp.Status)
+	})
+	t.Run("will return error if the url is empty", func(t *testing.T) {
+		// When
+		_, err := plugin.NewHTTPAccessRequester("")
+
+		// Then
+		require.Error(t, err)
+	})
+}
@@ -1,7 +1,9 @@
 package plugin
 
 import (
+	"context"
 	"encoding/gob"
+	"errors"
 	"fmt"
 	"net/rpc"
 	"os/exec"
@@ -29,6 +31,24 @@ func init() {
 	gob.Register(&PluginError{})
 }
 
+// IsValid returns true if the status is one of the known GrantStatus values.
+func (s GrantStatus) IsValid() bool {
+	switch s {
+	case GrantStatusGranted, GrantStatusPending, GrantStatusDenied:
+		return true
+	}
+	return false
+}
+
+// IsValid returns true if the status is one of the known RevokeStatus values.
+func (s RevokeStatus) IsValid() bool {
+	switch s {
+	case RevokeStatusRevoked, RevokeStatusPending:
+		return true
+	}
+	return false
+}
+
 // PluginError the error type returned by the rpc server over the wire
 type PluginError struct {
 	Err string
@@ -47,6 +67,22 @@ type AccessRequester interface {
 	RevokeAccess(ar *api.AccessRequest, app *argocd.Application) (*RevokeResponse, error)
 }
 
+// AccessRequesterV2 defines the context aware interface that can be
+// implemented by ephemeral access plugins. The context is cancelled when the
+// call times out or when the controller is shutting down. With the gRPC
+// protocol, the deadline and the cancellation are propagated to the plugin
+// process. Plugins implementing AccessRequester are supported through
+// NewV1Adapter.
+type AccessRequesterV2 interface {
+	Init(ctx context.Context) error
+	GrantAccess(ctx context.Context, ar *api.AccessRequest, app *argocd.Application) (*GrantResponse, error)
+	RevokeAccess(ctx context.Context, ar *api.AccessRequest, app *argocd.Application) (*RevokeResponse, error)
+}
+
+// ErrTimeout is returned when a plugin call doesn't complete before the
+// deadline of its context.
+var ErrTimeout = errors.New("plugin call timed out")
+
 // GrantResponse defines the response that will be returned by access
 // request plugins.
 type GrantResponse struct {
@@ -200,46 +236,116 @@ func (AccessRequestPlugin) Client(b *goPlugin.MuxBroker, c *rpc.Client) (interfa
 	return &AccessRequesterRPCClient{client: c}, nil
 }
 
+const (
+	// ProtocolVersionNetRPC is the plugin protocol version served with
+	// net/rpc. Only plugins written in Go can implement it.
+	ProtocolVersionNetRPC = 1
+	// ProtocolVersionGRPC is the plugin protocol version served with gRPC
+	// as defined in proto/accessrequester.proto.
+	ProtocolVersionGRPC = 2
+)
+
 // handshake returns the handshake config used by AccessRequester plugins.
+// The ProtocolVersion is only used by plugins not negotiating the version.
 func handshake() goPlugin.HandshakeConfig {
 	return goPlugin.HandshakeConfig{
-		ProtocolVersion:  1,
+		ProtocolVersion:  ProtocolVersionNetRPC,
 		MagicCookieKey:   "EPHEMERAL_ACCESS_PLUGIN",
 		MagicCookieValue: "ephemeralaccess",
 	}
 }
 
 // NewServerConfig will build and return a new instance of server stub configs.
+// The plugin is served with gRPC if supported by the controller and falls
+// back to net/rpc otherwise.
 func NewServerConfig(impl AccessRequester, log hclog.Logger) *goPlugin.ServeConfig {
-	pluginMap := map[string]goPlugin.Plugin{
-		Key: &AccessRequestPlugin{
-			Impl: impl,
+	return newServerConfig(impl, NewV1Adapter(impl), log)
+}
+
+// NewServerConfigV2 will build and return a new instance of server stub
+// configs for plugins implementing the context aware AccessRequesterV2
+// interface. When served with net/rpc, the plugin receives a context that is
+// never cancelled.
+func NewServerConfigV2(impl AccessRequesterV2, log hclog.Logger) *goPlugin.ServeConfig {
+	return newServerConfig(&v2Adapter{impl: impl}, impl, log)
+}
+
+func newServerConfig(impl AccessRequester, implV2 AccessRequesterV2, log hclog.Logger) *goPlugin.ServeConfig {
+	versionedPlugins := map[int]goPlugin.PluginSet{
+		ProtocolVersionNetRPC: {
+			Key: &AccessRequestPlugin{Impl: impl},
+		},
+		ProtocolVersionGRPC: {
+			Key: &AccessRequestGRPCPlugin{Impl: implV2},
 		},
 	}
 	return &goPlugin.ServeConfig{
-		HandshakeConfig: handshake(),
-		Plugins:         pluginMap,
-		Logger:          log,
+		HandshakeConfig:  handshake(),
+		VersionedPlugins: versionedPlugins,
+		GRPCServer:       goPlugin.DefaultGRPCServer,
+		Logger:           log,
 	}
 }
 
 // NewClientConfig will build and return a new instance of client stub configs.
+// Both the net/rpc and gRPC protocols are accepted. The protocol used is
+// negotiated with the plugin based on the protocol version.
 func NewClientConfig(pluginPath string, log hclog.Logger) *goPlugin.ClientConfig {
-	pluginMap := map[string]goPlugin.Plugin{
-		Key: &AccessRequestPlugin{},
+	versionedPlugins := map[int]goPlugin.PluginSet{
+		ProtocolVersionNetRPC: {
+			Key: &AccessRequestPlugin{},
+		},
+		ProtocolVersionGRPC: {
+			Key: &AccessRequestGRPCPlugin{},
+		},
 	}
 	return &goPlugin.ClientConfig{
-		HandshakeConfig: handshake(),
-		Plugins:         pluginMap,
-		Cmd:             exec.Command(pluginPath),
-		Logger:          log,
+		HandshakeConfig:  handshake(),
+		VersionedPlugins: versionedPlugins,
+		AllowedProtocols: []goPlugin.Protocol{goPlugin.ProtocolNetRPC, goPlugin.ProtocolGRPC},
+		Cmd:              exec.Command(pluginPath),
+		Logger:           log,
 	}
 }
 
 // GetAccessRequester will attempt to instantiate a new AccessRequester from the
-// provided client. The returned AccessRequester will invoke RPC calls targeting
-// the plugin implementation on method calls.
+// provided client. The returned AccessRequester will invoke net/rpc or gRPC
+// calls targeting the plugin implementation on method calls depending on the
+// negotiated protocol.
 func GetAccessRequester(client *goPlugin.Client) (AccessRequester, error) {
+	raw, err := dispense(client)
+	if err != nil {
+		return nil, err
+	}
+
+	switch plugin := raw.(type) {
+	case AccessRequester:
+		return plugin, nil
+	case AccessRequesterV2:
+		return &v2Adapter{impl: plugin}, nil
+	}
+	return nil, fmt.Errorf("returned plugin instance is not AccessRequester")
+}
+
+// GetAccessRequesterV2 will attempt to instantiate a new AccessRequesterV2
+// from the provided client. Plugins served with net/rpc are adapted with
+// NewV1Adapter as the context can't be propagated to the plugin process.
+func GetAccessRequesterV2(client *goPlugin.Client) (AccessRequesterV2, error) {
+	raw, err := dispense(client)
+	if err != nil {
+		return nil, err
+	}
+
+	switch plugin := raw.(type) {
+	case AccessRequesterV2:
+		return plugin, nil
+	case AccessRequester:
+		return NewV1Adapter(plugin), nil
+	}
+	return nil, fmt.Errorf("returned plugin instance is not AccessRequester")
+}
+
+func dispense(client *goPlugin.Client) (interface{}, error) {
 	rpcClient, err := client.Client()
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving rpc client: %w", err)
@@ -249,11 +355,5 @@ func GetAccessRequester(client *goPlugin.Client) (AccessRequester, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error getting a new plugin instance: %w", err)
 	}
-
-	plugin, ok := raw.(AccessRequester)
-	if !ok {
-		return nil, fmt.Errorf("returned plugin instance is not AccessRequester")
-	}
-
-	return plugin, nil
+	return raw, nil
 }
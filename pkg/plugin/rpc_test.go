@@ -17,17 +17,55 @@ import (
 )
 
 type fixture struct {
-	accessRequesterMock *mocks.MockAccessRequester
-	cancel              func()
-	client              plugin.AccessRequester
+	accessRequesterMock   *mocks.MockAccessRequester
+	accessRequesterV2Mock *mocks.MockAccessRequesterV2
+	cancel                func()
+	client                plugin.AccessRequester
+	clientV2              plugin.AccessRequesterV2
+	pluginClient          *goPlugin.Client
 }
 
 func newFixture(t *testing.T) *fixture {
+	mock := mocks.NewMockAccessRequester(t)
+	f := serve(t, plugin.NewServerConfig(mock, nil))
+	f.accessRequesterMock = mock
+	return f
+}
+
+func newFixtureV2(t *testing.T) *fixture {
+	mock := mocks.NewMockAccessRequesterV2(t)
+	f := serve(t, plugin.NewServerConfigV2(mock, nil))
+	f.accessRequesterV2Mock = mock
+	return f
+}
+
+// serve will start the plugin server with the given config and return a
+// fixture with the clients connected to it.
+func serve(t *testing.T, srvConfig *goPlugin.ServeConfig) *fixture {
+	client, cancel := serveClient(t, srvConfig)
+	accessRequester, err := plugin.GetAccessRequester(client)
+	if err != nil {
+		t.Fatalf("error getting AccessRequester: %s", err)
+	}
+	accessRequesterV2, err := plugin.GetAccessRequesterV2(client)
+	if err != nil {
+		t.Fatalf("error getting AccessRequesterV2: %s", err)
+	}
+	return &fixture{
+		cancel:       cancel,
+		client:       accessRequester,
+		clientV2:     accessRequesterV2,
+		pluginClient: client,
+	}
+}
+
+// serveClient will start the plugin server with the given config and return
+// a plugin client attached to it. The server is stopped with the returned
+// cancel function.
+func serveClient(t *testing.T, srvConfig *goPlugin.ServeConfig) (*goPlugin.Client, context.CancelFunc) {
 	ctx, cancel := context.WithCancel(context.Background())
 	ch := make(chan *goPlugin.ReattachConfig, 1)
 
-	mock := mocks.NewMockAccessRequester(t)
-	srvConfig := plugin.NewServerConfig(mock, nil)
 	srvConfig.Test = &goPlugin.ServeTestConfig{
 		Context:          ctx,
 		ReattachConfigCh: ch,
@@ -49,17 +87,9 @@ func newFixture(t *testing.T) *fixture {
 	cliConfig := plugin.NewClientConfig("", nil)
 	cliConfig.Cmd = nil
 	cliConfig.Reattach = config
-	client := goPlugin.NewClient(cliConfig)
-
-	plugin, err := plugin.GetAccessRequester(client)
-	if err != nil {
-		t.Fatalf("error getting AccessRequester: %s", err)
-	}
-	return &fixture{
-		accessRequesterMock: mock,
-		cancel:              cancel,
-		client:              plugin,
-	}
+	// go-plugin doesn't select the negotiated plugin set when reattaching
+	cliConfig.Plugins = cliConfig.VersionedPlugins[config.ProtocolVersion]
+	return goPlugin.NewClient(cliConfig), cancel
 }
 
 func TestAccessRequesterRPC(t *testing.T) {
@@ -154,6 +184,31 @@ func TestAccessRequesterRPC(t *testing.T) {
 		f.accessRequesterMock.AssertNumberOfCalls(t, "GrantAccess", 1)
 		f.accessRequesterMock.AssertNumberOfCalls(t, "RevokeAccess", 0)
 	})
+	t.Run("will send justification and ticket reference to GrantAccess", func(t *testing.T) {
+		// Given
+		f := newFixture(t)
+		defer f.cancel()
+		ar := newAccessRequest("some-ar", "some-ns", "some-roletmpl", "some-user")
+		ar.Spec.Justification = "some reason"
+		ar.Spec.TicketRef = "OPS-123"
+		app := newApplication("some-project")
+		var receivedAr *api.AccessRequest
+		runFn := func(ar *api.AccessRequest, app *argocd.Application) (*plugin.GrantResponse, error) {
+			receivedAr = ar
+			return &plugin.GrantResponse{Status: plugin.GrantStatusGranted}, nil
+		}
+		f.accessRequesterMock.EXPECT().GrantAccess(mock.Anything, mock.Anything).
+			RunAndReturn(runFn)
+
+		// When
+		_, err := f.client.GrantAccess(ar, app)
+
+		// Then
+		assert.NoError(t, err)
+		assert.NotNil(t, receivedAr)
+		assert.Equal(t, "some reason", receivedAr.Spec.Justification)
+		assert.Equal(t, "OPS-123", receivedAr.Spec.TicketRef)
+	})
 	t.Run("will validate GrantAccess properly returns error", func(t *testing.T) {
 		// Given
 		f := newFixture(t)
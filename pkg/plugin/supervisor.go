@@ -0,0 +1,286 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	argocd "github.com/argoproj-labs/argocd-ephemeral-access/api/argoproj/v1alpha1"
+	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
+	"github.com/hashicorp/go-hclog"
+	goPlugin "github.com/hashicorp/go-plugin"
+)
+
+const (
+	defaultHealthCheckInterval = 10 * time.Second
+	defaultSupervisorTimeout   = 30 * time.Second
+	defaultRestartMinBackoff   = time.Second
+	defaultRestartMaxBackoff   = 5 * time.Minute
+)
+
+// ErrPluginUnavailable is returned when the plugin is invoked while its
+// process isn't healthy, e.g. after a crash and before it is relaunched.
+var ErrPluginUnavailable = errors.New("plugin is unavailable")
+
+// Supervisor is an AccessRequesterV2 managing the plugin process. Once
+// started, it periodically pings the plugin and relaunches the process with
+// exponential backoff if it exits or stops responding.
+type Supervisor struct {
+	newClient  func() *goPlugin.Client
+	log        hclog.Logger
+	interval   time.Duration
+	timeout    time.Duration
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	healthHook func(healthy bool)
+
+	lock            sync.RWMutex
+	client          *goPlugin.Client
+	accessRequester AccessRequesterV2
+	healthy         bool
+}
+
+// SupervisorOption defines an optional configuration of the Supervisor.
+type SupervisorOption func(*Supervisor)
+
+// WithHealthCheckInterval configures how often the plugin is pinged. Values
+// of zero or less are replaced with the default interval of 10 seconds.
+func WithHealthCheckInterval(interval time.Duration) SupervisorOption {
+	return func(s *Supervisor) {
+		s.interval = interval
+	}
+}
+
+// WithSupervisorTimeout configures the maximum duration of the health checks
+// and of the Init call when the plugin is relaunched. Values of zero or less
+// are replaced with the default timeout of 30 seconds.
+func WithSupervisorTimeout(timeout time.Duration) SupervisorOption {
+	return func(s *Supervisor) {
+		s.timeout = timeout
+	}
+}
+
+// WithRestartMaxBackoff configures the maximum interval between failed
+// attempts to relaunch the plugin. The interval starts at one second, or at
+// the maximum if lower, and is doubled after each failed attempt. Values of
+// zero or less are replaced with the default maximum of 5 minutes.
+func WithRestartMaxBackoff(backoff time.Duration) SupervisorOption {
+	return func(s *Supervisor) {
+		s.maxBackoff = backoff
+	}
+}
+
+// WithHealthHook configures a function invoked every time the plugin health
+// changes, e.g. to publish it as a metric.
+func WithHealthHook(hook func(healthy bool)) SupervisorOption {
+	return func(s *Supervisor) {
+		s.healthHook = hook
+	}
+}
+
+// NewSupervisor returns a Supervisor launching the plugin process with the
+// clients returned by newClient. A new client is required for every launch
+// as go-plugin clients can't be restarted once their process exits. The
+// plugin is launched when Init is invoked.
+func NewSupervisor(newClient func() *goPlugin.Client, log hclog.Logger, opts ...SupervisorOption) *Supervisor {
+	if log == nil {
+		log = hclog.NewNullLogger()
+	}
+	s := &Supervisor{
+		newClient:  newClient,
+		log:        log,
+		interval:   defaultHealthCheckInterval,
+		timeout:    defaultSupervisorTimeout,
+		minBackoff: defaultRestartMinBackoff,
+		maxBackoff: defaultRestartMaxBackoff,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.interval <= 0 {
+		s.interval = defaultHealthCheckInterval
+	}
+	if s.timeout <= 0 {
+		s.timeout = defaultSupervisorTimeout
+	}
+	if s.maxBackoff <= 0 {
+		s.maxBackoff = defaultRestartMaxBackoff
+	}
+	s.minBackoff = min(s.minBackoff, s.maxBackoff)
+	return s
+}
+
+// Init will launch the plugin process and invoke its Init function.
+func (s *Supervisor) Init(ctx context.Context) error {
+	return s.launch(ctx)
+}
+
+// GrantAccess will invoke the GrantAccess function of the running plugin.
+func (s *Supervisor) GrantAccess(ctx context.Context, ar *api.AccessRequest, app *argocd.Application) (*GrantResponse, error) {
+	accessRequester, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	return accessRequester.GrantAccess(ctx, ar, app)
+}
+
+// RevokeAccess will invoke the RevokeAccess function of the running plugin.
+func (s *Supervisor) RevokeAccess(ctx context.Context, ar *api.AccessRequest, app *argocd.Application) (*RevokeResponse, error) {
+	accessRequester, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	return accessRequester.RevokeAccess(ctx, ar, app)
+}
+
+// Start will check the plugin health periodically until the given context is
+// done, relaunching the plugin process when it isn't healthy. The plugin
+// process is killed once the context is done. It implements the
+// controller-runtime manager.Runnable interface.
+func (s *Supervisor) Start(ctx context.Context) error {
+	defer s.kill()
+	wait := s.interval
+	backoff := s.minBackoff
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		}
+		err := s.ping(ctx)
+		if err == nil {
+			s.setHealthy(true)
+			wait = s.interval
+			backoff = s.minBackoff
+			continue
+		}
+		s.setHealthy(false)
+		s.log.Warn("AccessRequester plugin is not healthy: relaunching", "error", err)
+		initCtx, cancel := context.WithTimeout(ctx, s.timeout)
+		err = s.launch(initCtx)
+		cancel()
+		if err != nil {
+			s.log.Error("error relaunching AccessRequester plugin", "error", err, "backoff", backoff)
+			wait = backoff
+			backoff = min(backoff*2, s.maxBackoff)
+			continue
+		}
+		s.log.Info("AccessRequester plugin relaunched successfully")
+		wait = s.interval
+		backoff = s.minBackoff
+	}
+}
+
+// NeedLeaderElection returns false so the plugin is supervised in all the
+// controller replicas. It implements the controller-runtime
+// manager.LeaderElectionRunnable interface.
+func (s *Supervisor) NeedLeaderElection() bool {
+	return false
+}
+
+// Healthy returns true if the plugin process responded to the last health
+// check.
+func (s *Supervisor) Healthy() bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.healthy
+}
+
+// Check returns an error if the plugin isn't healthy. It implements the
+// controller-runtime healthz.Checker function so it can be used in the
+// readiness probe.
+func (s *Supervisor) Check(_ *http.Request) error {
+	if !s.Healthy() {
+		return errors.New("AccessRequester plugin is not healthy")
+	}
+	return nil
+}
+
+// launch will start a new plugin process and invoke its Init function. The
+// previous process is killed once the new one is initialized.
+func (s *Supervisor) launch(ctx context.Context) error {
+	client := s.newClient()
+	accessRequester, err := GetAccessRequesterV2(client)
+	if err != nil {
+		client.Kill()
+		return fmt.Errorf("error getting AccessRequester plugin: %w", err)
+	}
+	err = accessRequester.Init(ctx)
+	if err != nil {
+		client.Kill()
+		return fmt.Errorf("error invoking plugin Init function: %w", err)
+	}
+
+	s.lock.Lock()
+	previous := s.client
+	s.client = client
+	s.accessRequester = accessRequester
+	s.lock.Unlock()
+
+	if previous != nil {
+		previous.Kill()
+	}
+	s.setHealthy(true)
+	return nil
+}
+
+// ping will check if the plugin process is running and responding.
+func (s *Supervisor) ping(ctx context.Context) error {
+	s.lock.RLock()
+	client := s.client
+	s.lock.RUnlock()
+	if client == nil {
+		return errors.New("plugin process not launched")
+	}
+	if client.Exited() {
+		return errors.New("plugin process exited")
+	}
+	protocol, err := client.Client()
+	if err != nil {
+		return fmt.Errorf("error retrieving plugin client: %w", err)
+	}
+	pingCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	_, err = callWithContext(pingCtx, func() (any, error) {
+		return nil, protocol.Ping()
+	})
+	if err != nil {
+		return fmt.Errorf("error pinging plugin: %w", err)
+	}
+	return nil
+}
+
+// current returns the AccessRequester of the running plugin process.
+func (s *Supervisor) current() (AccessRequesterV2, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.accessRequester == nil || !s.healthy {
+		return nil, ErrPluginUnavailable
+	}
+	return s.accessRequester, nil
+}
+
+func (s *Supervisor) setHealthy(healthy bool) {
+	s.lock.Lock()
+	changed := s.healthy != healthy
+	s.healthy = healthy
+	s.lock.Unlock()
+	if changed && s.healthHook != nil {
+		s.healthHook(healthy)
+	}
+}
+
+func (s *Supervisor) kill() {
+	s.lock.Lock()
+	client := s.client
+	s.client = nil
+	s.accessRequester = nil
+	s.lock.Unlock()
+	if client != nil {
+		client.Kill()
+	}
+	s.setHealthy(false)
+}
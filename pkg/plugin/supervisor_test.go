@@ -0,0 +1,218 @@
+package plugin_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	argocd "github.com/argoproj-labs/argocd-ephemeral-access/api/argoproj/v1alpha1"
+	api "github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
+	"github.com/argoproj-labs/argocd-ephemeral-access/pkg/plugin"
+	"github.com/argoproj-labs/argocd-ephemeral-access/test/mocks"
+	goPlugin "github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// supervisorFixture serves a new plugin server every time the supervisor
+// launches the plugin. The gRPC protocol is used as only the gRPC server
+// closes the existing connections when stopped, simulating a crash.
+type supervisorFixture struct {
+	accessRequesterMock *mocks.MockAccessRequesterV2
+	lock                sync.Mutex
+	launches            int
+	cancels             []context.CancelFunc
+	health              []bool
+}
+
+func newSupervisorFixture(t *testing.T) *supervisorFixture {
+	t.Setenv("PLUGIN_PROTOCOL_VERSIONS", "1,2")
+	f := &supervisorFixture{
+		accessRequesterMock: mocks.NewMockAccessRequesterV2(t),
+	}
+	t.Cleanup(func() {
+		for _, cancel := range f.cancels {
+			cancel()
+		}
+	})
+	return f
+}
+
+func (f *supervisorFixture) newSupervisor(t *testing.T, opts ...plugin.SupervisorOption) *plugin.Supervisor {
+	newClient := func() *goPlugin.Client {
+		client, cancel := serveClient(t, plugin.NewServerConfigV2(f.accessRequesterMock, nil))
+		f.lock.Lock()
+		defer f.lock.Unlock()
+		f.launches++
+		f.cancels = append(f.cancels, cancel)
+		return client
+	}
+	healthHook := func(healthy bool) {
+		f.lock.Lock()
+		defer f.lock.Unlock()
+		f.health = append(f.health, healthy)
+	}
+	opts = append(opts, plugin.WithHealthHook(healthHook))
+	return plugin.NewSupervisor(newClient, nil, opts...)
+}
+
+// crash will stop the plugin server started by the last launch.
+func (f *supervisorFixture) crash() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.cancels[len(f.cancels)-1]()
+}
+
+func (f *supervisorFixture) getLaunches() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.launches
+}
+
+func (f *supervisorFixture) getHealth() []bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return append([]bool{}, f.health...)
+}
+
+func TestSupervisor(t *testing.T) {
+	t.Run("will launch the plugin on Init", func(t *testing.T) {
+		// Given
+		f := newSupervisorFixture(t)
+		f.accessRequesterMock.EXPECT().Init(mock.Anything).Return(nil).Once()
+		f.accessRequesterMock.EXPECT().GrantAccess(mock.Anything, mock.Anything, mock.Anything).
+			Return(&plugin.GrantResponse{Status: plugin.GrantStatusGranted}, nil)
+		s := f.newSupervisor(t)
+
+		// When
+		err := s.Init(context.Background())
+
+		// Then
+		require.NoError(t, err)
+		assert.True(t, s.Healthy())
+		assert.NoError(t, s.Check(nil))
+		assert.Equal(t, []bool{true}, f.getHealth())
+		resp, err := s.GrantAccess(context.Background(), &api.AccessRequest{}, &argocd.Application{})
+		require.NoError(t, err)
+		assert.Equal(t, plugin.GrantStatusGranted, resp.Status)
+	})
+	t.Run("will return error if the plugin Init fails", func(t *testing.T) {
+		// Given
+		f := newSupervisorFixture(t)
+		f.accessRequesterMock.EXPECT().Init(mock.Anything).Return(errors.New("some init error")).Once()
+		s := f.newSupervisor(t)
+
+		// When
+		err := s.Init(context.Background())
+
+		// Then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "some init error")
+		assert.False(t, s.Healthy())
+		assert.Error(t, s.Check(nil))
+	})
+	t.Run("will return ErrPluginUnavailable if the plugin is not launched", func(t *testing.T) {
+		// Given
+		f := newSupervisorFixture(t)
+		s := f.newSupervisor(t)
+
+		// When
+		resp, err := s.RevokeAccess(context.Background(), &api.AccessRequest{}, &argocd.Application{})
+
+		// Then
+		assert.ErrorIs(t, err, plugin.ErrPluginUnavailable)
+		assert.Nil(t, resp)
+	})
+	t.Run("will relaunch the plugin when it stops responding", func(t *testing.T) {
+		// Given
+		f := newSupervisorFixture(t)
+		f.accessRequesterMock.EXPECT().Init(mock.Anything).Return(nil).Twice()
+		s := f.newSupervisor(t, plugin.WithHealthCheckInterval(20*time.Millisecond))
+		require.NoError(t, s.Init(context.Background()))
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go s.Start(ctx)
+
+		// When
+		f.crash()
+
+		// Then
+		require.Eventually(t, func() bool {
+			return f.getLaunches() == 2 && s.Healthy()
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, []bool{true, false, true}, f.getHealth())
+	})
+	t.Run("will retry with backoff when the relaunch fails", func(t *testing.T) {
+		// Given
+		f := newSupervisorFixture(t)
+		f.accessRequesterMock.EXPECT().Init(mock.Anything).Return(nil).Once()
+		f.accessRequesterMock.EXPECT().Init(mock.Anything).Return(errors.New("some init error")).Twice()
+		f.accessRequesterMock.EXPECT().Init(mock.Anything).Return(nil).Once()
+		s := f.newSupervisor(t,
+			plugin.WithHealthCheckInterval(20*time.Millisecond),
+			plugin.WithRestartMaxBackoff(40*time.Millisecond))
+		require.NoError(t, s.Init(context.Background()))
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go s.Start(ctx)
+
+		// When
+		f.crash()
+
+		// Then
+		require.Eventually(t, func() bool {
+			return f.getLaunches() == 4 && s.Healthy()
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, []bool{true, false, true}, f.getHealth())
+	})
+	t.Run("will use the default interval and timeout if not positive", func(t *testing.T) {
+		// Given
+		f := newSupervisorFixture(t)
+		f.accessRequesterMock.EXPECT().Init(mock.Anything).Return(nil).Once()
+		s := f.newSupervisor(t,
+			plugin.WithHealthCheckInterval(0),
+			plugin.WithSupervisorTimeout(0))
+		require.NoError(t, s.Init(context.Background()))
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go s.Start(ctx)
+
+		// When
+		f.crash()
+
+		// Then
+		assert.Never(t, func() bool {
+			return f.getLaunches() > 1
+		}, 200*time.Millisecond, 10*time.Millisecond)
+		assert.Equal(t, []bool{true}, f.getHealth())
+	})
+	t.Run("will stop the plugin when the context is done", func(t *testing.T) {
+		// Given
+		f := newSupervisorFixture(t)
+		f.accessRequesterMock.EXPECT().Init(mock.Anything).Return(nil).Once()
+		s := f.newSupervisor(t, plugin.WithHealthCheckInterval(20*time.Millisecond))
+		require.NoError(t, s.Init(context.Background()))
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			done <- s.Start(ctx)
+		}()
+
+		// When
+		cancel()
+
+		// Then
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(2 * time.Second):
+			t.Fatal("supervisor did not stop")
+		}
+		assert.False(t, s.Healthy())
+		_, err := s.GrantAccess(context.Background(), &api.AccessRequest{}, &argocd.Application{})
+		assert.ErrorIs(t, err, plugin.ErrPluginUnavailable)
+	})
+}
@@ -37,6 +37,182 @@ func (_m *MockConfigurer) EXPECT() *MockConfigurer_Expecter {
 	return &MockConfigurer_Expecter{mock: &_m.Mock}
 }
 
+// ArchiveNamespace provides a mock function for the type MockConfigurer
+func (_mock *MockConfigurer) ArchiveNamespace() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArchiveNamespace")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockConfigurer_ArchiveNamespace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArchiveNamespace'
+type MockConfigurer_ArchiveNamespace_Call struct {
+	*mock.Call
+}
+
+// ArchiveNamespace is a helper method to define mock.On call
+func (_e *MockConfigurer_Expecter) ArchiveNamespace() *MockConfigurer_ArchiveNamespace_Call {
+	return &MockConfigurer_ArchiveNamespace_Call{Call: _e.mock.On("ArchiveNamespace")}
+}
+
+func (_c *MockConfigurer_ArchiveNamespace_Call) Run(run func()) *MockConfigurer_ArchiveNamespace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockConfigurer_ArchiveNamespace_Call) Return(s string) *MockConfigurer_ArchiveNamespace_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockConfigurer_ArchiveNamespace_Call) RunAndReturn(run func() string) *MockConfigurer_ArchiveNamespace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ArchivePath provides a mock function for the type MockConfigurer
+func (_mock *MockConfigurer) ArchivePath() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArchivePath")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockConfigurer_ArchivePath_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArchivePath'
+type MockConfigurer_ArchivePath_Call struct {
+	*mock.Call
+}
+
+// ArchivePath is a helper method to define mock.On call
+func (_e *MockConfigurer_Expecter) ArchivePath() *MockConfigurer_ArchivePath_Call {
+	return &MockConfigurer_ArchivePath_Call{Call: _e.mock.On("ArchivePath")}
+}
+
+func (_c *MockConfigurer_ArchivePath_Call) Run(run func()) *MockConfigurer_ArchivePath_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockConfigurer_ArchivePath_Call) Return(s string) *MockConfigurer_ArchivePath_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockConfigurer_ArchivePath_Call) RunAndReturn(run func() string) *MockConfigurer_ArchivePath_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ArchiveType provides a mock function for the type MockConfigurer
+func (_mock *MockConfigurer) ArchiveType() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArchiveType")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockConfigurer_ArchiveType_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArchiveType'
+type MockConfigurer_ArchiveType_Call struct {
+	*mock.Call
+}
+
+// ArchiveType is a helper method to define mock.On call
+func (_e *MockConfigurer_Expecter) ArchiveType() *MockConfigurer_ArchiveType_Call {
+	return &MockConfigurer_ArchiveType_Call{Call: _e.mock.On("ArchiveType")}
+}
+
+func (_c *MockConfigurer_ArchiveType_Call) Run(run func()) *MockConfigurer_ArchiveType_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockConfigurer_ArchiveType_Call) Return(s string) *MockConfigurer_ArchiveType_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockConfigurer_ArchiveType_Call) RunAndReturn(run func() string) *MockConfigurer_ArchiveType_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AuditLogPath provides a mock function for the type MockConfigurer
+func (_mock *MockConfigurer) AuditLogPath() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuditLogPath")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockConfigurer_AuditLogPath_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuditLogPath'
+type MockConfigurer_AuditLogPath_Call struct {
+	*mock.Call
+}
+
+// AuditLogPath is a helper method to define mock.On call
+func (_e *MockConfigurer_Expecter) AuditLogPath() *MockConfigurer_AuditLogPath_Call {
+	return &MockConfigurer_AuditLogPath_Call{Call: _e.mock.On("AuditLogPath")}
+}
+
+func (_c *MockConfigurer_AuditLogPath_Call) Run(run func()) *MockConfigurer_AuditLogPath_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockConfigurer_AuditLogPath_Call) Return(s string) *MockConfigurer_AuditLogPath_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockConfigurer_AuditLogPath_Call) RunAndReturn(run func() string) *MockConfigurer_AuditLogPath_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ControllerAccessRequestTTL provides a mock function for the type MockConfigurer
 func (_mock *MockConfigurer) ControllerAccessRequestTTL() time.Duration {
 	ret := _mock.Called()
@@ -521,46 +697,886 @@ func (_c *MockConfigurer_MetricsSecure_Call) RunAndReturn(run func() bool) *Mock
 	return _c
 }
 
-// PluginPath provides a mock function for the type MockConfigurer
-func (_mock *MockConfigurer) PluginPath() string {
+// NotificationArgoCDAnnotations provides a mock function for the type MockConfigurer
+func (_mock *MockConfigurer) NotificationArgoCDAnnotations() bool {
 	ret := _mock.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for PluginPath")
+		panic("no return value specified for NotificationArgoCDAnnotations")
 	}
 
-	var r0 string
-	if returnFunc, ok := ret.Get(0).(func() string); ok {
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func() bool); ok {
 		r0 = returnFunc()
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(bool)
 	}
 	return r0
 }
 
-// MockConfigurer_PluginPath_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PluginPath'
-type MockConfigurer_PluginPath_Call struct {
+// MockConfigurer_NotificationArgoCDAnnotations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NotificationArgoCDAnnotations'
+type MockConfigurer_NotificationArgoCDAnnotations_Call struct {
 	*mock.Call
 }
 
-// PluginPath is a helper method to define mock.On call
-func (_e *MockConfigurer_Expecter) PluginPath() *MockConfigurer_PluginPath_Call {
-	return &MockConfigurer_PluginPath_Call{Call: _e.mock.On("PluginPath")}
+// NotificationArgoCDAnnotations is a helper method to define mock.On call
+func (_e *MockConfigurer_Expecter) NotificationArgoCDAnnotations() *MockConfigurer_NotificationArgoCDAnnotations_Call {
+	return &MockConfigurer_NotificationArgoCDAnnotations_Call{Call: _e.mock.On("NotificationArgoCDAnnotations")}
 }
 
-func (_c *MockConfigurer_PluginPath_Call) Run(run func()) *MockConfigurer_PluginPath_Call {
+func (_c *MockConfigurer_NotificationArgoCDAnnotations_Call) Run(run func()) *MockConfigurer_NotificationArgoCDAnnotations_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run()
 	})
 	return _c
 }
 
-func (_c *MockConfigurer_PluginPath_Call) Return(s string) *MockConfigurer_PluginPath_Call {
-	_c.Call.Return(s)
+func (_c *MockConfigurer_NotificationArgoCDAnnotations_Call) Return(b bool) *MockConfigurer_NotificationArgoCDAnnotations_Call {
+	_c.Call.Return(b)
 	return _c
 }
 
-func (_c *MockConfigurer_PluginPath_Call) RunAndReturn(run func() string) *MockConfigurer_PluginPath_Call {
+func (_c *MockConfigurer_NotificationArgoCDAnnotations_Call) RunAndReturn(run func() bool) *MockConfigurer_NotificationArgoCDAnnotations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NotificationEvents provides a mock function for the type MockConfigurer
+func (_mock *MockConfigurer) NotificationEvents() []string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for NotificationEvents")
+	}
+
+	var r0 []string
+	if returnFunc, ok := ret.Get(0).(func() []string); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	return r0
+}
+
+// MockConfigurer_NotificationEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NotificationEvents'
+type MockConfigurer_NotificationEvents_Call struct {
+	*mock.Call
+}
+
+// NotificationEvents is a helper method to define mock.On call
+func (_e *MockConfigurer_Expecter) NotificationEvents() *MockConfigurer_NotificationEvents_Call {
+	return &MockConfigurer_NotificationEvents_Call{Call: _e.mock.On("NotificationEvents")}
+}
+
+func (_c *MockConfigurer_NotificationEvents_Call) Run(run func()) *MockConfigurer_NotificationEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockConfigurer_NotificationEvents_Call) Return(strings []string) *MockConfigurer_NotificationEvents_Call {
+	_c.Call.Return(strings)
+	return _c
+}
+
+func (_c *MockConfigurer_NotificationEvents_Call) RunAndReturn(run func() []string) *MockConfigurer_NotificationEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NotificationExpiryWarning provides a mock function for the type MockConfigurer
+func (_mock *MockConfigurer) NotificationExpiryWarning() time.Duration {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for NotificationExpiryWarning")
+	}
+
+	var r0 time.Duration
+	if returnFunc, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+	return r0
+}
+
+// MockConfigurer_NotificationExpiryWarning_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NotificationExpiryWarning'
+type MockConfigurer_NotificationExpiryWarning_Call struct {
+	*mock.Call
+}
+
+// NotificationExpiryWarning is a helper method to define mock.On call
+func (_e *MockConfigurer_Expecter) NotificationExpiryWarning() *MockConfigurer_NotificationExpiryWarning_Call {
+	return &MockConfigurer_NotificationExpiryWarning_Call{Call: _e.mock.On("NotificationExpiryWarning")}
+}
+
+func (_c *MockConfigurer_NotificationExpiryWarning_Call) Run(run func()) *MockConfigurer_NotificationExpiryWarning_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockConfigurer_NotificationExpiryWarning_Call) Return(duration time.Duration) *MockConfigurer_NotificationExpiryWarning_Call {
+	_c.Call.Return(duration)
+	return _c
+}
+
+func (_c *MockConfigurer_NotificationExpiryWarning_Call) RunAndReturn(run func() time.Duration) *MockConfigurer_NotificationExpiryWarning_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NotificationMaxRetries provides a mock function for the type MockConfigurer
+func (_mock *MockConfigurer) NotificationMaxRetries() int {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for NotificationMaxRetries")
+	}
+
+	var r0 int
+	if returnFunc, ok := ret.Get(0).(func() int); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	return r0
+}
+
+// MockConfigurer_NotificationMaxRetries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NotificationMaxRetries'
+type MockConfigurer_NotificationMaxRetries_Call struct {
+	*mock.Call
+}
+
+// NotificationMaxRetries is a helper method to define mock.On call
+func (_e *MockConfigurer_Expecter) NotificationMaxRetries() *MockConfigurer_NotificationMaxRetries_Call {
+	return &MockConfigurer_NotificationMaxRetries_Call{Call: _e.mock.On("NotificationMaxRetries")}
+}
+
+func (_c *MockConfigurer_NotificationMaxRetries_Call) Run(run func()) *MockConfigurer_NotificationMaxRetries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockConfigurer_NotificationMaxRetries_Call) Return(n int) *MockConfigurer_NotificationMaxRetries_Call {
+	_c.Call.Return(n)
+	return _c
+}
+
+func (_c *MockConfigurer_NotificationMaxRetries_Call) RunAndReturn(run func() int) *MockConfigurer_NotificationMaxRetries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NotificationSecretName provides a mock function for the type MockConfigurer
+func (_mock *MockConfigurer) NotificationSecretName() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for NotificationSecretName")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockConfigurer_NotificationSecretName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NotificationSecretName'
+type MockConfigurer_NotificationSecretName_Call struct {
+	*mock.Call
+}
+
+// NotificationSecretName is a helper method to define mock.On call
+func (_e *MockConfigurer_Expecter) NotificationSecretName() *MockConfigurer_NotificationSecretName_Call {
+	return &MockConfigurer_NotificationSecretName_Call{Call: _e.mock.On("NotificationSecretName")}
+}
+
+func (_c *MockConfigurer_NotificationSecretName_Call) Run(run func()) *MockConfigurer_NotificationSecretName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockConfigurer_NotificationSecretName_Call) Return(s string) *MockConfigurer_NotificationSecretName_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockConfigurer_NotificationSecretName_Call) RunAndReturn(run func() string) *MockConfigurer_NotificationSecretName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NotificationSecretNamespace provides a mock function for the type MockConfigurer
+func (_mock *MockConfigurer) NotificationSecretNamespace() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for NotificationSecretNamespace")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockConfigurer_NotificationSecretNamespace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NotificationSecretNamespace'
+type MockConfigurer_NotificationSecretNamespace_Call struct {
+	*mock.Call
+}
+
+// NotificationSecretNamespace is a helper method to define mock.On call
+func (_e *MockConfigurer_Expecter) NotificationSecretNamespace() *MockConfigurer_NotificationSecretNamespace_Call {
+	return &MockConfigurer_NotificationSecretNamespace_Call{Call: _e.mock.On("NotificationSecretNamespace")}
+}
+
+func (_c *MockConfigurer_NotificationSecretNamespace_Call) Run(run func()) *MockConfigurer_NotificationSecretNamespace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockConfigurer_NotificationSecretNamespace_Call) Return(s string) *MockConfigurer_NotificationSecretNamespace_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockConfigurer_NotificationSecretNamespace_Call) RunAndReturn(run func() string) *MockConfigurer_NotificationSecretNamespace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NotificationWebhookSecret provides a mock function for the type MockConfigurer
+func (_mock *MockConfigurer) NotificationWebhookSecret() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for NotificationWebhookSecret")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockConfigurer_NotificationWebhookSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NotificationWebhookSecret'
+type MockConfigurer_NotificationWebhookSecret_Call struct {
+	*mock.Call
+}
+
+// NotificationWebhookSecret is a helper method to define mock.On call
+func (_e *MockConfigurer_Expecter) NotificationWebhookSecret() *MockConfigurer_NotificationWebhookSecret_Call {
+	return &MockConfigurer_NotificationWebhookSecret_Call{Call: _e.mock.On("NotificationWebhookSecret")}
+}
+
+func (_c *MockConfigurer_NotificationWebhookSecret_Call) Run(run func()) *MockConfigurer_NotificationWebhookSecret_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockConfigurer_NotificationWebhookSecret_Call) Return(s string) *MockConfigurer_NotificationWebhookSecret_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockConfigurer_NotificationWebhookSecret_Call) RunAndReturn(run func() string) *MockConfigurer_NotificationWebhookSecret_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NotificationWebhookTemplate provides a mock function for the type MockConfigurer
+func (_mock *MockConfigurer) NotificationWebhookTemplate() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for NotificationWebhookTemplate")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockConfigurer_NotificationWebhookTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NotificationWebhookTemplate'
+type MockConfigurer_NotificationWebhookTemplate_Call struct {
+	*mock.Call
+}
+
+// NotificationWebhookTemplate is a helper method to define mock.On call
+func (_e *MockConfigurer_Expecter) NotificationWebhookTemplate() *MockConfigurer_NotificationWebhookTemplate_Call {
+	return &MockConfigurer_NotificationWebhookTemplate_Call{Call: _e.mock.On("NotificationWebhookTemplate")}
+}
+
+func (_c *MockConfigurer_NotificationWebhookTemplate_Call) Run(run func()) *MockConfigurer_NotificationWebhookTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockConfigurer_NotificationWebhookTemplate_Call) Return(s string) *MockConfigurer_NotificationWebhookTemplate_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockConfigurer_NotificationWebhookTemplate_Call) RunAndReturn(run func() string) *MockConfigurer_NotificationWebhookTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NotificationWebhookURL provides a mock function for the type MockConfigurer
+func (_mock *MockConfigurer) NotificationWebhookURL() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for NotificationWebhookURL")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockConfigurer_NotificationWebhookURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NotificationWebhookURL'
+type MockConfigurer_NotificationWebhookURL_Call struct {
+	*mock.Call
+}
+
+// NotificationWebhookURL is a helper method to define mock.On call
+func (_e *MockConfigurer_Expecter) NotificationWebhookURL() *MockConfigurer_NotificationWebhookURL_Call {
+	return &MockConfigurer_NotificationWebhookURL_Call{Call: _e.mock.On("NotificationWebhookURL")}
+}
+
+func (_c *MockConfigurer_NotificationWebhookURL_Call) Run(run func()) *MockConfigurer_NotificationWebhookURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockConfigurer_NotificationWebhookURL_Call) Return(s string) *MockConfigurer_NotificationWebhookURL_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockConfigurer_NotificationWebhookURL_Call) RunAndReturn(run func() string) *MockConfigurer_NotificationWebhookURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PluginChain provides a mock function for the type MockConfigurer
+func (_mock *MockConfigurer) PluginChain() []string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PluginChain")
+	}
+
+	var r0 []string
+	if returnFunc, ok := ret.Get(0).(func() []string); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	return r0
+}
+
+// MockConfigurer_PluginChain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PluginChain'
+type MockConfigurer_PluginChain_Call struct {
+	*mock.Call
+}
+
+// PluginChain is a helper method to define mock.On call
+func (_e *MockConfigurer_Expecter) PluginChain() *MockConfigurer_PluginChain_Call {
+	return &MockConfigurer_PluginChain_Call{Call: _e.mock.On("PluginChain")}
+}
+
+func (_c *MockConfigurer_PluginChain_Call) Run(run func()) *MockConfigurer_PluginChain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockConfigurer_PluginChain_Call) Return(strings []string) *MockConfigurer_PluginChain_Call {
+	_c.Call.Return(strings)
+	return _c
+}
+
+func (_c *MockConfigurer_PluginChain_Call) RunAndReturn(run func() []string) *MockConfigurer_PluginChain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PluginHealthCheckInterval provides a mock function for the type MockConfigurer
+func (_mock *MockConfigurer) PluginHealthCheckInterval() time.Duration {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PluginHealthCheckInterval")
+	}
+
+	var r0 time.Duration
+	if returnFunc, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+	return r0
+}
+
+// MockConfigurer_PluginHealthCheckInterval_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PluginHealthCheckInterval'
+type MockConfigurer_PluginHealthCheckInterval_Call struct {
+	*mock.Call
+}
+
+// PluginHealthCheckInterval is a helper method to define mock.On call
+func (_e *MockConfigurer_Expecter) PluginHealthCheckInterval() *MockConfigurer_PluginHealthCheckInterval_Call {
+	return &MockConfigurer_PluginHealthCheckInterval_Call{Call: _e.mock.On("PluginHealthCheckInterval")}
+}
+
+func (_c *MockConfigurer_PluginHealthCheckInterval_Call) Run(run func()) *MockConfigurer_PluginHealthCheckInterval_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockConfigurer_PluginHealthCheckInterval_Call) Return(duration time.Duration) *MockConfigurer_PluginHealthCheckInterval_Call {
+	_c.Call.Return(duration)
+	return _c
+}
+
+func (_c *MockConfigurer_PluginHealthCheckInterval_Call) RunAndReturn(run func() time.Duration) *MockConfigurer_PluginHealthCheckInterval_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PluginMaxRetries provides a mock function for the type MockConfigurer
+func (_mock *MockConfigurer) PluginMaxRetries() int {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PluginMaxRetries")
+	}
+
+	var r0 int
+	if returnFunc, ok := ret.Get(0).(func() int); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	return r0
+}
+
+// MockConfigurer_PluginMaxRetries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PluginMaxRetries'
+type MockConfigurer_PluginMaxRetries_Call struct {
+	*mock.Call
+}
+
+// PluginMaxRetries is a helper method to define mock.On call
+func (_e *MockConfigurer_Expecter) PluginMaxRetries() *MockConfigurer_PluginMaxRetries_Call {
+	return &MockConfigurer_PluginMaxRetries_Call{Call: _e.mock.On("PluginMaxRetries")}
+}
+
+func (_c *MockConfigurer_PluginMaxRetries_Call) Run(run func()) *MockConfigurer_PluginMaxRetries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockConfigurer_PluginMaxRetries_Call) Return(n int) *MockConfigurer_PluginMaxRetries_Call {
+	_c.Call.Return(n)
+	return _c
+}
+
+func (_c *MockConfigurer_PluginMaxRetries_Call) RunAndReturn(run func() int) *MockConfigurer_PluginMaxRetries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PluginPath provides a mock function for the type MockConfigurer
+func (_mock *MockConfigurer) PluginPath() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PluginPath")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockConfigurer_PluginPath_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PluginPath'
+type MockConfigurer_PluginPath_Call struct {
+	*mock.Call
+}
+
+// PluginPath is a helper method to define mock.On call
+func (_e *MockConfigurer_Expecter) PluginPath() *MockConfigurer_PluginPath_Call {
+	return &MockConfigurer_PluginPath_Call{Call: _e.mock.On("PluginPath")}
+}
+
+func (_c *MockConfigurer_PluginPath_Call) Run(run func()) *MockConfigurer_PluginPath_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockConfigurer_PluginPath_Call) Return(s string) *MockConfigurer_PluginPath_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockConfigurer_PluginPath_Call) RunAndReturn(run func() string) *MockConfigurer_PluginPath_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PluginRestartMaxBackoff provides a mock function for the type MockConfigurer
+func (_mock *MockConfigurer) PluginRestartMaxBackoff() time.Duration {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PluginRestartMaxBackoff")
+	}
+
+	var r0 time.Duration
+	if returnFunc, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+	return r0
+}
+
+// MockConfigurer_PluginRestartMaxBackoff_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PluginRestartMaxBackoff'
+type MockConfigurer_PluginRestartMaxBackoff_Call struct {
+	*mock.Call
+}
+
+// PluginRestartMaxBackoff is a helper method to define mock.On call
+func (_e *MockConfigurer_Expecter) PluginRestartMaxBackoff() *MockConfigurer_PluginRestartMaxBackoff_Call {
+	return &MockConfigurer_PluginRestartMaxBackoff_Call{Call: _e.mock.On("PluginRestartMaxBackoff")}
+}
+
+func (_c *MockConfigurer_PluginRestartMaxBackoff_Call) Run(run func()) *MockConfigurer_PluginRestartMaxBackoff_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockConfigurer_PluginRestartMaxBackoff_Call) Return(duration time.Duration) *MockConfigurer_PluginRestartMaxBackoff_Call {
+	_c.Call.Return(duration)
+	return _c
+}
+
+func (_c *MockConfigurer_PluginRestartMaxBackoff_Call) RunAndReturn(run func() time.Duration) *MockConfigurer_PluginRestartMaxBackoff_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PluginTLSCAPath provides a mock function for the type MockConfigurer
+func (_mock *MockConfigurer) PluginTLSCAPath() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PluginTLSCAPath")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockConfigurer_PluginTLSCAPath_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PluginTLSCAPath'
+type MockConfigurer_PluginTLSCAPath_Call struct {
+	*mock.Call
+}
+
+// PluginTLSCAPath is a helper method to define mock.On call
+func (_e *MockConfigurer_Expecter) PluginTLSCAPath() *MockConfigurer_PluginTLSCAPath_Call {
+	return &MockConfigurer_PluginTLSCAPath_Call{Call: _e.mock.On("PluginTLSCAPath")}
+}
+
+func (_c *MockConfigurer_PluginTLSCAPath_Call) Run(run func()) *MockConfigurer_PluginTLSCAPath_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockConfigurer_PluginTLSCAPath_Call) Return(s string) *MockConfigurer_PluginTLSCAPath_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockConfigurer_PluginTLSCAPath_Call) RunAndReturn(run func() string) *MockConfigurer_PluginTLSCAPath_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PluginTLSCertPath provides a mock function for the type MockConfigurer
+func (_mock *MockConfigurer) PluginTLSCertPath() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PluginTLSCertPath")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockConfigurer_PluginTLSCertPath_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PluginTLSCertPath'
+type MockConfigurer_PluginTLSCertPath_Call struct {
+	*mock.Call
+}
+
+// PluginTLSCertPath is a helper method to define mock.On call
+func (_e *MockConfigurer_Expecter) PluginTLSCertPath() *MockConfigurer_PluginTLSCertPath_Call {
+	return &MockConfigurer_PluginTLSCertPath_Call{Call: _e.mock.On("PluginTLSCertPath")}
+}
+
+func (_c *MockConfigurer_PluginTLSCertPath_Call) Run(run func()) *MockConfigurer_PluginTLSCertPath_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockConfigurer_PluginTLSCertPath_Call) Return(s string) *MockConfigurer_PluginTLSCertPath_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockConfigurer_PluginTLSCertPath_Call) RunAndReturn(run func() string) *MockConfigurer_PluginTLSCertPath_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PluginTLSKeyPath provides a mock function for the type MockConfigurer
+func (_mock *MockConfigurer) PluginTLSKeyPath() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PluginTLSKeyPath")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockConfigurer_PluginTLSKeyPath_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PluginTLSKeyPath'
+type MockConfigurer_PluginTLSKeyPath_Call struct {
+	*mock.Call
+}
+
+// PluginTLSKeyPath is a helper method to define mock.On call
+func (_e *MockConfigurer_Expecter) PluginTLSKeyPath() *MockConfigurer_PluginTLSKeyPath_Call {
+	return &MockConfigurer_PluginTLSKeyPath_Call{Call: _e.mock.On("PluginTLSKeyPath")}
+}
+
+func (_c *MockConfigurer_PluginTLSKeyPath_Call) Run(run func()) *MockConfigurer_PluginTLSKeyPath_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockConfigurer_PluginTLSKeyPath_Call) Return(s string) *MockConfigurer_PluginTLSKeyPath_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockConfigurer_PluginTLSKeyPath_Call) RunAndReturn(run func() string) *MockConfigurer_PluginTLSKeyPath_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PluginTimeout provides a mock function for the type MockConfigurer
+func (_mock *MockConfigurer) PluginTimeout() time.Duration {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PluginTimeout")
+	}
+
+	var r0 time.Duration
+	if returnFunc, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+	return r0
+}
+
+// MockConfigurer_PluginTimeout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PluginTimeout'
+type MockConfigurer_PluginTimeout_Call struct {
+	*mock.Call
+}
+
+// PluginTimeout is a helper method to define mock.On call
+func (_e *MockConfigurer_Expecter) PluginTimeout() *MockConfigurer_PluginTimeout_Call {
+	return &MockConfigurer_PluginTimeout_Call{Call: _e.mock.On("PluginTimeout")}
+}
+
+func (_c *MockConfigurer_PluginTimeout_Call) Run(run func()) *MockConfigurer_PluginTimeout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockConfigurer_PluginTimeout_Call) Return(duration time.Duration) *MockConfigurer_PluginTimeout_Call {
+	_c.Call.Return(duration)
+	return _c
+}
+
+func (_c *MockConfigurer_PluginTimeout_Call) RunAndReturn(run func() time.Duration) *MockConfigurer_PluginTimeout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PluginToken provides a mock function for the type MockConfigurer
+func (_mock *MockConfigurer) PluginToken() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PluginToken")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockConfigurer_PluginToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PluginToken'
+type MockConfigurer_PluginToken_Call struct {
+	*mock.Call
+}
+
+// PluginToken is a helper method to define mock.On call
+func (_e *MockConfigurer_Expecter) PluginToken() *MockConfigurer_PluginToken_Call {
+	return &MockConfigurer_PluginToken_Call{Call: _e.mock.On("PluginToken")}
+}
+
+func (_c *MockConfigurer_PluginToken_Call) Run(run func()) *MockConfigurer_PluginToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockConfigurer_PluginToken_Call) Return(s string) *MockConfigurer_PluginToken_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockConfigurer_PluginToken_Call) RunAndReturn(run func() string) *MockConfigurer_PluginToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PluginURL provides a mock function for the type MockConfigurer
+func (_mock *MockConfigurer) PluginURL() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PluginURL")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockConfigurer_PluginURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PluginURL'
+type MockConfigurer_PluginURL_Call struct {
+	*mock.Call
+}
+
+// PluginURL is a helper method to define mock.On call
+func (_e *MockConfigurer_Expecter) PluginURL() *MockConfigurer_PluginURL_Call {
+	return &MockConfigurer_PluginURL_Call{Call: _e.mock.On("PluginURL")}
+}
+
+func (_c *MockConfigurer_PluginURL_Call) Run(run func()) *MockConfigurer_PluginURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockConfigurer_PluginURL_Call) Return(s string) *MockConfigurer_PluginURL_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockConfigurer_PluginURL_Call) RunAndReturn(run func() string) *MockConfigurer_PluginURL_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -108,6 +108,154 @@ func (_c *MockPersister_CreateAccessRequest_Call) RunAndReturn(run func(ctx cont
 	return _c
 }
 
+// GetAccessBinding provides a mock function for the type MockPersister
+func (_mock *MockPersister) GetAccessBinding(ctx context.Context, name string, namespace string) (*v1alpha1.AccessBinding, error) {
+	ret := _mock.Called(ctx, name, namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccessBinding")
+	}
+
+	var r0 *v1alpha1.AccessBinding
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*v1alpha1.AccessBinding, error)); ok {
+		return returnFunc(ctx, name, namespace)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *v1alpha1.AccessBinding); ok {
+		r0 = returnFunc(ctx, name, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.AccessBinding)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, name, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPersister_GetAccessBinding_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccessBinding'
+type MockPersister_GetAccessBinding_Call struct {
+	*mock.Call
+}
+
+// GetAccessBinding is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - namespace string
+func (_e *MockPersister_Expecter) GetAccessBinding(ctx interface{}, name interface{}, namespace interface{}) *MockPersister_GetAccessBinding_Call {
+	return &MockPersister_GetAccessBinding_Call{Call: _e.mock.On("GetAccessBinding", ctx, name, namespace)}
+}
+
+func (_c *MockPersister_GetAccessBinding_Call) Run(run func(ctx context.Context, name string, namespace string)) *MockPersister_GetAccessBinding_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPersister_GetAccessBinding_Call) Return(accessBinding *v1alpha1.AccessBinding, err error) *MockPersister_GetAccessBinding_Call {
+	_c.Call.Return(accessBinding, err)
+	return _c
+}
+
+func (_c *MockPersister_GetAccessBinding_Call) RunAndReturn(run func(ctx context.Context, name string, namespace string) (*v1alpha1.AccessBinding, error)) *MockPersister_GetAccessBinding_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAccessRequest provides a mock function for the type MockPersister
+func (_mock *MockPersister) GetAccessRequest(ctx context.Context, name string, namespace string) (*v1alpha1.AccessRequest, error) {
+	ret := _mock.Called(ctx, name, namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccessRequest")
+	}
+
+	var r0 *v1alpha1.AccessRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*v1alpha1.AccessRequest, error)); ok {
+		return returnFunc(ctx, name, namespace)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *v1alpha1.AccessRequest); ok {
+		r0 = returnFunc(ctx, name, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.AccessRequest)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, name, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPersister_GetAccessRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccessRequest'
+type MockPersister_GetAccessRequest_Call struct {
+	*mock.Call
+}
+
+// GetAccessRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - namespace string
+func (_e *MockPersister_Expecter) GetAccessRequest(ctx interface{}, name interface{}, namespace interface{}) *MockPersister_GetAccessRequest_Call {
+	return &MockPersister_GetAccessRequest_Call{Call: _e.mock.On("GetAccessRequest", ctx, name, namespace)}
+}
+
+func (_c *MockPersister_GetAccessRequest_Call) Run(run func(ctx context.Context, name string, namespace string)) *MockPersister_GetAccessRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPersister_GetAccessRequest_Call) Return(accessRequest *v1alpha1.AccessRequest, err error) *MockPersister_GetAccessRequest_Call {
+	_c.Call.Return(accessRequest, err)
+	return _c
+}
+
+func (_c *MockPersister_GetAccessRequest_Call) RunAndReturn(run func(ctx context.Context, name string, namespace string) (*v1alpha1.AccessRequest, error)) *MockPersister_GetAccessRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetAppProject provides a mock function for the type MockPersister
 func (_mock *MockPersister) GetAppProject(ctx context.Context, name string, namespace string) (*unstructured.Unstructured, error) {
 	ret := _mock.Called(ctx, name, namespace)
@@ -256,6 +404,80 @@ func (_c *MockPersister_GetApplication_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
+// GetRoleTemplate provides a mock function for the type MockPersister
+func (_mock *MockPersister) GetRoleTemplate(ctx context.Context, name string, namespace string) (*v1alpha1.RoleTemplate, error) {
+	ret := _mock.Called(ctx, name, namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoleTemplate")
+	}
+
+	var r0 *v1alpha1.RoleTemplate
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*v1alpha1.RoleTemplate, error)); ok {
+		return returnFunc(ctx, name, namespace)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *v1alpha1.RoleTemplate); ok {
+		r0 = returnFunc(ctx, name, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.RoleTemplate)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, name, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPersister_GetRoleTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoleTemplate'
+type MockPersister_GetRoleTemplate_Call struct {
+	*mock.Call
+}
+
+// GetRoleTemplate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - namespace string
+func (_e *MockPersister_Expecter) GetRoleTemplate(ctx interface{}, name interface{}, namespace interface{}) *MockPersister_GetRoleTemplate_Call {
+	return &MockPersister_GetRoleTemplate_Call{Call: _e.mock.On("GetRoleTemplate", ctx, name, namespace)}
+}
+
+func (_c *MockPersister_GetRoleTemplate_Call) Run(run func(ctx context.Context, name string, namespace string)) *MockPersister_GetRoleTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPersister_GetRoleTemplate_Call) Return(roleTemplate *v1alpha1.RoleTemplate, err error) *MockPersister_GetRoleTemplate_Call {
+	_c.Call.Return(roleTemplate, err)
+	return _c
+}
+
+func (_c *MockPersister_GetRoleTemplate_Call) RunAndReturn(run func(ctx context.Context, name string, namespace string) (*v1alpha1.RoleTemplate, error)) *MockPersister_GetRoleTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListAccessBindings provides a mock function for the type MockPersister
 func (_mock *MockPersister) ListAccessBindings(ctx context.Context, roleName string, namespace string) (*v1alpha1.AccessBindingList, error) {
 	ret := _mock.Called(ctx, roleName, namespace)
@@ -465,3 +687,379 @@ func (_c *MockPersister_ListAllAccessBindings_Call) RunAndReturn(run func(ctx co
 	_c.Call.Return(run)
 	return _c
 }
+
+// ListApplicationAccessRequests provides a mock function for the type MockPersister
+func (_mock *MockPersister) ListApplicationAccessRequests(ctx context.Context, namespace string, appName string, appNamespace string) (*v1alpha1.AccessRequestList, error) {
+	ret := _mock.Called(ctx, namespace, appName, appNamespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListApplicationAccessRequests")
+	}
+
+	var r0 *v1alpha1.AccessRequestList
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (*v1alpha1.AccessRequestList, error)); ok {
+		return returnFunc(ctx, namespace, appName, appNamespace)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) *v1alpha1.AccessRequestList); ok {
+		r0 = returnFunc(ctx, namespace, appName, appNamespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.AccessRequestList)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, namespace, appName, appNamespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPersister_ListApplicationAccessRequests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListApplicationAccessRequests'
+type MockPersister_ListApplicationAccessRequests_Call struct {
+	*mock.Call
+}
+
+// ListApplicationAccessRequests is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - appName string
+//   - appNamespace string
+func (_e *MockPersister_Expecter) ListApplicationAccessRequests(ctx interface{}, namespace interface{}, appName interface{}, appNamespace interface{}) *MockPersister_ListApplicationAccessRequests_Call {
+	return &MockPersister_ListApplicationAccessRequests_Call{Call: _e.mock.On("ListApplicationAccessRequests", ctx, namespace, appName, appNamespace)}
+}
+
+func (_c *MockPersister_ListApplicationAccessRequests_Call) Run(run func(ctx context.Context, namespace string, appName string, appNamespace string)) *MockPersister_ListApplicationAccessRequests_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPersister_ListApplicationAccessRequests_Call) Return(accessRequestList *v1alpha1.AccessRequestList, err error) *MockPersister_ListApplicationAccessRequests_Call {
+	_c.Call.Return(accessRequestList, err)
+	return _c
+}
+
+func (_c *MockPersister_ListApplicationAccessRequests_Call) RunAndReturn(run func(ctx context.Context, namespace string, appName string, appNamespace string) (*v1alpha1.AccessRequestList, error)) *MockPersister_ListApplicationAccessRequests_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSubjectAccessRequests provides a mock function for the type MockPersister
+func (_mock *MockPersister) ListSubjectAccessRequests(ctx context.Context, namespace string, username string, status v1alpha1.Status) (*v1alpha1.AccessRequestList, error) {
+	ret := _mock.Called(ctx, namespace, username, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSubjectAccessRequests")
+	}
+
+	var r0 *v1alpha1.AccessRequestList
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, v1alpha1.Status) (*v1alpha1.AccessRequestList, error)); ok {
+		return returnFunc(ctx, namespace, username, status)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, v1alpha1.Status) *v1alpha1.AccessRequestList); ok {
+		r0 = returnFunc(ctx, namespace, username, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.AccessRequestList)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, v1alpha1.Status) error); ok {
+		r1 = returnFunc(ctx, namespace, username, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPersister_ListSubjectAccessRequests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSubjectAccessRequests'
+type MockPersister_ListSubjectAccessRequests_Call struct {
+	*mock.Call
+}
+
+// ListSubjectAccessRequests is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - username string
+//   - status v1alpha1.Status
+func (_e *MockPersister_Expecter) ListSubjectAccessRequests(ctx interface{}, namespace interface{}, username interface{}, status interface{}) *MockPersister_ListSubjectAccessRequests_Call {
+	return &MockPersister_ListSubjectAccessRequests_Call{Call: _e.mock.On("ListSubjectAccessRequests", ctx, namespace, username, status)}
+}
+
+func (_c *MockPersister_ListSubjectAccessRequests_Call) Run(run func(ctx context.Context, namespace string, username string, status v1alpha1.Status)) *MockPersister_ListSubjectAccessRequests_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 v1alpha1.Status
+		if args[3] != nil {
+			arg3 = args[3].(v1alpha1.Status)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPersister_ListSubjectAccessRequests_Call) Return(accessRequestList *v1alpha1.AccessRequestList, err error) *MockPersister_ListSubjectAccessRequests_Call {
+	_c.Call.Return(accessRequestList, err)
+	return _c
+}
+
+func (_c *MockPersister_ListSubjectAccessRequests_Call) RunAndReturn(run func(ctx context.Context, namespace string, username string, status v1alpha1.Status) (*v1alpha1.AccessRequestList, error)) *MockPersister_ListSubjectAccessRequests_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchAccessRequests provides a mock function for the type MockPersister
+func (_mock *MockPersister) SearchAccessRequests(ctx context.Context, namespace string, filter *backend.AccessRequestFilter) (*v1alpha1.AccessRequestList, error) {
+	ret := _mock.Called(ctx, namespace, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchAccessRequests")
+	}
+
+	var r0 *v1alpha1.AccessRequestList
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *backend.AccessRequestFilter) (*v1alpha1.AccessRequestList, error)); ok {
+		return returnFunc(ctx, namespace, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *backend.AccessRequestFilter) *v1alpha1.AccessRequestList); ok {
+		r0 = returnFunc(ctx, namespace, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.AccessRequestList)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *backend.AccessRequestFilter) error); ok {
+		r1 = returnFunc(ctx, namespace, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPersister_SearchAccessRequests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchAccessRequests'
+type MockPersister_SearchAccessRequests_Call struct {
+	*mock.Call
+}
+
+// SearchAccessRequests is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - filter *backend.AccessRequestFilter
+func (_e *MockPersister_Expecter) SearchAccessRequests(ctx interface{}, namespace interface{}, filter interface{}) *MockPersister_SearchAccessRequests_Call {
+	return &MockPersister_SearchAccessRequests_Call{Call: _e.mock.On("SearchAccessRequests", ctx, namespace, filter)}
+}
+
+func (_c *MockPersister_SearchAccessRequests_Call) Run(run func(ctx context.Context, namespace string, filter *backend.AccessRequestFilter)) *MockPersister_SearchAccessRequests_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *backend.AccessRequestFilter
+		if args[2] != nil {
+			arg2 = args[2].(*backend.AccessRequestFilter)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPersister_SearchAccessRequests_Call) Return(accessRequestList *v1alpha1.AccessRequestList, err error) *MockPersister_SearchAccessRequests_Call {
+	_c.Call.Return(accessRequestList, err)
+	return _c
+}
+
+func (_c *MockPersister_SearchAccessRequests_Call) RunAndReturn(run func(ctx context.Context, namespace string, filter *backend.AccessRequestFilter) (*v1alpha1.AccessRequestList, error)) *MockPersister_SearchAccessRequests_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateAccessRequest provides a mock function for the type MockPersister
+func (_mock *MockPersister) UpdateAccessRequest(ctx context.Context, ar *v1alpha1.AccessRequest) (*v1alpha1.AccessRequest, error) {
+	ret := _mock.Called(ctx, ar)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAccessRequest")
+	}
+
+	var r0 *v1alpha1.AccessRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *v1alpha1.AccessRequest) (*v1alpha1.AccessRequest, error)); ok {
+		return returnFunc(ctx, ar)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *v1alpha1.AccessRequest) *v1alpha1.AccessRequest); ok {
+		r0 = returnFunc(ctx, ar)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.AccessRequest)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *v1alpha1.AccessRequest) error); ok {
+		r1 = returnFunc(ctx, ar)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPersister_UpdateAccessRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAccessRequest'
+type MockPersister_UpdateAccessRequest_Call struct {
+	*mock.Call
+}
+
+// UpdateAccessRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ar *v1alpha1.AccessRequest
+func (_e *MockPersister_Expecter) UpdateAccessRequest(ctx interface{}, ar interface{}) *MockPersister_UpdateAccessRequest_Call {
+	return &MockPersister_UpdateAccessRequest_Call{Call: _e.mock.On("UpdateAccessRequest", ctx, ar)}
+}
+
+func (_c *MockPersister_UpdateAccessRequest_Call) Run(run func(ctx context.Context, ar *v1alpha1.AccessRequest)) *MockPersister_UpdateAccessRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *v1alpha1.AccessRequest
+		if args[1] != nil {
+			arg1 = args[1].(*v1alpha1.AccessRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPersister_UpdateAccessRequest_Call) Return(accessRequest *v1alpha1.AccessRequest, err error) *MockPersister_UpdateAccessRequest_Call {
+	_c.Call.Return(accessRequest, err)
+	return _c
+}
+
+func (_c *MockPersister_UpdateAccessRequest_Call) RunAndReturn(run func(ctx context.Context, ar *v1alpha1.AccessRequest) (*v1alpha1.AccessRequest, error)) *MockPersister_UpdateAccessRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WatchSubjectAccessRequests provides a mock function for the type MockPersister
+func (_mock *MockPersister) WatchSubjectAccessRequests(ctx context.Context, namespace string, username string) (<-chan *v1alpha1.AccessRequest, error) {
+	ret := _mock.Called(ctx, namespace, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchSubjectAccessRequests")
+	}
+
+	var r0 <-chan *v1alpha1.AccessRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (<-chan *v1alpha1.AccessRequest, error)); ok {
+		return returnFunc(ctx, namespace, username)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) <-chan *v1alpha1.AccessRequest); ok {
+		r0 = returnFunc(ctx, namespace, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan *v1alpha1.AccessRequest)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, namespace, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPersister_WatchSubjectAccessRequests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WatchSubjectAccessRequests'
+type MockPersister_WatchSubjectAccessRequests_Call struct {
+	*mock.Call
+}
+
+// WatchSubjectAccessRequests is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - username string
+func (_e *MockPersister_Expecter) WatchSubjectAccessRequests(ctx interface{}, namespace interface{}, username interface{}) *MockPersister_WatchSubjectAccessRequests_Call {
+	return &MockPersister_WatchSubjectAccessRequests_Call{Call: _e.mock.On("WatchSubjectAccessRequests", ctx, namespace, username)}
+}
+
+func (_c *MockPersister_WatchSubjectAccessRequests_Call) Run(run func(ctx context.Context, namespace string, username string)) *MockPersister_WatchSubjectAccessRequests_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPersister_WatchSubjectAccessRequests_Call) Return(accessRequest <-chan *v1alpha1.AccessRequest, err error) *MockPersister_WatchSubjectAccessRequests_Call {
+	_c.Call.Return(accessRequest, err)
+	return _c
+}
+
+func (_c *MockPersister_WatchSubjectAccessRequests_Call) RunAndReturn(run func(ctx context.Context, namespace string, username string) (<-chan *v1alpha1.AccessRequest, error)) *MockPersister_WatchSubjectAccessRequests_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -6,6 +6,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/argoproj-labs/argocd-ephemeral-access/api/ephemeral-access/v1alpha1"
 	"github.com/argoproj-labs/argocd-ephemeral-access/internal/backend"
@@ -40,48 +41,984 @@ func (_m *MockService) EXPECT() *MockService_Expecter {
 	return &MockService_Expecter{mock: &_m.Mock}
 }
 
+// AddApprovalDecision provides a mock function for the type MockService
+func (_mock *MockService) AddApprovalDecision(ctx context.Context, ar *v1alpha1.AccessRequest, decision *v1alpha1.ApprovalDecision) (*v1alpha1.AccessRequest, error) {
+	ret := _mock.Called(ctx, ar, decision)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddApprovalDecision")
+	}
+
+	var r0 *v1alpha1.AccessRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *v1alpha1.AccessRequest, *v1alpha1.ApprovalDecision) (*v1alpha1.AccessRequest, error)); ok {
+		return returnFunc(ctx, ar, decision)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *v1alpha1.AccessRequest, *v1alpha1.ApprovalDecision) *v1alpha1.AccessRequest); ok {
+		r0 = returnFunc(ctx, ar, decision)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.AccessRequest)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *v1alpha1.AccessRequest, *v1alpha1.ApprovalDecision) error); ok {
+		r1 = returnFunc(ctx, ar, decision)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_AddApprovalDecision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddApprovalDecision'
+type MockService_AddApprovalDecision_Call struct {
+	*mock.Call
+}
+
+// AddApprovalDecision is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ar *v1alpha1.AccessRequest
+//   - decision *v1alpha1.ApprovalDecision
+func (_e *MockService_Expecter) AddApprovalDecision(ctx interface{}, ar interface{}, decision interface{}) *MockService_AddApprovalDecision_Call {
+	return &MockService_AddApprovalDecision_Call{Call: _e.mock.On("AddApprovalDecision", ctx, ar, decision)}
+}
+
+func (_c *MockService_AddApprovalDecision_Call) Run(run func(ctx context.Context, ar *v1alpha1.AccessRequest, decision *v1alpha1.ApprovalDecision)) *MockService_AddApprovalDecision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *v1alpha1.AccessRequest
+		if args[1] != nil {
+			arg1 = args[1].(*v1alpha1.AccessRequest)
+		}
+		var arg2 *v1alpha1.ApprovalDecision
+		if args[2] != nil {
+			arg2 = args[2].(*v1alpha1.ApprovalDecision)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_AddApprovalDecision_Call) Return(accessRequest *v1alpha1.AccessRequest, err error) *MockService_AddApprovalDecision_Call {
+	_c.Call.Return(accessRequest, err)
+	return _c
+}
+
+func (_c *MockService_AddApprovalDecision_Call) RunAndReturn(run func(ctx context.Context, ar *v1alpha1.AccessRequest, decision *v1alpha1.ApprovalDecision) (*v1alpha1.AccessRequest, error)) *MockService_AddApprovalDecision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CancelAccessRequest provides a mock function for the type MockService
+func (_mock *MockService) CancelAccessRequest(ctx context.Context, ar *v1alpha1.AccessRequest) (*v1alpha1.AccessRequest, error) {
+	ret := _mock.Called(ctx, ar)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelAccessRequest")
+	}
+
+	var r0 *v1alpha1.AccessRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *v1alpha1.AccessRequest) (*v1alpha1.AccessRequest, error)); ok {
+		return returnFunc(ctx, ar)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *v1alpha1.AccessRequest) *v1alpha1.AccessRequest); ok {
+		r0 = returnFunc(ctx, ar)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.AccessRequest)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *v1alpha1.AccessRequest) error); ok {
+		r1 = returnFunc(ctx, ar)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_CancelAccessRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelAccessRequest'
+type MockService_CancelAccessRequest_Call struct {
+	*mock.Call
+}
+
+// CancelAccessRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ar *v1alpha1.AccessRequest
+func (_e *MockService_Expecter) CancelAccessRequest(ctx interface{}, ar interface{}) *MockService_CancelAccessRequest_Call {
+	return &MockService_CancelAccessRequest_Call{Call: _e.mock.On("CancelAccessRequest", ctx, ar)}
+}
+
+func (_c *MockService_CancelAccessRequest_Call) Run(run func(ctx context.Context, ar *v1alpha1.AccessRequest)) *MockService_CancelAccessRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *v1alpha1.AccessRequest
+		if args[1] != nil {
+			arg1 = args[1].(*v1alpha1.AccessRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_CancelAccessRequest_Call) Return(accessRequest *v1alpha1.AccessRequest, err error) *MockService_CancelAccessRequest_Call {
+	_c.Call.Return(accessRequest, err)
+	return _c
+}
+
+func (_c *MockService_CancelAccessRequest_Call) RunAndReturn(run func(ctx context.Context, ar *v1alpha1.AccessRequest) (*v1alpha1.AccessRequest, error)) *MockService_CancelAccessRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateAccessRequest provides a mock function for the type MockService
-func (_mock *MockService) CreateAccessRequest(ctx context.Context, key *backend.AccessRequestKey, binding *v1alpha1.AccessBinding) (*v1alpha1.AccessRequest, error) {
-	ret := _mock.Called(ctx, key, binding)
+func (_mock *MockService) CreateAccessRequest(ctx context.Context, key *backend.AccessRequestKey, binding *v1alpha1.AccessBinding, opts *backend.AccessRequestOptions) (*v1alpha1.AccessRequest, error) {
+	ret := _mock.Called(ctx, key, binding, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAccessRequest")
+	}
+
+	var r0 *v1alpha1.AccessRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *backend.AccessRequestKey, *v1alpha1.AccessBinding, *backend.AccessRequestOptions) (*v1alpha1.AccessRequest, error)); ok {
+		return returnFunc(ctx, key, binding, opts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *backend.AccessRequestKey, *v1alpha1.AccessBinding, *backend.AccessRequestOptions) *v1alpha1.AccessRequest); ok {
+		r0 = returnFunc(ctx, key, binding, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.AccessRequest)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *backend.AccessRequestKey, *v1alpha1.AccessBinding, *backend.AccessRequestOptions) error); ok {
+		r1 = returnFunc(ctx, key, binding, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_CreateAccessRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAccessRequest'
+type MockService_CreateAccessRequest_Call struct {
+	*mock.Call
+}
+
+// CreateAccessRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key *backend.AccessRequestKey
+//   - binding *v1alpha1.AccessBinding
+//   - opts *backend.AccessRequestOptions
+func (_e *MockService_Expecter) CreateAccessRequest(ctx interface{}, key interface{}, binding interface{}, opts interface{}) *MockService_CreateAccessRequest_Call {
+	return &MockService_CreateAccessRequest_Call{Call: _e.mock.On("CreateAccessRequest", ctx, key, binding, opts)}
+}
+
+func (_c *MockService_CreateAccessRequest_Call) Run(run func(ctx context.Context, key *backend.AccessRequestKey, binding *v1alpha1.AccessBinding, opts *backend.AccessRequestOptions)) *MockService_CreateAccessRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *backend.AccessRequestKey
+		if args[1] != nil {
+			arg1 = args[1].(*backend.AccessRequestKey)
+		}
+		var arg2 *v1alpha1.AccessBinding
+		if args[2] != nil {
+			arg2 = args[2].(*v1alpha1.AccessBinding)
+		}
+		var arg3 *backend.AccessRequestOptions
+		if args[3] != nil {
+			arg3 = args[3].(*backend.AccessRequestOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_CreateAccessRequest_Call) Return(accessRequest *v1alpha1.AccessRequest, err error) *MockService_CreateAccessRequest_Call {
+	_c.Call.Return(accessRequest, err)
+	return _c
+}
+
+func (_c *MockService_CreateAccessRequest_Call) RunAndReturn(run func(ctx context.Context, key *backend.AccessRequestKey, binding *v1alpha1.AccessBinding, opts *backend.AccessRequestOptions) (*v1alpha1.AccessRequest, error)) *MockService_CreateAccessRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExplainAccessBindings provides a mock function for the type MockService
+func (_mock *MockService) ExplainAccessBindings(ctx context.Context, roleName string, namespace string, groups []string, app *unstructured.Unstructured, project *unstructured.Unstructured) ([]*backend.AccessBindingExplanation, error) {
+	ret := _mock.Called(ctx, roleName, namespace, groups, app, project)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExplainAccessBindings")
+	}
+
+	var r0 []*backend.AccessBindingExplanation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []string, *unstructured.Unstructured, *unstructured.Unstructured) ([]*backend.AccessBindingExplanation, error)); ok {
+		return returnFunc(ctx, roleName, namespace, groups, app, project)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []string, *unstructured.Unstructured, *unstructured.Unstructured) []*backend.AccessBindingExplanation); ok {
+		r0 = returnFunc(ctx, roleName, namespace, groups, app, project)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*backend.AccessBindingExplanation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, []string, *unstructured.Unstructured, *unstructured.Unstructured) error); ok {
+		r1 = returnFunc(ctx, roleName, namespace, groups, app, project)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_ExplainAccessBindings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExplainAccessBindings'
+type MockService_ExplainAccessBindings_Call struct {
+	*mock.Call
+}
+
+// ExplainAccessBindings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - roleName string
+//   - namespace string
+//   - groups []string
+//   - app *unstructured.Unstructured
+//   - project *unstructured.Unstructured
+func (_e *MockService_Expecter) ExplainAccessBindings(ctx interface{}, roleName interface{}, namespace interface{}, groups interface{}, app interface{}, project interface{}) *MockService_ExplainAccessBindings_Call {
+	return &MockService_ExplainAccessBindings_Call{Call: _e.mock.On("ExplainAccessBindings", ctx, roleName, namespace, groups, app, project)}
+}
+
+func (_c *MockService_ExplainAccessBindings_Call) Run(run func(ctx context.Context, roleName string, namespace string, groups []string, app *unstructured.Unstructured, project *unstructured.Unstructured)) *MockService_ExplainAccessBindings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 []string
+		if args[3] != nil {
+			arg3 = args[3].([]string)
+		}
+		var arg4 *unstructured.Unstructured
+		if args[4] != nil {
+			arg4 = args[4].(*unstructured.Unstructured)
+		}
+		var arg5 *unstructured.Unstructured
+		if args[5] != nil {
+			arg5 = args[5].(*unstructured.Unstructured)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_ExplainAccessBindings_Call) Return(accessBindingExplanations []*backend.AccessBindingExplanation, err error) *MockService_ExplainAccessBindings_Call {
+	_c.Call.Return(accessBindingExplanations, err)
+	return _c
+}
+
+func (_c *MockService_ExplainAccessBindings_Call) RunAndReturn(run func(ctx context.Context, roleName string, namespace string, groups []string, app *unstructured.Unstructured, project *unstructured.Unstructured) ([]*backend.AccessBindingExplanation, error)) *MockService_ExplainAccessBindings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExtendAccessRequest provides a mock function for the type MockService
+func (_mock *MockService) ExtendAccessRequest(ctx context.Context, ar *v1alpha1.AccessRequest, duration time.Duration) (*v1alpha1.AccessRequest, error) {
+	ret := _mock.Called(ctx, ar, duration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExtendAccessRequest")
+	}
+
+	var r0 *v1alpha1.AccessRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *v1alpha1.AccessRequest, time.Duration) (*v1alpha1.AccessRequest, error)); ok {
+		return returnFunc(ctx, ar, duration)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *v1alpha1.AccessRequest, time.Duration) *v1alpha1.AccessRequest); ok {
+		r0 = returnFunc(ctx, ar, duration)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.AccessRequest)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *v1alpha1.AccessRequest, time.Duration) error); ok {
+		r1 = returnFunc(ctx, ar, duration)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_ExtendAccessRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExtendAccessRequest'
+type MockService_ExtendAccessRequest_Call struct {
+	*mock.Call
+}
+
+// ExtendAccessRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ar *v1alpha1.AccessRequest
+//   - duration time.Duration
+func (_e *MockService_Expecter) ExtendAccessRequest(ctx interface{}, ar interface{}, duration interface{}) *MockService_ExtendAccessRequest_Call {
+	return &MockService_ExtendAccessRequest_Call{Call: _e.mock.On("ExtendAccessRequest", ctx, ar, duration)}
+}
+
+func (_c *MockService_ExtendAccessRequest_Call) Run(run func(ctx context.Context, ar *v1alpha1.AccessRequest, duration time.Duration)) *MockService_ExtendAccessRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *v1alpha1.AccessRequest
+		if args[1] != nil {
+			arg1 = args[1].(*v1alpha1.AccessRequest)
+		}
+		var arg2 time.Duration
+		if args[2] != nil {
+			arg2 = args[2].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_ExtendAccessRequest_Call) Return(accessRequest *v1alpha1.AccessRequest, err error) *MockService_ExtendAccessRequest_Call {
+	_c.Call.Return(accessRequest, err)
+	return _c
+}
+
+func (_c *MockService_ExtendAccessRequest_Call) RunAndReturn(run func(ctx context.Context, ar *v1alpha1.AccessRequest, duration time.Duration) (*v1alpha1.AccessRequest, error)) *MockService_ExtendAccessRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAccessBindingsForGroups provides a mock function for the type MockService
+func (_mock *MockService) GetAccessBindingsForGroups(ctx context.Context, namespace string, groups []string, app *unstructured.Unstructured, project *unstructured.Unstructured) ([]*v1alpha1.AccessBinding, error) {
+	ret := _mock.Called(ctx, namespace, groups, app, project)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccessBindingsForGroups")
+	}
+
+	var r0 []*v1alpha1.AccessBinding
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string, *unstructured.Unstructured, *unstructured.Unstructured) ([]*v1alpha1.AccessBinding, error)); ok {
+		return returnFunc(ctx, namespace, groups, app, project)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string, *unstructured.Unstructured, *unstructured.Unstructured) []*v1alpha1.AccessBinding); ok {
+		r0 = returnFunc(ctx, namespace, groups, app, project)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1alpha1.AccessBinding)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string, *unstructured.Unstructured, *unstructured.Unstructured) error); ok {
+		r1 = returnFunc(ctx, namespace, groups, app, project)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_GetAccessBindingsForGroups_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccessBindingsForGroups'
+type MockService_GetAccessBindingsForGroups_Call struct {
+	*mock.Call
+}
+
+// GetAccessBindingsForGroups is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - groups []string
+//   - app *unstructured.Unstructured
+//   - project *unstructured.Unstructured
+func (_e *MockService_Expecter) GetAccessBindingsForGroups(ctx interface{}, namespace interface{}, groups interface{}, app interface{}, project interface{}) *MockService_GetAccessBindingsForGroups_Call {
+	return &MockService_GetAccessBindingsForGroups_Call{Call: _e.mock.On("GetAccessBindingsForGroups", ctx, namespace, groups, app, project)}
+}
+
+func (_c *MockService_GetAccessBindingsForGroups_Call) Run(run func(ctx context.Context, namespace string, groups []string, app *unstructured.Unstructured, project *unstructured.Unstructured)) *MockService_GetAccessBindingsForGroups_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		var arg3 *unstructured.Unstructured
+		if args[3] != nil {
+			arg3 = args[3].(*unstructured.Unstructured)
+		}
+		var arg4 *unstructured.Unstructured
+		if args[4] != nil {
+			arg4 = args[4].(*unstructured.Unstructured)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_GetAccessBindingsForGroups_Call) Return(accessBindings []*v1alpha1.AccessBinding, err error) *MockService_GetAccessBindingsForGroups_Call {
+	_c.Call.Return(accessBindings, err)
+	return _c
+}
+
+func (_c *MockService_GetAccessBindingsForGroups_Call) RunAndReturn(run func(ctx context.Context, namespace string, groups []string, app *unstructured.Unstructured, project *unstructured.Unstructured) ([]*v1alpha1.AccessBinding, error)) *MockService_GetAccessBindingsForGroups_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAccessRequest provides a mock function for the type MockService
+func (_mock *MockService) GetAccessRequest(ctx context.Context, name string, namespace string) (*v1alpha1.AccessRequest, error) {
+	ret := _mock.Called(ctx, name, namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccessRequest")
+	}
+
+	var r0 *v1alpha1.AccessRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*v1alpha1.AccessRequest, error)); ok {
+		return returnFunc(ctx, name, namespace)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *v1alpha1.AccessRequest); ok {
+		r0 = returnFunc(ctx, name, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.AccessRequest)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, name, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_GetAccessRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccessRequest'
+type MockService_GetAccessRequest_Call struct {
+	*mock.Call
+}
+
+// GetAccessRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - namespace string
+func (_e *MockService_Expecter) GetAccessRequest(ctx interface{}, name interface{}, namespace interface{}) *MockService_GetAccessRequest_Call {
+	return &MockService_GetAccessRequest_Call{Call: _e.mock.On("GetAccessRequest", ctx, name, namespace)}
+}
+
+func (_c *MockService_GetAccessRequest_Call) Run(run func(ctx context.Context, name string, namespace string)) *MockService_GetAccessRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_GetAccessRequest_Call) Return(accessRequest *v1alpha1.AccessRequest, err error) *MockService_GetAccessRequest_Call {
+	_c.Call.Return(accessRequest, err)
+	return _c
+}
+
+func (_c *MockService_GetAccessRequest_Call) RunAndReturn(run func(ctx context.Context, name string, namespace string) (*v1alpha1.AccessRequest, error)) *MockService_GetAccessRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAccessRequestByRole provides a mock function for the type MockService
+func (_mock *MockService) GetAccessRequestByRole(ctx context.Context, key *backend.AccessRequestKey, roleName string) (*v1alpha1.AccessRequest, error) {
+	ret := _mock.Called(ctx, key, roleName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccessRequestByRole")
+	}
+
+	var r0 *v1alpha1.AccessRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *backend.AccessRequestKey, string) (*v1alpha1.AccessRequest, error)); ok {
+		return returnFunc(ctx, key, roleName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *backend.AccessRequestKey, string) *v1alpha1.AccessRequest); ok {
+		r0 = returnFunc(ctx, key, roleName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.AccessRequest)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *backend.AccessRequestKey, string) error); ok {
+		r1 = returnFunc(ctx, key, roleName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_GetAccessRequestByRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccessRequestByRole'
+type MockService_GetAccessRequestByRole_Call struct {
+	*mock.Call
+}
+
+// GetAccessRequestByRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key *backend.AccessRequestKey
+//   - roleName string
+func (_e *MockService_Expecter) GetAccessRequestByRole(ctx interface{}, key interface{}, roleName interface{}) *MockService_GetAccessRequestByRole_Call {
+	return &MockService_GetAccessRequestByRole_Call{Call: _e.mock.On("GetAccessRequestByRole", ctx, key, roleName)}
+}
+
+func (_c *MockService_GetAccessRequestByRole_Call) Run(run func(ctx context.Context, key *backend.AccessRequestKey, roleName string)) *MockService_GetAccessRequestByRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *backend.AccessRequestKey
+		if args[1] != nil {
+			arg1 = args[1].(*backend.AccessRequestKey)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_GetAccessRequestByRole_Call) Return(accessRequest *v1alpha1.AccessRequest, err error) *MockService_GetAccessRequestByRole_Call {
+	_c.Call.Return(accessRequest, err)
+	return _c
+}
+
+func (_c *MockService_GetAccessRequestByRole_Call) RunAndReturn(run func(ctx context.Context, key *backend.AccessRequestKey, roleName string) (*v1alpha1.AccessRequest, error)) *MockService_GetAccessRequestByRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAppProject provides a mock function for the type MockService
+func (_mock *MockService) GetAppProject(ctx context.Context, name string, namespace string) (*unstructured.Unstructured, error) {
+	ret := _mock.Called(ctx, name, namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAppProject")
+	}
+
+	var r0 *unstructured.Unstructured
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*unstructured.Unstructured, error)); ok {
+		return returnFunc(ctx, name, namespace)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *unstructured.Unstructured); ok {
+		r0 = returnFunc(ctx, name, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*unstructured.Unstructured)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, name, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_GetAppProject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAppProject'
+type MockService_GetAppProject_Call struct {
+	*mock.Call
+}
+
+// GetAppProject is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - namespace string
+func (_e *MockService_Expecter) GetAppProject(ctx interface{}, name interface{}, namespace interface{}) *MockService_GetAppProject_Call {
+	return &MockService_GetAppProject_Call{Call: _e.mock.On("GetAppProject", ctx, name, namespace)}
+}
+
+func (_c *MockService_GetAppProject_Call) Run(run func(ctx context.Context, name string, namespace string)) *MockService_GetAppProject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_GetAppProject_Call) Return(unstructured1 *unstructured.Unstructured, err error) *MockService_GetAppProject_Call {
+	_c.Call.Return(unstructured1, err)
+	return _c
+}
+
+func (_c *MockService_GetAppProject_Call) RunAndReturn(run func(ctx context.Context, name string, namespace string) (*unstructured.Unstructured, error)) *MockService_GetAppProject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetApplication provides a mock function for the type MockService
+func (_mock *MockService) GetApplication(ctx context.Context, name string, namespace string) (*unstructured.Unstructured, error) {
+	ret := _mock.Called(ctx, name, namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetApplication")
+	}
+
+	var r0 *unstructured.Unstructured
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*unstructured.Unstructured, error)); ok {
+		return returnFunc(ctx, name, namespace)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *unstructured.Unstructured); ok {
+		r0 = returnFunc(ctx, name, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*unstructured.Unstructured)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, name, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_GetApplication_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetApplication'
+type MockService_GetApplication_Call struct {
+	*mock.Call
+}
+
+// GetApplication is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - namespace string
+func (_e *MockService_Expecter) GetApplication(ctx interface{}, name interface{}, namespace interface{}) *MockService_GetApplication_Call {
+	return &MockService_GetApplication_Call{Call: _e.mock.On("GetApplication", ctx, name, namespace)}
+}
+
+func (_c *MockService_GetApplication_Call) Run(run func(ctx context.Context, name string, namespace string)) *MockService_GetApplication_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_GetApplication_Call) Return(unstructured1 *unstructured.Unstructured, err error) *MockService_GetApplication_Call {
+	_c.Call.Return(unstructured1, err)
+	return _c
+}
+
+func (_c *MockService_GetApplication_Call) RunAndReturn(run func(ctx context.Context, name string, namespace string) (*unstructured.Unstructured, error)) *MockService_GetApplication_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetGrantingAccessBinding provides a mock function for the type MockService
+func (_mock *MockService) GetGrantingAccessBinding(ctx context.Context, roleName string, namespace string, groups []string, app *unstructured.Unstructured, project *unstructured.Unstructured) (*v1alpha1.AccessBinding, error) {
+	ret := _mock.Called(ctx, roleName, namespace, groups, app, project)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGrantingAccessBinding")
+	}
+
+	var r0 *v1alpha1.AccessBinding
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []string, *unstructured.Unstructured, *unstructured.Unstructured) (*v1alpha1.AccessBinding, error)); ok {
+		return returnFunc(ctx, roleName, namespace, groups, app, project)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []string, *unstructured.Unstructured, *unstructured.Unstructured) *v1alpha1.AccessBinding); ok {
+		r0 = returnFunc(ctx, roleName, namespace, groups, app, project)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.AccessBinding)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, []string, *unstructured.Unstructured, *unstructured.Unstructured) error); ok {
+		r1 = returnFunc(ctx, roleName, namespace, groups, app, project)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_GetGrantingAccessBinding_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGrantingAccessBinding'
+type MockService_GetGrantingAccessBinding_Call struct {
+	*mock.Call
+}
+
+// GetGrantingAccessBinding is a helper method to define mock.On call
+//   - ctx context.Context
+//   - roleName string
+//   - namespace string
+//   - groups []string
+//   - app *unstructured.Unstructured
+//   - project *unstructured.Unstructured
+func (_e *MockService_Expecter) GetGrantingAccessBinding(ctx interface{}, roleName interface{}, namespace interface{}, groups interface{}, app interface{}, project interface{}) *MockService_GetGrantingAccessBinding_Call {
+	return &MockService_GetGrantingAccessBinding_Call{Call: _e.mock.On("GetGrantingAccessBinding", ctx, roleName, namespace, groups, app, project)}
+}
+
+func (_c *MockService_GetGrantingAccessBinding_Call) Run(run func(ctx context.Context, roleName string, namespace string, groups []string, app *unstructured.Unstructured, project *unstructured.Unstructured)) *MockService_GetGrantingAccessBinding_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 []string
+		if args[3] != nil {
+			arg3 = args[3].([]string)
+		}
+		var arg4 *unstructured.Unstructured
+		if args[4] != nil {
+			arg4 = args[4].(*unstructured.Unstructured)
+		}
+		var arg5 *unstructured.Unstructured
+		if args[5] != nil {
+			arg5 = args[5].(*unstructured.Unstructured)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_GetGrantingAccessBinding_Call) Return(accessBinding *v1alpha1.AccessBinding, err error) *MockService_GetGrantingAccessBinding_Call {
+	_c.Call.Return(accessBinding, err)
+	return _c
+}
+
+func (_c *MockService_GetGrantingAccessBinding_Call) RunAndReturn(run func(ctx context.Context, roleName string, namespace string, groups []string, app *unstructured.Unstructured, project *unstructured.Unstructured) (*v1alpha1.AccessBinding, error)) *MockService_GetGrantingAccessBinding_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRenderedRoleTemplate provides a mock function for the type MockService
+func (_mock *MockService) GetRenderedRoleTemplate(ctx context.Context, ar *v1alpha1.AccessRequest, projName string) (*v1alpha1.RoleTemplate, error) {
+	ret := _mock.Called(ctx, ar, projName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRenderedRoleTemplate")
+	}
+
+	var r0 *v1alpha1.RoleTemplate
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *v1alpha1.AccessRequest, string) (*v1alpha1.RoleTemplate, error)); ok {
+		return returnFunc(ctx, ar, projName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *v1alpha1.AccessRequest, string) *v1alpha1.RoleTemplate); ok {
+		r0 = returnFunc(ctx, ar, projName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.RoleTemplate)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *v1alpha1.AccessRequest, string) error); ok {
+		r1 = returnFunc(ctx, ar, projName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_GetRenderedRoleTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRenderedRoleTemplate'
+type MockService_GetRenderedRoleTemplate_Call struct {
+	*mock.Call
+}
+
+// GetRenderedRoleTemplate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ar *v1alpha1.AccessRequest
+//   - projName string
+func (_e *MockService_Expecter) GetRenderedRoleTemplate(ctx interface{}, ar interface{}, projName interface{}) *MockService_GetRenderedRoleTemplate_Call {
+	return &MockService_GetRenderedRoleTemplate_Call{Call: _e.mock.On("GetRenderedRoleTemplate", ctx, ar, projName)}
+}
+
+func (_c *MockService_GetRenderedRoleTemplate_Call) Run(run func(ctx context.Context, ar *v1alpha1.AccessRequest, projName string)) *MockService_GetRenderedRoleTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *v1alpha1.AccessRequest
+		if args[1] != nil {
+			arg1 = args[1].(*v1alpha1.AccessRequest)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_GetRenderedRoleTemplate_Call) Return(roleTemplate *v1alpha1.RoleTemplate, err error) *MockService_GetRenderedRoleTemplate_Call {
+	_c.Call.Return(roleTemplate, err)
+	return _c
+}
+
+func (_c *MockService_GetRenderedRoleTemplate_Call) RunAndReturn(run func(ctx context.Context, ar *v1alpha1.AccessRequest, projName string) (*v1alpha1.RoleTemplate, error)) *MockService_GetRenderedRoleTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAccessRequests provides a mock function for the type MockService
+func (_mock *MockService) ListAccessRequests(ctx context.Context, key *backend.AccessRequestKey, sort bool) ([]*v1alpha1.AccessRequest, error) {
+	ret := _mock.Called(ctx, key, sort)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateAccessRequest")
+		panic("no return value specified for ListAccessRequests")
 	}
 
-	var r0 *v1alpha1.AccessRequest
+	var r0 []*v1alpha1.AccessRequest
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *backend.AccessRequestKey, *v1alpha1.AccessBinding) (*v1alpha1.AccessRequest, error)); ok {
-		return returnFunc(ctx, key, binding)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *backend.AccessRequestKey, bool) ([]*v1alpha1.AccessRequest, error)); ok {
+		return returnFunc(ctx, key, sort)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *backend.AccessRequestKey, *v1alpha1.AccessBinding) *v1alpha1.AccessRequest); ok {
-		r0 = returnFunc(ctx, key, binding)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *backend.AccessRequestKey, bool) []*v1alpha1.AccessRequest); ok {
+		r0 = returnFunc(ctx, key, sort)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*v1alpha1.AccessRequest)
+			r0 = ret.Get(0).([]*v1alpha1.AccessRequest)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *backend.AccessRequestKey, *v1alpha1.AccessBinding) error); ok {
-		r1 = returnFunc(ctx, key, binding)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *backend.AccessRequestKey, bool) error); ok {
+		r1 = returnFunc(ctx, key, sort)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockService_CreateAccessRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAccessRequest'
-type MockService_CreateAccessRequest_Call struct {
+// MockService_ListAccessRequests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAccessRequests'
+type MockService_ListAccessRequests_Call struct {
 	*mock.Call
 }
 
-// CreateAccessRequest is a helper method to define mock.On call
+// ListAccessRequests is a helper method to define mock.On call
 //   - ctx context.Context
 //   - key *backend.AccessRequestKey
-//   - binding *v1alpha1.AccessBinding
-func (_e *MockService_Expecter) CreateAccessRequest(ctx interface{}, key interface{}, binding interface{}) *MockService_CreateAccessRequest_Call {
-	return &MockService_CreateAccessRequest_Call{Call: _e.mock.On("CreateAccessRequest", ctx, key, binding)}
+//   - sort bool
+func (_e *MockService_Expecter) ListAccessRequests(ctx interface{}, key interface{}, sort interface{}) *MockService_ListAccessRequests_Call {
+	return &MockService_ListAccessRequests_Call{Call: _e.mock.On("ListAccessRequests", ctx, key, sort)}
 }
 
-func (_c *MockService_CreateAccessRequest_Call) Run(run func(ctx context.Context, key *backend.AccessRequestKey, binding *v1alpha1.AccessBinding)) *MockService_CreateAccessRequest_Call {
+func (_c *MockService_ListAccessRequests_Call) Run(run func(ctx context.Context, key *backend.AccessRequestKey, sort bool)) *MockService_ListAccessRequests_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -91,9 +1028,9 @@ func (_c *MockService_CreateAccessRequest_Call) Run(run func(ctx context.Context
 		if args[1] != nil {
 			arg1 = args[1].(*backend.AccessRequestKey)
 		}
-		var arg2 *v1alpha1.AccessBinding
+		var arg2 bool
 		if args[2] != nil {
-			arg2 = args[2].(*v1alpha1.AccessBinding)
+			arg2 = args[2].(bool)
 		}
 		run(
 			arg0,
@@ -104,292 +1041,281 @@ func (_c *MockService_CreateAccessRequest_Call) Run(run func(ctx context.Context
 	return _c
 }
 
-func (_c *MockService_CreateAccessRequest_Call) Return(accessRequest *v1alpha1.AccessRequest, err error) *MockService_CreateAccessRequest_Call {
-	_c.Call.Return(accessRequest, err)
+func (_c *MockService_ListAccessRequests_Call) Return(accessRequests []*v1alpha1.AccessRequest, err error) *MockService_ListAccessRequests_Call {
+	_c.Call.Return(accessRequests, err)
 	return _c
 }
 
-func (_c *MockService_CreateAccessRequest_Call) RunAndReturn(run func(ctx context.Context, key *backend.AccessRequestKey, binding *v1alpha1.AccessBinding) (*v1alpha1.AccessRequest, error)) *MockService_CreateAccessRequest_Call {
+func (_c *MockService_ListAccessRequests_Call) RunAndReturn(run func(ctx context.Context, key *backend.AccessRequestKey, sort bool) ([]*v1alpha1.AccessRequest, error)) *MockService_ListAccessRequests_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAccessBindingsForGroups provides a mock function for the type MockService
-func (_mock *MockService) GetAccessBindingsForGroups(ctx context.Context, namespace string, groups []string, app *unstructured.Unstructured, project *unstructured.Unstructured) ([]*v1alpha1.AccessBinding, error) {
-	ret := _mock.Called(ctx, namespace, groups, app, project)
+// ListPendingApprovals provides a mock function for the type MockService
+func (_mock *MockService) ListPendingApprovals(ctx context.Context, key *backend.AccessRequestKey, groups []string) ([]*v1alpha1.AccessRequest, error) {
+	ret := _mock.Called(ctx, key, groups)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAccessBindingsForGroups")
+		panic("no return value specified for ListPendingApprovals")
 	}
 
-	var r0 []*v1alpha1.AccessBinding
+	var r0 []*v1alpha1.AccessRequest
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string, *unstructured.Unstructured, *unstructured.Unstructured) ([]*v1alpha1.AccessBinding, error)); ok {
-		return returnFunc(ctx, namespace, groups, app, project)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *backend.AccessRequestKey, []string) ([]*v1alpha1.AccessRequest, error)); ok {
+		return returnFunc(ctx, key, groups)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string, *unstructured.Unstructured, *unstructured.Unstructured) []*v1alpha1.AccessBinding); ok {
-		r0 = returnFunc(ctx, namespace, groups, app, project)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *backend.AccessRequestKey, []string) []*v1alpha1.AccessRequest); ok {
+		r0 = returnFunc(ctx, key, groups)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*v1alpha1.AccessBinding)
+			r0 = ret.Get(0).([]*v1alpha1.AccessRequest)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string, *unstructured.Unstructured, *unstructured.Unstructured) error); ok {
-		r1 = returnFunc(ctx, namespace, groups, app, project)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *backend.AccessRequestKey, []string) error); ok {
+		r1 = returnFunc(ctx, key, groups)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockService_GetAccessBindingsForGroups_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccessBindingsForGroups'
-type MockService_GetAccessBindingsForGroups_Call struct {
+// MockService_ListPendingApprovals_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPendingApprovals'
+type MockService_ListPendingApprovals_Call struct {
 	*mock.Call
 }
 
-// GetAccessBindingsForGroups is a helper method to define mock.On call
+// ListPendingApprovals is a helper method to define mock.On call
 //   - ctx context.Context
-//   - namespace string
+//   - key *backend.AccessRequestKey
 //   - groups []string
-//   - app *unstructured.Unstructured
-//   - project *unstructured.Unstructured
-func (_e *MockService_Expecter) GetAccessBindingsForGroups(ctx interface{}, namespace interface{}, groups interface{}, app interface{}, project interface{}) *MockService_GetAccessBindingsForGroups_Call {
-	return &MockService_GetAccessBindingsForGroups_Call{Call: _e.mock.On("GetAccessBindingsForGroups", ctx, namespace, groups, app, project)}
+func (_e *MockService_Expecter) ListPendingApprovals(ctx interface{}, key interface{}, groups interface{}) *MockService_ListPendingApprovals_Call {
+	return &MockService_ListPendingApprovals_Call{Call: _e.mock.On("ListPendingApprovals", ctx, key, groups)}
 }
 
-func (_c *MockService_GetAccessBindingsForGroups_Call) Run(run func(ctx context.Context, namespace string, groups []string, app *unstructured.Unstructured, project *unstructured.Unstructured)) *MockService_GetAccessBindingsForGroups_Call {
+func (_c *MockService_ListPendingApprovals_Call) Run(run func(ctx context.Context, key *backend.AccessRequestKey, groups []string)) *MockService_ListPendingApprovals_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *backend.AccessRequestKey
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(*backend.AccessRequestKey)
 		}
 		var arg2 []string
 		if args[2] != nil {
 			arg2 = args[2].([]string)
 		}
-		var arg3 *unstructured.Unstructured
-		if args[3] != nil {
-			arg3 = args[3].(*unstructured.Unstructured)
-		}
-		var arg4 *unstructured.Unstructured
-		if args[4] != nil {
-			arg4 = args[4].(*unstructured.Unstructured)
-		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
-			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_GetAccessBindingsForGroups_Call) Return(accessBindings []*v1alpha1.AccessBinding, err error) *MockService_GetAccessBindingsForGroups_Call {
-	_c.Call.Return(accessBindings, err)
+func (_c *MockService_ListPendingApprovals_Call) Return(accessRequests []*v1alpha1.AccessRequest, err error) *MockService_ListPendingApprovals_Call {
+	_c.Call.Return(accessRequests, err)
 	return _c
 }
 
-func (_c *MockService_GetAccessBindingsForGroups_Call) RunAndReturn(run func(ctx context.Context, namespace string, groups []string, app *unstructured.Unstructured, project *unstructured.Unstructured) ([]*v1alpha1.AccessBinding, error)) *MockService_GetAccessBindingsForGroups_Call {
+func (_c *MockService_ListPendingApprovals_Call) RunAndReturn(run func(ctx context.Context, key *backend.AccessRequestKey, groups []string) ([]*v1alpha1.AccessRequest, error)) *MockService_ListPendingApprovals_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAccessRequestByRole provides a mock function for the type MockService
-func (_mock *MockService) GetAccessRequestByRole(ctx context.Context, key *backend.AccessRequestKey, roleName string) (*v1alpha1.AccessRequest, error) {
-	ret := _mock.Called(ctx, key, roleName)
+// ListSubjectAccessRequests provides a mock function for the type MockService
+func (_mock *MockService) ListSubjectAccessRequests(ctx context.Context, namespace string, username string, opts *backend.ListOptions) (*backend.AccessRequestPage, error) {
+	ret := _mock.Called(ctx, namespace, username, opts)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAccessRequestByRole")
+		panic("no return value specified for ListSubjectAccessRequests")
 	}
 
-	var r0 *v1alpha1.AccessRequest
+	var r0 *backend.AccessRequestPage
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *backend.AccessRequestKey, string) (*v1alpha1.AccessRequest, error)); ok {
-		return returnFunc(ctx, key, roleName)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *backend.ListOptions) (*backend.AccessRequestPage, error)); ok {
+		return returnFunc(ctx, namespace, username, opts)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *backend.AccessRequestKey, string) *v1alpha1.AccessRequest); ok {
-		r0 = returnFunc(ctx, key, roleName)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *backend.ListOptions) *backend.AccessRequestPage); ok {
+		r0 = returnFunc(ctx, namespace, username, opts)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*v1alpha1.AccessRequest)
+			r0 = ret.Get(0).(*backend.AccessRequestPage)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *backend.AccessRequestKey, string) error); ok {
-		r1 = returnFunc(ctx, key, roleName)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *backend.ListOptions) error); ok {
+		r1 = returnFunc(ctx, namespace, username, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockService_GetAccessRequestByRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccessRequestByRole'
-type MockService_GetAccessRequestByRole_Call struct {
+// MockService_ListSubjectAccessRequests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSubjectAccessRequests'
+type MockService_ListSubjectAccessRequests_Call struct {
 	*mock.Call
 }
 
-// GetAccessRequestByRole is a helper method to define mock.On call
+// ListSubjectAccessRequests is a helper method to define mock.On call
 //   - ctx context.Context
-//   - key *backend.AccessRequestKey
-//   - roleName string
-func (_e *MockService_Expecter) GetAccessRequestByRole(ctx interface{}, key interface{}, roleName interface{}) *MockService_GetAccessRequestByRole_Call {
-	return &MockService_GetAccessRequestByRole_Call{Call: _e.mock.On("GetAccessRequestByRole", ctx, key, roleName)}
+//   - namespace string
+//   - username string
+//   - opts *backend.ListOptions
+func (_e *MockService_Expecter) ListSubjectAccessRequests(ctx interface{}, namespace interface{}, username interface{}, opts interface{}) *MockService_ListSubjectAccessRequests_Call {
+	return &MockService_ListSubjectAccessRequests_Call{Call: _e.mock.On("ListSubjectAccessRequests", ctx, namespace, username, opts)}
 }
 
-func (_c *MockService_GetAccessRequestByRole_Call) Run(run func(ctx context.Context, key *backend.AccessRequestKey, roleName string)) *MockService_GetAccessRequestByRole_Call {
+func (_c *MockService_ListSubjectAccessRequests_Call) Run(run func(ctx context.Context, namespace string, username string, opts *backend.ListOptions)) *MockService_ListSubjectAccessRequests_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *backend.AccessRequestKey
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*backend.AccessRequestKey)
+			arg1 = args[1].(string)
 		}
 		var arg2 string
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
+		var arg3 *backend.ListOptions
+		if args[3] != nil {
+			arg3 = args[3].(*backend.ListOptions)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_GetAccessRequestByRole_Call) Return(accessRequest *v1alpha1.AccessRequest, err error) *MockService_GetAccessRequestByRole_Call {
-	_c.Call.Return(accessRequest, err)
+func (_c *MockService_ListSubjectAccessRequests_Call) Return(accessRequestPage *backend.AccessRequestPage, err error) *MockService_ListSubjectAccessRequests_Call {
+	_c.Call.Return(accessRequestPage, err)
 	return _c
 }
 
-func (_c *MockService_GetAccessRequestByRole_Call) RunAndReturn(run func(ctx context.Context, key *backend.AccessRequestKey, roleName string) (*v1alpha1.AccessRequest, error)) *MockService_GetAccessRequestByRole_Call {
+func (_c *MockService_ListSubjectAccessRequests_Call) RunAndReturn(run func(ctx context.Context, namespace string, username string, opts *backend.ListOptions) (*backend.AccessRequestPage, error)) *MockService_ListSubjectAccessRequests_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAppProject provides a mock function for the type MockService
-func (_mock *MockService) GetAppProject(ctx context.Context, name string, namespace string) (*unstructured.Unstructured, error) {
-	ret := _mock.Called(ctx, name, namespace)
+// RevokeAccessRequest provides a mock function for the type MockService
+func (_mock *MockService) RevokeAccessRequest(ctx context.Context, ar *v1alpha1.AccessRequest) (*v1alpha1.AccessRequest, error) {
+	ret := _mock.Called(ctx, ar)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAppProject")
+		panic("no return value specified for RevokeAccessRequest")
 	}
 
-	var r0 *unstructured.Unstructured
+	var r0 *v1alpha1.AccessRequest
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*unstructured.Unstructured, error)); ok {
-		return returnFunc(ctx, name, namespace)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *v1alpha1.AccessRequest) (*v1alpha1.AccessRequest, error)); ok {
+		return returnFunc(ctx, ar)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *unstructured.Unstructured); ok {
-		r0 = returnFunc(ctx, name, namespace)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *v1alpha1.AccessRequest) *v1alpha1.AccessRequest); ok {
+		r0 = returnFunc(ctx, ar)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*unstructured.Unstructured)
+			r0 = ret.Get(0).(*v1alpha1.AccessRequest)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, name, namespace)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *v1alpha1.AccessRequest) error); ok {
+		r1 = returnFunc(ctx, ar)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockService_GetAppProject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAppProject'
-type MockService_GetAppProject_Call struct {
+// MockService_RevokeAccessRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeAccessRequest'
+type MockService_RevokeAccessRequest_Call struct {
 	*mock.Call
 }
 
-// GetAppProject is a helper method to define mock.On call
+// RevokeAccessRequest is a helper method to define mock.On call
 //   - ctx context.Context
-//   - name string
-//   - namespace string
-func (_e *MockService_Expecter) GetAppProject(ctx interface{}, name interface{}, namespace interface{}) *MockService_GetAppProject_Call {
-	return &MockService_GetAppProject_Call{Call: _e.mock.On("GetAppProject", ctx, name, namespace)}
+//   - ar *v1alpha1.AccessRequest
+func (_e *MockService_Expecter) RevokeAccessRequest(ctx interface{}, ar interface{}) *MockService_RevokeAccessRequest_Call {
+	return &MockService_RevokeAccessRequest_Call{Call: _e.mock.On("RevokeAccessRequest", ctx, ar)}
 }
 
-func (_c *MockService_GetAppProject_Call) Run(run func(ctx context.Context, name string, namespace string)) *MockService_GetAppProject_Call {
+func (_c *MockService_RevokeAccessRequest_Call) Run(run func(ctx context.Context, ar *v1alpha1.AccessRequest)) *MockService_RevokeAccessRequest_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *v1alpha1.AccessRequest
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg1 = args[1].(*v1alpha1.AccessRequest)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_GetAppProject_Call) Return(unstructured1 *unstructured.Unstructured, err error) *MockService_GetAppProject_Call {
-	_c.Call.Return(unstructured1, err)
+func (_c *MockService_RevokeAccessRequest_Call) Return(accessRequest *v1alpha1.AccessRequest, err error) *MockService_RevokeAccessRequest_Call {
+	_c.Call.Return(accessRequest, err)
 	return _c
 }
 
-func (_c *MockService_GetAppProject_Call) RunAndReturn(run func(ctx context.Context, name string, namespace string) (*unstructured.Unstructured, error)) *MockService_GetAppProject_Call {
+func (_c *MockService_RevokeAccessRequest_Call) RunAndReturn(run func(ctx context.Context, ar *v1alpha1.AccessRequest) (*v1alpha1.AccessRequest, error)) *MockService_RevokeAccessRequest_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetApplication provides a mock function for the type MockService
-func (_mock *MockService) GetApplication(ctx context.Context, name string, namespace string) (*unstructured.Unstructured, error) {
-	ret := _mock.Called(ctx, name, namespace)
+// SearchAccessRequests provides a mock function for the type MockService
+func (_mock *MockService) SearchAccessRequests(ctx context.Context, namespace string, filter *backend.AccessRequestFilter, opts *backend.ListOptions) (*backend.AccessRequestPage, error) {
+	ret := _mock.Called(ctx, namespace, filter, opts)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetApplication")
+		panic("no return value specified for SearchAccessRequests")
 	}
 
-	var r0 *unstructured.Unstructured
+	var r0 *backend.AccessRequestPage
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*unstructured.Unstructured, error)); ok {
-		return returnFunc(ctx, name, namespace)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *backend.AccessRequestFilter, *backend.ListOptions) (*backend.AccessRequestPage, error)); ok {
+		return returnFunc(ctx, namespace, filter, opts)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *unstructured.Unstructured); ok {
-		r0 = returnFunc(ctx, name, namespace)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *backend.AccessRequestFilter, *backend.ListOptions) *backend.AccessRequestPage); ok {
+		r0 = returnFunc(ctx, namespace, filter, opts)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*unstructured.Unstructured)
+			r0 = ret.Get(0).(*backend.AccessRequestPage)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, name, namespace)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *backend.AccessRequestFilter, *backend.ListOptions) error); ok {
+		r1 = returnFunc(ctx, namespace, filter, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockService_GetApplication_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetApplication'
-type MockService_GetApplication_Call struct {
+// MockService_SearchAccessRequests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchAccessRequests'
+type MockService_SearchAccessRequests_Call struct {
 	*mock.Call
 }
 
-// GetApplication is a helper method to define mock.On call
+// SearchAccessRequests is a helper method to define mock.On call
 //   - ctx context.Context
-//   - name string
 //   - namespace string
-func (_e *MockService_Expecter) GetApplication(ctx interface{}, name interface{}, namespace interface{}) *MockService_GetApplication_Call {
-	return &MockService_GetApplication_Call{Call: _e.mock.On("GetApplication", ctx, name, namespace)}
+//   - filter *backend.AccessRequestFilter
+//   - opts *backend.ListOptions
+func (_e *MockService_Expecter) SearchAccessRequests(ctx interface{}, namespace interface{}, filter interface{}, opts interface{}) *MockService_SearchAccessRequests_Call {
+	return &MockService_SearchAccessRequests_Call{Call: _e.mock.On("SearchAccessRequests", ctx, namespace, filter, opts)}
 }
 
-func (_c *MockService_GetApplication_Call) Run(run func(ctx context.Context, name string, namespace string)) *MockService_GetApplication_Call {
+func (_c *MockService_SearchAccessRequests_Call) Run(run func(ctx context.Context, namespace string, filter *backend.AccessRequestFilter, opts *backend.ListOptions)) *MockService_SearchAccessRequests_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -399,74 +1325,77 @@ func (_c *MockService_GetApplication_Call) Run(run func(ctx context.Context, nam
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
+		var arg2 *backend.AccessRequestFilter
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(*backend.AccessRequestFilter)
+		}
+		var arg3 *backend.ListOptions
+		if args[3] != nil {
+			arg3 = args[3].(*backend.ListOptions)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_GetApplication_Call) Return(unstructured1 *unstructured.Unstructured, err error) *MockService_GetApplication_Call {
-	_c.Call.Return(unstructured1, err)
+func (_c *MockService_SearchAccessRequests_Call) Return(accessRequestPage *backend.AccessRequestPage, err error) *MockService_SearchAccessRequests_Call {
+	_c.Call.Return(accessRequestPage, err)
 	return _c
 }
 
-func (_c *MockService_GetApplication_Call) RunAndReturn(run func(ctx context.Context, name string, namespace string) (*unstructured.Unstructured, error)) *MockService_GetApplication_Call {
+func (_c *MockService_SearchAccessRequests_Call) RunAndReturn(run func(ctx context.Context, namespace string, filter *backend.AccessRequestFilter, opts *backend.ListOptions) (*backend.AccessRequestPage, error)) *MockService_SearchAccessRequests_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetGrantingAccessBinding provides a mock function for the type MockService
-func (_mock *MockService) GetGrantingAccessBinding(ctx context.Context, roleName string, namespace string, groups []string, app *unstructured.Unstructured, project *unstructured.Unstructured) (*v1alpha1.AccessBinding, error) {
-	ret := _mock.Called(ctx, roleName, namespace, groups, app, project)
+// SearchArchivedAccessRequests provides a mock function for the type MockService
+func (_mock *MockService) SearchArchivedAccessRequests(ctx context.Context, namespace string, filter *backend.AccessRequestFilter, opts *backend.ListOptions) (*backend.AccessRequestPage, error) {
+	ret := _mock.Called(ctx, namespace, filter, opts)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetGrantingAccessBinding")
+		panic("no return value specified for SearchArchivedAccessRequests")
 	}
 
-	var r0 *v1alpha1.AccessBinding
+	var r0 *backend.AccessRequestPage
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []string, *unstructured.Unstructured, *unstructured.Unstructured) (*v1alpha1.AccessBinding, error)); ok {
-		return returnFunc(ctx, roleName, namespace, groups, app, project)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *backend.AccessRequestFilter, *backend.ListOptions) (*backend.AccessRequestPage, error)); ok {
+		return returnFunc(ctx, namespace, filter, opts)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []string, *unstructured.Unstructured, *unstructured.Unstructured) *v1alpha1.AccessBinding); ok {
-		r0 = returnFunc(ctx, roleName, namespace, groups, app, project)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *backend.AccessRequestFilter, *backend.ListOptions) *backend.AccessRequestPage); ok {
+		r0 = returnFunc(ctx, namespace, filter, opts)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*v1alpha1.AccessBinding)
+			r0 = ret.Get(0).(*backend.AccessRequestPage)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, []string, *unstructured.Unstructured, *unstructured.Unstructured) error); ok {
-		r1 = returnFunc(ctx, roleName, namespace, groups, app, project)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *backend.AccessRequestFilter, *backend.ListOptions) error); ok {
+		r1 = returnFunc(ctx, namespace, filter, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockService_GetGrantingAccessBinding_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGrantingAccessBinding'
-type MockService_GetGrantingAccessBinding_Call struct {
+// MockService_SearchArchivedAccessRequests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchArchivedAccessRequests'
+type MockService_SearchArchivedAccessRequests_Call struct {
 	*mock.Call
 }
 
-// GetGrantingAccessBinding is a helper method to define mock.On call
+// SearchArchivedAccessRequests is a helper method to define mock.On call
 //   - ctx context.Context
-//   - roleName string
 //   - namespace string
-//   - groups []string
-//   - app *unstructured.Unstructured
-//   - project *unstructured.Unstructured
-func (_e *MockService_Expecter) GetGrantingAccessBinding(ctx interface{}, roleName interface{}, namespace interface{}, groups interface{}, app interface{}, project interface{}) *MockService_GetGrantingAccessBinding_Call {
-	return &MockService_GetGrantingAccessBinding_Call{Call: _e.mock.On("GetGrantingAccessBinding", ctx, roleName, namespace, groups, app, project)}
+//   - filter *backend.AccessRequestFilter
+//   - opts *backend.ListOptions
+func (_e *MockService_Expecter) SearchArchivedAccessRequests(ctx interface{}, namespace interface{}, filter interface{}, opts interface{}) *MockService_SearchArchivedAccessRequests_Call {
+	return &MockService_SearchArchivedAccessRequests_Call{Call: _e.mock.On("SearchArchivedAccessRequests", ctx, namespace, filter, opts)}
 }
 
-func (_c *MockService_GetGrantingAccessBinding_Call) Run(run func(ctx context.Context, roleName string, namespace string, groups []string, app *unstructured.Unstructured, project *unstructured.Unstructured)) *MockService_GetGrantingAccessBinding_Call {
+func (_c *MockService_SearchArchivedAccessRequests_Call) Run(run func(ctx context.Context, namespace string, filter *backend.AccessRequestFilter, opts *backend.ListOptions)) *MockService_SearchArchivedAccessRequests_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -476,98 +1405,88 @@ func (_c *MockService_GetGrantingAccessBinding_Call) Run(run func(ctx context.Co
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
+		var arg2 *backend.AccessRequestFilter
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(*backend.AccessRequestFilter)
 		}
-		var arg3 []string
+		var arg3 *backend.ListOptions
 		if args[3] != nil {
-			arg3 = args[3].([]string)
-		}
-		var arg4 *unstructured.Unstructured
-		if args[4] != nil {
-			arg4 = args[4].(*unstructured.Unstructured)
-		}
-		var arg5 *unstructured.Unstructured
-		if args[5] != nil {
-			arg5 = args[5].(*unstructured.Unstructured)
+			arg3 = args[3].(*backend.ListOptions)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
-			arg4,
-			arg5,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_GetGrantingAccessBinding_Call) Return(accessBinding *v1alpha1.AccessBinding, err error) *MockService_GetGrantingAccessBinding_Call {
-	_c.Call.Return(accessBinding, err)
+func (_c *MockService_SearchArchivedAccessRequests_Call) Return(accessRequestPage *backend.AccessRequestPage, err error) *MockService_SearchArchivedAccessRequests_Call {
+	_c.Call.Return(accessRequestPage, err)
 	return _c
 }
 
-func (_c *MockService_GetGrantingAccessBinding_Call) RunAndReturn(run func(ctx context.Context, roleName string, namespace string, groups []string, app *unstructured.Unstructured, project *unstructured.Unstructured) (*v1alpha1.AccessBinding, error)) *MockService_GetGrantingAccessBinding_Call {
+func (_c *MockService_SearchArchivedAccessRequests_Call) RunAndReturn(run func(ctx context.Context, namespace string, filter *backend.AccessRequestFilter, opts *backend.ListOptions) (*backend.AccessRequestPage, error)) *MockService_SearchArchivedAccessRequests_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListAccessRequests provides a mock function for the type MockService
-func (_mock *MockService) ListAccessRequests(ctx context.Context, key *backend.AccessRequestKey, sort bool) ([]*v1alpha1.AccessRequest, error) {
-	ret := _mock.Called(ctx, key, sort)
+// WatchSubjectAccessRequests provides a mock function for the type MockService
+func (_mock *MockService) WatchSubjectAccessRequests(ctx context.Context, namespace string, username string) (<-chan *v1alpha1.AccessRequest, error) {
+	ret := _mock.Called(ctx, namespace, username)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListAccessRequests")
+		panic("no return value specified for WatchSubjectAccessRequests")
 	}
 
-	var r0 []*v1alpha1.AccessRequest
+	var r0 <-chan *v1alpha1.AccessRequest
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *backend.AccessRequestKey, bool) ([]*v1alpha1.AccessRequest, error)); ok {
-		return returnFunc(ctx, key, sort)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (<-chan *v1alpha1.AccessRequest, error)); ok {
+		return returnFunc(ctx, namespace, username)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *backend.AccessRequestKey, bool) []*v1alpha1.AccessRequest); ok {
-		r0 = returnFunc(ctx, key, sort)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) <-chan *v1alpha1.AccessRequest); ok {
+		r0 = returnFunc(ctx, namespace, username)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*v1alpha1.AccessRequest)
+			r0 = ret.Get(0).(<-chan *v1alpha1.AccessRequest)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *backend.AccessRequestKey, bool) error); ok {
-		r1 = returnFunc(ctx, key, sort)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, namespace, username)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockService_ListAccessRequests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAccessRequests'
-type MockService_ListAccessRequests_Call struct {
+// MockService_WatchSubjectAccessRequests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WatchSubjectAccessRequests'
+type MockService_WatchSubjectAccessRequests_Call struct {
 	*mock.Call
 }
 
-// ListAccessRequests is a helper method to define mock.On call
+// WatchSubjectAccessRequests is a helper method to define mock.On call
 //   - ctx context.Context
-//   - key *backend.AccessRequestKey
-//   - sort bool
-func (_e *MockService_Expecter) ListAccessRequests(ctx interface{}, key interface{}, sort interface{}) *MockService_ListAccessRequests_Call {
-	return &MockService_ListAccessRequests_Call{Call: _e.mock.On("ListAccessRequests", ctx, key, sort)}
+//   - namespace string
+//   - username string
+func (_e *MockService_Expecter) WatchSubjectAccessRequests(ctx interface{}, namespace interface{}, username interface{}) *MockService_WatchSubjectAccessRequests_Call {
+	return &MockService_WatchSubjectAccessRequests_Call{Call: _e.mock.On("WatchSubjectAccessRequests", ctx, namespace, username)}
 }
 
-func (_c *MockService_ListAccessRequests_Call) Run(run func(ctx context.Context, key *backend.AccessRequestKey, sort bool)) *MockService_ListAccessRequests_Call {
+func (_c *MockService_WatchSubjectAccessRequests_Call) Run(run func(ctx context.Context, namespace string, username string)) *MockService_WatchSubjectAccessRequests_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *backend.AccessRequestKey
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*backend.AccessRequestKey)
+			arg1 = args[1].(string)
 		}
-		var arg2 bool
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].(bool)
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
@@ -578,12 +1497,12 @@ func (_c *MockService_ListAccessRequests_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockService_ListAccessRequests_Call) Return(accessRequests []*v1alpha1.AccessRequest, err error) *MockService_ListAccessRequests_Call {
-	_c.Call.Return(accessRequests, err)
+func (_c *MockService_WatchSubjectAccessRequests_Call) Return(accessRequest <-chan *v1alpha1.AccessRequest, err error) *MockService_WatchSubjectAccessRequests_Call {
+	_c.Call.Return(accessRequest, err)
 	return _c
 }
 
-func (_c *MockService_ListAccessRequests_Call) RunAndReturn(run func(ctx context.Context, key *backend.AccessRequestKey, sort bool) ([]*v1alpha1.AccessRequest, error)) *MockService_ListAccessRequests_Call {
+func (_c *MockService_WatchSubjectAccessRequests_Call) RunAndReturn(run func(ctx context.Context, namespace string, username string) (<-chan *v1alpha1.AccessRequest, error)) *MockService_WatchSubjectAccessRequests_Call {
 	_c.Call.Return(run)
 	return _c
 }